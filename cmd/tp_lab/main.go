@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"github.com/HeadHardener/tp_lab/configs"
 	"github.com/HeadHardener/tp_lab/internal/app/handlers"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/HeadHardener/tp_lab/internal/app/models/websocket"
 	"github.com/HeadHardener/tp_lab/internal/app/repositories"
 	"github.com/HeadHardener/tp_lab/internal/app/services"
+	"github.com/HeadHardener/tp_lab/internal/pkg/format"
 	"github.com/HeadHardener/tp_lab/internal/pkg/server"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
 	"go.uber.org/zap"
 	"log"
 	"os/signal"
@@ -38,11 +41,47 @@ func main() {
 		logger.Fatal(fmt.Sprintf("unable to make up conn with db, error: %s", err.Error()))
 	}
 
-	repository := repositories.NewRepository(db)
-	service := services.NewService(repository)
-	handler := handlers.NewHandler(service)
+	serviceConfig, err := configs.NewServiceConfig(*confPath)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("unable to read config file, error: %s", err.Error()))
+	}
+
+	repositoryConfig, err := configs.NewRepositoryConfig(*confPath)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("unable to read config file, error: %s", err.Error()))
+	}
+
+	formatConfig, err := configs.NewFormatConfig(*confPath)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("unable to read config file, error: %s", err.Error()))
+	}
+
+	plateFormats, err := format.Plates.Select(formatConfig.PlateFormats)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("invalid plate_formats value, error: %s", err.Error()))
+	}
+
+	phoneFormats, err := format.Phones.Select(formatConfig.PhoneFormats)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("invalid phone_formats value, error: %s", err.Error()))
+	}
+
+	models.UseFormats(plateFormats, phoneFormats)
+
+	repository := repositories.NewRepository(db, repositoryConfig)
+	attachmentStorage, err := storage.NewLocal(serviceConfig.AttachmentDir)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("unable to open attachment storage, error: %s", err.Error()))
+	}
 
 	hub := ws.NewHub()
+	notifiers := func(orgID int) services.Notifier {
+		return ws.NewNotifier(hub, ws.AlertRoomID(orgID), "system")
+	}
+
+	service := services.NewService(repository, attachmentStorage, notifiers, serviceConfig)
+	handler := handlers.NewHandler(service)
+
 	websocketHandler := handlers.NewWSHandler(hub)
 	go hub.Run()
 
@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"github.com/joho/godotenv"
+	"os"
+	"strings"
+)
+
+const (
+	defaultPlateFormats = "by"
+	defaultPhoneFormats = "by"
+)
+
+type FormatConfig struct {
+	// PlateFormats and PhoneFormats are names of the accepted formats,
+	// values are normalized by the first matching one
+	PlateFormats []string
+	PhoneFormats []string
+}
+
+func NewFormatConfig(path string) (*FormatConfig, error) {
+	err := godotenv.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	plateFormats := os.Getenv("plate_formats")
+	if plateFormats == "" {
+		plateFormats = defaultPlateFormats
+	}
+
+	phoneFormats := os.Getenv("phone_formats")
+	if phoneFormats == "" {
+		phoneFormats = defaultPhoneFormats
+	}
+
+	return &FormatConfig{
+		PlateFormats: splitList(plateFormats),
+		PhoneFormats: splitList(phoneFormats),
+	}, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,47 @@
+package configs
+
+import (
+	"errors"
+	"github.com/joho/godotenv"
+	"os"
+)
+
+// stock shortage policies
+const (
+	StockShortageRefuse = "refuse"
+	StockShortageWarn   = "warn"
+)
+
+type RepositoryConfig struct {
+	// StockShortagePolicy tells what to do with documents which leave the fuel stock below zero:
+	// "refuse" rejects them, "warn" stores them marked with the stock shortage flag
+	StockShortagePolicy string
+	// LimitPolicy is the fuel limit policy of the service config
+	LimitPolicy string
+}
+
+func NewRepositoryConfig(path string) (*RepositoryConfig, error) {
+	err := godotenv.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := os.Getenv("stock_shortage_policy")
+	if policy == "" {
+		policy = StockShortageWarn
+	}
+
+	if policy != StockShortageRefuse && policy != StockShortageWarn {
+		return nil, errors.New("invalid stock_shortage_policy value, allowed: refuse, warn")
+	}
+
+	limitPolicy, err := readLimitPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RepositoryConfig{
+		StockShortagePolicy: policy,
+		LimitPolicy:         limitPolicy,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package configs
+
+import (
+	"errors"
+	"github.com/joho/godotenv"
+	"os"
+	"strconv"
+)
+
+const (
+	defaultWaybillTemplate      = "./configs/waybill.tmpl"
+	defaultTrashRetentionDays   = 30
+	defaultNormTolerancePercent = 10
+	defaultAttachmentDir        = "./attachments"
+	defaultAttachmentMaxSize    = 10 << 20
+	defaultAttachmentTypes      = "image/jpeg,image/png,application/pdf"
+)
+
+// fuel limit policies
+const (
+	LimitRefuse = "refuse"
+	LimitWarn   = "warn"
+)
+
+type ServiceConfig struct {
+	WaybillTemplate string
+	// TrashRetentionDays is how long deleted documents are kept before they can be purged
+	TrashRetentionDays int
+	// NormTolerancePercent is how much the fuel consumption may exceed the vehicle norm before the document is flagged
+	NormTolerancePercent float64
+	// AttachmentDir is the root of the local storage of document attachments
+	AttachmentDir string
+	// AttachmentMaxSize is the limit of a single attachment in bytes
+	AttachmentMaxSize int64
+	// AttachmentTypes are MIME types detected from the content which can be attached
+	AttachmentTypes []string
+	// LimitPolicy tells what to do with documents which take a fuel limit over its amount:
+	// "refuse" rejects them, "warn" stores them marked with the limit exceeded flag
+	LimitPolicy string
+}
+
+func NewServiceConfig(path string) (*ServiceConfig, error) {
+	err := godotenv.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	waybillTemplate := os.Getenv("waybill_template")
+	if waybillTemplate == "" {
+		waybillTemplate = defaultWaybillTemplate
+	}
+
+	trashRetentionDays := defaultTrashRetentionDays
+	if days := os.Getenv("trash_retention_days"); days != "" {
+		trashRetentionDays, err = strconv.Atoi(days)
+		if err != nil || trashRetentionDays < 0 {
+			return nil, errors.New("invalid trash_retention_days value")
+		}
+	}
+
+	normTolerancePercent := float64(defaultNormTolerancePercent)
+	if tolerance := os.Getenv("norm_tolerance_percent"); tolerance != "" {
+		normTolerancePercent, err = strconv.ParseFloat(tolerance, 64)
+		if err != nil || normTolerancePercent < 0 {
+			return nil, errors.New("invalid norm_tolerance_percent value")
+		}
+	}
+
+	attachmentDir := os.Getenv("attachment_dir")
+	if attachmentDir == "" {
+		attachmentDir = defaultAttachmentDir
+	}
+
+	attachmentMaxSize := int64(defaultAttachmentMaxSize)
+	if size := os.Getenv("attachment_max_size"); size != "" {
+		attachmentMaxSize, err = strconv.ParseInt(size, 10, 64)
+		if err != nil || attachmentMaxSize <= 0 {
+			return nil, errors.New("invalid attachment_max_size value")
+		}
+	}
+
+	attachmentTypes := os.Getenv("attachment_types")
+	if attachmentTypes == "" {
+		attachmentTypes = defaultAttachmentTypes
+	}
+
+	limitPolicy, err := readLimitPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceConfig{
+		WaybillTemplate:      waybillTemplate,
+		TrashRetentionDays:   trashRetentionDays,
+		NormTolerancePercent: normTolerancePercent,
+		AttachmentDir:        attachmentDir,
+		AttachmentMaxSize:    attachmentMaxSize,
+		AttachmentTypes:      splitList(attachmentTypes),
+		LimitPolicy:          limitPolicy,
+	}, nil
+}
+
+// readLimitPolicy reads limit_policy which both the service and the repositories follow,
+// the service reports documents over the limit early and the repositories check them once more
+// under the lock of the limit.
+func readLimitPolicy() (string, error) {
+	policy := os.Getenv("limit_policy")
+	if policy == "" {
+		policy = LimitWarn
+	}
+
+	if policy != LimitRefuse && policy != LimitWarn {
+		return "", errors.New("invalid limit_policy value, allowed: refuse, warn")
+	}
+
+	return policy, nil
+}
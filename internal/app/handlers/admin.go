@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/go-chi/chi/v5"
 	"net/http"
@@ -17,13 +18,13 @@ func (h *Handler) createWorker(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := workerInput.Validate(); err != nil {
-		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		h.newInputErrResponse(w, err)
 		return
 	}
 
-	id, err := h.service.Administration.CreateWorker(workerInput)
+	id, err := h.tenant(r).Administration.CreateWorker(workerInput)
 	if err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		h.newErrResponse(w, workerErrStatus(err), err.Error())
 		return
 	}
 
@@ -33,7 +34,7 @@ func (h *Handler) createWorker(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) getAllWorkers(w http.ResponseWriter, r *http.Request) {
-	workers, err := h.service.Administration.GetAll()
+	workers, err := h.tenant(r).Administration.GetAll()
 	if err != nil {
 		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -49,12 +50,13 @@ func (h *Handler) getWorkerByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	worker, err := h.service.Administration.GetByID(workerID)
+	worker, err := h.tenant(r).Administration.GetByID(workerID)
 	if err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		h.newErrResponse(w, workerErrStatus(err), err.Error())
 		return
 	}
 
+	setETag(w, worker.Version)
 	newResponse(w, http.StatusOK, worker)
 }
 
@@ -66,14 +68,29 @@ func (h *Handler) updateWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := workerInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
 	workerID, err := strconv.Atoi(chi.URLParam(r, "worker_id"))
 	if err != nil {
 		h.newErrResponse(w, http.StatusBadRequest, "invalid worker_id param")
 		return
 	}
 
-	if err := h.service.Administration.UpdateWorker(workerID, workerInput); err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.newErrResponse(w, versionErrStatus(err), err.Error())
+		return
+	}
+
+	if err := h.tenant(r).Administration.UpdateWorker(workerID, version, workerInput); err != nil {
+		if errors.Is(err, models.ErrVersionMismatch) {
+			h.workerPreconditionFailed(w, r, workerID)
+			return
+		}
+		h.newErrResponse(w, workerErrStatus(err), err.Error())
 		return
 	}
 
@@ -81,3 +98,14 @@ func (h *Handler) updateWorker(w http.ResponseWriter, r *http.Request) {
 		"status": "updated",
 	})
 }
+
+func workerErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrWorkerNotFound), errors.Is(err, models.ErrOrganizationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrDepartmentNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
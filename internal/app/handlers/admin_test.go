@@ -28,13 +28,13 @@ func TestHandler_createWorker(t *testing.T) {
 	}{
 		{
 			name: "ok",
-			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+111 11 111-11-11", "role":"worker",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+375 11 111-11-11", "role":"worker",
 						"password":"12345"}`,
 			inputWorker: models.CreateWorkerInput{
 				Name:        "Test",
 				Surname:     "Tested",
 				FathersName: "Tester",
-				Phone:       "+111 11 111-11-11",
+				Phone:       "+375 11 111-11-11",
 				Role:        "worker",
 				Password:    "12345",
 			},
@@ -56,35 +56,37 @@ func TestHandler_createWorker(t *testing.T) {
 				Role:        "worker",
 				Password:    "12345",
 			},
-			mockBehavior:         func(s *mock_services.MockAdministration, worker models.CreateWorkerInput) {},
-			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: "{\"message\":\"empty or invalid phone number\"}\n",
+			mockBehavior:       func(s *mock_services.MockAdministration, worker models.CreateWorkerInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"phone\",\"code\":\"invalid\",\"message\":\"invalid phone number\"}]}\n",
 		},
 		{
 			name: "invalid role",
-			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+111 11 111-11-11", "role":"boss",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+375 11 111-11-11", "role":"boss",
 						"password":"12345"}`,
 			inputWorker: models.CreateWorkerInput{
 				Name:        "Test",
 				Surname:     "Tested",
 				FathersName: "Tester",
-				Phone:       "+111 11 111-11-11",
+				Phone:       "+375 11 111-11-11",
 				Role:        "boss",
 				Password:    "12345",
 			},
-			mockBehavior:         func(s *mock_services.MockAdministration, worker models.CreateWorkerInput) {},
-			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: "{\"message\":\"invalid role\"}\n",
+			mockBehavior:       func(s *mock_services.MockAdministration, worker models.CreateWorkerInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"role\",\"code\":\"invalid\",\"message\":\"invalid role\"}]}\n",
 		},
 		{
 			name: "service failure",
-			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+111 11 111-11-11", "role":"worker",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester", "phone":"+375 11 111-11-11", "role":"worker",
 						"password":"12345"}`,
 			inputWorker: models.CreateWorkerInput{
 				Name:        "Test",
 				Surname:     "Tested",
 				FathersName: "Tester",
-				Phone:       "+111 11 111-11-11",
+				Phone:       "+375 11 111-11-11",
 				Role:        "worker",
 				Password:    "12345",
 			},
@@ -140,14 +142,14 @@ func TestHandler_getWorkerByID(t *testing.T) {
 					Name:         "Test",
 					Surname:      "Tested",
 					FathersName:  "Tester",
-					Phone:        "+111 11 111-11-11",
+					Phone:        "+375 11 111-11-11",
 					Role:         "worker",
 					PasswordHash: "hash",
 				}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponseBody: fmt.Sprintf("{\"id\":1,\"name\":\"Test\"," +
-				"\"surname\":\"Tested\",\"fathers_name\":\"Tester\",\"phone\":\"+111 11 111-11-11\"," +
+				"\"surname\":\"Tested\",\"fathers_name\":\"Tester\",\"phone\":\"+375 11 111-11-11\"," +
 				"\"role\":\"worker\",\"password_hash\":\"hash\"}\n"),
 		},
 		{
@@ -157,6 +159,15 @@ func TestHandler_getWorkerByID(t *testing.T) {
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: fmt.Sprintf("{\"message\":\"invalid worker_id param\"}\n"),
 		},
+		{
+			name:     "worker of another organization",
+			workerID: 2,
+			mockBehavior: func(s *mock_services.MockAdministration, workerID any) {
+				s.EXPECT().GetByID(workerID).Return(models.Worker{}, models.ErrWorkerNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: fmt.Sprintf("{\"message\":\"worker doesn't exist\"}\n"),
+		},
 		{
 			name:     "service failure",
 			workerID: 1,
@@ -192,3 +203,99 @@ func TestHandler_getWorkerByID(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_updateWorker(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockAdministration, input models.UpdateWorkerInput)
+
+	name := "Renamed"
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		ifMatch              string
+		input                models.UpdateWorkerInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"name":"Renamed"}`,
+			ifMatch:   `"2"`,
+			input:     models.UpdateWorkerInput{Name: &name},
+			mockBehavior: func(s *mock_services.MockAdministration, input models.UpdateWorkerInput) {
+				s.EXPECT().UpdateWorker(1, 2, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"updated\"}\n",
+		},
+		{
+			name:                 "missing If-Match",
+			inputBody:            `{"name":"Renamed"}`,
+			mockBehavior:         func(s *mock_services.MockAdministration, input models.UpdateWorkerInput) {},
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedResponseBody: "{\"message\":\"If-Match header is required\"}\n",
+		},
+		{
+			name:               "invalid phone",
+			inputBody:          `{"name":"", "phone":"12345"}`,
+			ifMatch:            `"2"`,
+			mockBehavior:       func(s *mock_services.MockAdministration, input models.UpdateWorkerInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"name\",\"code\":\"required\",\"message\":\"name can't be empty\"}," +
+				"{\"field\":\"phone\",\"code\":\"invalid\",\"message\":\"invalid phone number\"}]}\n",
+		},
+		{
+			name:      "stale version",
+			inputBody: `{"name":"Renamed"}`,
+			ifMatch:   `"2"`,
+			input:     models.UpdateWorkerInput{Name: &name},
+			mockBehavior: func(s *mock_services.MockAdministration, input models.UpdateWorkerInput) {
+				s.EXPECT().UpdateWorker(1, 2, input).Return(models.ErrVersionMismatch)
+				s.EXPECT().GetByID(1).Return(models.Worker{
+					ID:          1,
+					Name:        "Test",
+					Surname:     "Tested",
+					FathersName: "Tester",
+					Phone:       "+375 11 111-11-11",
+					Role:        "worker",
+					Version:     3,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedETag:       `"3"`,
+			expectedResponseBody: "{\"id\":1,\"name\":\"Test\",\"surname\":\"Tested\",\"fathers_name\":\"Tester\"," +
+				"\"phone\":\"+375 11 111-11-11\",\"role\":\"worker\",\"password_hash\":\"\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			adm := mock_services.NewMockAdministration(c)
+			tc.mockBehavior(adm, tc.input)
+
+			service := &services.Service{Administration: adm}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Put("/api/admin/worker/update/{worker_id}", handler.updateWorker)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "/api/admin/worker/update/1", bytes.NewBufferString(tc.inputBody))
+			if tc.ifMatch != "" {
+				r.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
+	"github.com/go-chi/chi/v5"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	part, err := filePart(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer part.Close()
+
+	attachment, err := h.tenant(r).DocumentAccess.Upload(worker, docID, part.FileName(), part)
+	if err != nil {
+		h.newErrResponse(w, attachmentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, attachment)
+}
+
+func (h *Handler) getAttachments(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachments, err := h.tenant(r).DocumentAccess.Attachments(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, attachmentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, attachments)
+}
+
+func (h *Handler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	docID, attachmentID, err := attachmentParams(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachment, content, err := h.tenant(r).DocumentAccess.Download(worker, docID, attachmentID)
+	if err != nil {
+		h.newErrResponse(w, attachmentErrStatus(err), err.Error())
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	w.Header().Set("ETag", fmt.Sprintf("%q", attachment.Checksum))
+
+	if _, err := io.Copy(w, content); err != nil {
+		h.errLogger.Error(err.Error())
+	}
+}
+
+func (h *Handler) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	docID, attachmentID, err := attachmentParams(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).DocumentAccess.DeleteAttachment(worker, docID, attachmentID); err != nil {
+		h.newErrResponse(w, attachmentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+// filePart returns the "file" part of the multipart request, the part is streamed
+// instead of being buffered, so large files don't have to fit in memory.
+func filePart(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.New("multipart/form-data request is expected")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.New("file field is required")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+func attachmentParams(r *http.Request) (int, int, error) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		return 0, 0, errors.New("invalid document_id param")
+	}
+
+	attachmentID, err := strconv.Atoi(chi.URLParam(r, "attachment_id"))
+	if err != nil {
+		return 0, 0, errors.New("invalid attachment_id param")
+	}
+
+	return docID, attachmentID, nil
+}
+
+func attachmentErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound), errors.Is(err, models.ErrAttachmentNotFound),
+		errors.Is(err, storage.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrNotEnoughRights):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrAttachmentEmpty):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, models.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler_uploadAttachment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes)
+
+	worker := models.WorkerAttributes{ID: 3, Role: "worker"}
+	createdAt := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		fieldName            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			fieldName: "file",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().Upload(worker, 1, "receipt.pdf", gomock.Any()).Return(models.Attachment{
+					ID:          7,
+					DocumentID:  1,
+					FileName:    "receipt.pdf",
+					ContentType: "application/pdf",
+					Size:        9,
+					Checksum:    "a1b2",
+					UploadedBy:  intPtr(3),
+					CreatedAt:   createdAt,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: "{\"id\":7,\"document_id\":1,\"file_name\":\"receipt.pdf\"," +
+				"\"content_type\":\"application/pdf\",\"size\":9,\"checksum\":\"a1b2\"," +
+				"\"uploaded_by\":3,\"created_at\":\"2023-03-14T10:00:00Z\"}\n",
+		},
+		{
+			name:                 "no file field",
+			fieldName:            "document",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"file field is required\"}\n",
+		},
+		{
+			name:      "too large",
+			fieldName: "file",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().Upload(worker, 1, "receipt.pdf", gomock.Any()).Return(models.Attachment{}, models.ErrAttachmentTooLarge)
+			},
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedResponseBody: "{\"message\":\"attachment is too large\"}\n",
+		},
+		{
+			name:      "type isn't allowed",
+			fieldName: "file",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().Upload(worker, 1, "receipt.pdf", gomock.Any()).Return(models.Attachment{}, models.ErrAttachmentType)
+			},
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: "{\"message\":\"attachment type isn't allowed\"}\n",
+		},
+		{
+			name:      "another's document",
+			fieldName: "file",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().Upload(worker, 1, "receipt.pdf", gomock.Any()).Return(models.Attachment{}, models.ErrNotEnoughRights)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"" + models.ErrNotEnoughRights.Error() + "\"}\n",
+		},
+		{
+			name:      "document out of scope",
+			fieldName: "file",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().Upload(worker, 1, "receipt.pdf", gomock.Any()).Return(models.Attachment{}, models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			access := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(access, worker)
+
+			service := &services.Service{DocumentAccess: access}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/gsm/{document_id}/attachments", handler.uploadAttachment)
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			part, _ := writer.CreateFormFile(tc.fieldName, "receipt.pdf")
+			part.Write([]byte("%PDF-1.4\n"))
+			writer.Close()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/gsm/1/attachments", body)
+			r.Header.Set("Content-Type", writer.FormDataContentType())
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_downloadAttachment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedHeaders      map[string]string
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockDocumentAccess) {
+				s.EXPECT().Download(worker, 1, 7).Return(models.Attachment{
+					ID:          7,
+					DocumentID:  1,
+					FileName:    "receipt.pdf",
+					ContentType: "application/pdf",
+					Size:        9,
+					Checksum:    "a1b2",
+				}, io.NopCloser(strings.NewReader("%PDF-1.4\n")), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Content-Type":        "application/pdf",
+				"Content-Length":      "9",
+				"Content-Disposition": "attachment; filename=\"receipt.pdf\"",
+				"ETag":                "\"a1b2\"",
+			},
+			expectedResponseBody: "%PDF-1.4\n",
+		},
+		{
+			name: "attachment not found",
+			mockBehavior: func(s *mock_services.MockDocumentAccess) {
+				s.EXPECT().Download(worker, 1, 7).Return(models.Attachment{}, nil, models.ErrAttachmentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"attachment doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService)
+
+			service := &services.Service{DocumentAccess: accessService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/gsm/{document_id}/attachments/{attachment_id}", handler.downloadAttachment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/gsm/1/attachments/7", bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			for key, value := range tc.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteAttachment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes)
+
+	worker := models.WorkerAttributes{ID: 3, Role: "worker"}
+
+	testTable := []struct {
+		name                 string
+		url                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			url:  "/api/gsm/1/attachments/7",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().DeleteAttachment(worker, 1, 7).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name:                 "invalid attachment_id",
+			url:                  "/api/gsm/1/attachments/receipt",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid attachment_id param\"}\n",
+		},
+		{
+			name: "attachment not found",
+			url:  "/api/gsm/1/attachments/7",
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes) {
+				s.EXPECT().DeleteAttachment(worker, 1, 7).Return(models.ErrAttachmentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"attachment doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			access := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(access, worker)
+
+			service := &services.Service{DocumentAccess: access}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/gsm/{document_id}/attachments/{attachment_id}", handler.deleteAttachment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", tc.url, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
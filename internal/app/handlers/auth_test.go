@@ -47,9 +47,24 @@ func TestHandler_signIn(t *testing.T) {
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: "{\"message\":\"name fields can't be empty\"}\n",
 		},
+		{
+			name:      "normalized phone",
+			inputBody: `{"name":"Vitali", "surname":"Tsaal", "phone":"375 (44) 5711905", "password":"12345"}`,
+			inputWorker: models.LogWorkerInput{
+				Name:     "Vitali",
+				Surname:  "Tsaal",
+				Phone:    "+375 44 571-19-05",
+				Password: "12345",
+			},
+			mockBehavior: func(s *mock_services.MockAuthorization, worker models.LogWorkerInput) {
+				s.EXPECT().GenerateToken(worker).Return("token", nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"token\":\"token\"}\n",
+		},
 		{
 			name:                 "invalid phone",
-			inputBody:            `{"name":"Vitali", "surname":"Tsaal", "phone":"+375 44571-19-05", "password":"12345"}`,
+			inputBody:            `{"name":"Vitali", "surname":"Tsaal", "phone":"+375 44 571-19", "password":"12345"}`,
 			mockBehavior:         func(s *mock_services.MockAuthorization, worker models.LogWorkerInput) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: "{\"message\":\"empty or invalid phone number\"}\n",
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	var commentInput models.CommentInput
+
+	if err := json.NewDecoder(r.Body).Decode(&commentInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := commentInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comment, err := h.tenant(r).Comments.Create(worker, docID, commentInput)
+	if err != nil {
+		h.newErrResponse(w, commentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, comment)
+}
+
+func (h *Handler) getComments(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comments, err := h.tenant(r).Comments.GetAll(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, commentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, comments)
+}
+
+func (h *Handler) updateComment(w http.ResponseWriter, r *http.Request) {
+	docID, commentID, err := commentParams(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var commentInput models.CommentInput
+
+	if err := json.NewDecoder(r.Body).Decode(&commentInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := commentInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).Comments.Update(worker, docID, commentID, commentInput); err != nil {
+		h.newErrResponse(w, commentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "updated",
+	})
+}
+
+func (h *Handler) deleteComment(w http.ResponseWriter, r *http.Request) {
+	docID, commentID, err := commentParams(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).Comments.Delete(worker, docID, commentID); err != nil {
+		h.newErrResponse(w, commentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func commentParams(r *http.Request) (int, int, error) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		return 0, 0, errors.New("invalid document_id param")
+	}
+
+	commentID, err := strconv.Atoi(chi.URLParam(r, "comment_id"))
+	if err != nil {
+		return 0, 0, errors.New("invalid comment_id param")
+	}
+
+	return docID, commentID, nil
+}
+
+func commentErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound), errors.Is(err, models.ErrCommentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrNotEnoughRights):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_createComment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockComments, comment models.CommentInput)
+
+	worker := models.WorkerAttributes{ID: 2, Role: models.RoleDispatcher, Name: "Test Tested"}
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputComment         models.CommentInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "ok",
+			inputBody:    `{"body":" @3 please check the odometer "}`,
+			inputComment: models.CommentInput{Body: "@3 please check the odometer"},
+			mockBehavior: func(s *mock_services.MockComments, comment models.CommentInput) {
+				s.EXPECT().Create(worker, 1, comment).Return(models.Comment{
+					ID:         5,
+					DocumentID: 1,
+					WorkerID:   &worker.ID,
+					Author:     worker.Name,
+					Body:       comment.Body,
+					CreatedAt:  time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponseBody: "{\"id\":5,\"document_id\":1,\"worker_id\":2,\"author\":\"Test Tested\"," +
+				"\"body\":\"@3 please check the odometer\",\"created_at\":\"2023-01-02T09:00:00Z\",\"updated_at\":null}\n",
+		},
+		{
+			name:               "empty body",
+			inputBody:          `{"body":"  "}`,
+			mockBehavior:       func(s *mock_services.MockComments, comment models.CommentInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"body\",\"code\":\"required\",\"message\":\"empty comment\"}]}\n",
+		},
+		{
+			name:         "document out of scope",
+			inputBody:    `{"body":"why so much?"}`,
+			inputComment: models.CommentInput{Body: "why so much?"},
+			mockBehavior: func(s *mock_services.MockComments, comment models.CommentInput) {
+				s.EXPECT().Create(worker, 1, comment).Return(models.Comment{}, models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			commentService := mock_services.NewMockComments(c)
+			tc.mockBehavior(commentService, tc.inputComment)
+
+			service := &services.Service{Comments: commentService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/gsm/{document_id}/comments", handler.createComment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/gsm/1/comments", bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateComment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockComments)
+
+	worker := models.WorkerAttributes{ID: 2, Role: models.RoleWorker, Name: "Test Tested"}
+
+	testTable := []struct {
+		name                 string
+		url                  string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			url:       "/api/gsm/1/comments/5",
+			inputBody: `{"body":"@3 @4 please check the odometer"}`,
+			mockBehavior: func(s *mock_services.MockComments) {
+				s.EXPECT().Update(worker, 1, 5, models.CommentInput{Body: "@3 @4 please check the odometer"}).
+					Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"updated\"}\n",
+		},
+		{
+			name:                 "invalid comment_id",
+			url:                  "/api/gsm/1/comments/bad_id",
+			inputBody:            `{"body":"fixed"}`,
+			mockBehavior:         func(s *mock_services.MockComments) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid comment_id param\"}\n",
+		},
+		{
+			name:      "not the author",
+			url:       "/api/gsm/1/comments/6",
+			inputBody: `{"body":"fixed"}`,
+			mockBehavior: func(s *mock_services.MockComments) {
+				s.EXPECT().Update(worker, 1, 6, models.CommentInput{Body: "fixed"}).Return(models.ErrNotEnoughRights)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"you don't have enough rights\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			commentService := mock_services.NewMockComments(c)
+			tc.mockBehavior(commentService)
+
+			service := &services.Service{Comments: commentService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Put("/api/gsm/{document_id}/comments/{comment_id}", handler.updateComment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", tc.url, bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteComment(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockComments)
+
+	admin := models.WorkerAttributes{ID: 1, Role: models.RoleAdmin, Name: "Admin Tested"}
+
+	testTable := []struct {
+		name                 string
+		url                  string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			url:  "/api/gsm/1/comments/5",
+			mockBehavior: func(s *mock_services.MockComments) {
+				s.EXPECT().Delete(admin, 1, 5).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name: "comment of another document",
+			url:  "/api/gsm/2/comments/5",
+			mockBehavior: func(s *mock_services.MockComments) {
+				s.EXPECT().Delete(admin, 2, 5).Return(models.ErrCommentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"comment doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			commentService := mock_services.NewMockComments(c)
+			tc.mockBehavior(commentService)
+
+			service := &services.Service{Comments: commentService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/gsm/{document_id}/comments/{comment_id}", handler.deleteComment)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", tc.url, nil)
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, admin))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
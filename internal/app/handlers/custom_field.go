@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createCustomField(w http.ResponseWriter, r *http.Request) {
+	var fieldInput models.CreateCustomFieldInput
+
+	if err := json.NewDecoder(r.Body).Decode(&fieldInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := fieldInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.tenant(r).CustomFields.Create(fieldInput)
+	if err != nil {
+		h.newErrResponse(w, customFieldErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllCustomFields(w http.ResponseWriter, r *http.Request) {
+	fields, err := h.tenant(r).CustomFields.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, fields)
+}
+
+func (h *Handler) deleteCustomField(w http.ResponseWriter, r *http.Request) {
+	fieldID, err := strconv.Atoi(chi.URLParam(r, "field_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid field_id param")
+		return
+	}
+
+	if err := h.tenant(r).CustomFields.Delete(fieldID); err != nil {
+		h.newErrResponse(w, customFieldErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func customFieldErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrCustomFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrCustomFieldExists), errors.Is(err, models.ErrCustomFieldReferenced):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
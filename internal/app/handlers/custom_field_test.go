@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createCustomField(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockCustomFields, field models.CreateCustomFieldInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputField           models.CreateCustomFieldInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"code":"shift", "name":"Shift", "type":"enum", "options":["day","night"], "required":true}`,
+			inputField: models.CreateCustomFieldInput{
+				Code:     "shift",
+				Name:     "Shift",
+				Type:     models.CustomEnum,
+				Options:  []string{"day", "night"},
+				Required: true,
+			},
+			mockBehavior: func(s *mock_services.MockCustomFields, field models.CreateCustomFieldInput) {
+				s.EXPECT().Create(field).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:               "invalid definition",
+			inputBody:          `{"code":"Card No", "name":"Fuel card", "type":"number", "options":["1"]}`,
+			mockBehavior:       func(s *mock_services.MockCustomFields, field models.CreateCustomFieldInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"code\",\"code\":\"invalid\"," +
+				"\"message\":\"code has to start with a letter and contain only a-z, 0-9 and _\"}," +
+				"{\"field\":\"options\",\"code\":\"invalid\",\"message\":\"options can be set only for enum fields\"}]}\n",
+		},
+		{
+			name:      "field exists",
+			inputBody: `{"code":"card", "name":"Fuel card", "type":"string"}`,
+			inputField: models.CreateCustomFieldInput{
+				Code: "card",
+				Name: "Fuel card",
+				Type: models.CustomString,
+			},
+			mockBehavior: func(s *mock_services.MockCustomFields, field models.CreateCustomFieldInput) {
+				s.EXPECT().Create(field).Return(0, models.ErrCustomFieldExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"custom field with this code already exists\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			fieldService := mock_services.NewMockCustomFields(c)
+			tc.mockBehavior(fieldService, tc.inputField)
+
+			service := &services.Service{CustomFields: fieldService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/custom-fields", handler.createCustomField)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/custom-fields", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
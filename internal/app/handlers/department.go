@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"net/http"
+)
+
+func (h *Handler) createDepartment(w http.ResponseWriter, r *http.Request) {
+	var departmentInput models.CreateDepartmentInput
+
+	if err := json.NewDecoder(r.Body).Decode(&departmentInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := departmentInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.tenant(r).Departments.Create(departmentInput)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, models.ErrDepartmentExists) {
+			status = http.StatusConflict
+		}
+		h.newErrResponse(w, status, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllDepartments(w http.ResponseWriter, r *http.Request) {
+	departments, err := h.tenant(r).Departments.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, departments)
+}
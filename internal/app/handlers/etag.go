@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setETag sends the version of the record, updates have to pass it back in the If-Match header.
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", fmt.Sprintf("%q", strconv.Itoa(version)))
+}
+
+// ifMatchVersion returns the version from the If-Match header, weak tags are accepted too.
+func ifMatchVersion(r *http.Request) (int, error) {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if tag == "" {
+		return 0, models.ErrVersionRequired
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(tag, "W/"), `"`))
+	if err != nil {
+		return 0, errors.New("invalid If-Match header")
+	}
+
+	return version, nil
+}
+
+func versionErrStatus(err error) int {
+	if errors.Is(err, models.ErrVersionRequired) {
+		return http.StatusPreconditionRequired
+	}
+
+	return http.StatusBadRequest
+}
+
+// documentPreconditionFailed responds with the current state of the document after a stale update.
+func (h *Handler) documentPreconditionFailed(w http.ResponseWriter, r *http.Request, docID int) {
+	document, err := h.tenant(r).GSMInterface.GetByID(docID)
+	if err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	setETag(w, document.Version)
+	newResponse(w, http.StatusPreconditionFailed, document)
+}
+
+// workerPreconditionFailed responds with the current state of the worker after a stale update.
+func (h *Handler) workerPreconditionFailed(w http.ResponseWriter, r *http.Request, workerID int) {
+	worker, err := h.tenant(r).Administration.GetByID(workerID)
+	if err != nil {
+		h.newErrResponse(w, workerErrStatus(err), err.Error())
+		return
+	}
+
+	setETag(w, worker.Version)
+	newResponse(w, http.StatusPreconditionFailed, worker)
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/pkg/export"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type exportColumn[T any] struct {
+	name  string
+	value func(T) interface{}
+}
+
+var documentColumns = []exportColumn[models.Document]{
+	{"id", func(d models.Document) interface{} { return d.ID }},
+	{"car", func(d models.Document) interface{} { return d.Car }},
+	{"car_id", func(d models.Document) interface{} { return d.CarID }},
+	{"vehicle_id", func(d models.Document) interface{} { return d.VehicleID }},
+	{"department_id", func(d models.Document) interface{} { return d.DepartmentID }},
+	{"waybill", func(d models.Document) interface{} { return d.Waybill }},
+	{"driver_name", func(d models.Document) interface{} { return d.DriverName }},
+	{"gas_amount", func(d models.Document) interface{} { return d.GasAmount }},
+	{"gas_type", func(d models.Document) interface{} { return d.GasType }},
+	{"issue_date", func(d models.Document) interface{} { return time.Time(d.IssueDate) }},
+	{"status", func(d models.Document) interface{} { return d.Status }},
+	{"odometer_start", func(d models.Document) interface{} { return d.OdometerStart }},
+	{"odometer_end", func(d models.Document) interface{} { return d.OdometerEnd }},
+	{"mileage", func(d models.Document) interface{} { return d.Mileage }},
+	{"consumption", func(d models.Document) interface{} { return d.Consumption }},
+	{"norm_exceeded", func(d models.Document) interface{} { return d.NormExceeded }},
+	{"limit_exceeded", func(d models.Document) interface{} { return d.LimitExceeded }},
+	{"cost", func(d models.Document) interface{} { return d.Cost }},
+	{"tags", func(d models.Document) interface{} { return strings.Join(d.Tags, ", ") }},
+}
+
+var workerColumns = []exportColumn[models.Worker]{
+	{"id", func(w models.Worker) interface{} { return w.ID }},
+	{"name", func(w models.Worker) interface{} { return w.Name }},
+	{"surname", func(w models.Worker) interface{} { return w.Surname }},
+	{"fathers_name", func(w models.Worker) interface{} { return w.FathersName }},
+	{"phone", func(w models.Worker) interface{} { return w.Phone }},
+	{"role", func(w models.Worker) interface{} { return w.Role }},
+	{"department_id", func(w models.Worker) interface{} { return w.DepartmentID }},
+}
+
+func (h *Handler) exportDocuments(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDocumentFilter(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	columns, err := pickColumns(documentColumns, r.URL.Query().Get("columns"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writer, err := newExportWriter(w, r, "documents", "gas_amount")
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = writeTable(writer, columns, func(fn func(models.Document) error) error {
+		return h.tenant(r).DocumentAccess.Export(worker, filter, fn)
+	})
+	if err != nil {
+		// the status code is already sent, so the error can only be logged
+		h.errLogger.Error(err.Error())
+	}
+}
+
+func (h *Handler) exportWorkers(w http.ResponseWriter, r *http.Request) {
+	columns, err := pickColumns(workerColumns, r.URL.Query().Get("columns"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writer, err := newExportWriter(w, r, "workers")
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := writeTable(writer, columns, h.tenant(r).Administration.ExportWorkers); err != nil {
+		h.errLogger.Error(err.Error())
+	}
+}
+
+// newExportWriter returns writer of the format requested by the format query param,
+// totalColumns are summed up by the formats supporting totals.
+func newExportWriter(w http.ResponseWriter, r *http.Request, name string, totalColumns ...string) (export.Writer, error) {
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+		delimiter, err := export.ParseDelimiter(r.URL.Query().Get("delimiter"))
+		if err != nil {
+			return nil, err
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+
+		return export.NewCSVWriter(w, delimiter), nil
+	case "xlsx":
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xlsx"`, name))
+
+		return export.NewXLSXWriter(w, name, totalColumns...)
+	default:
+		return nil, errors.New("invalid format param")
+	}
+}
+
+// pickColumns returns columns listed in the comma separated param in the given order,
+// all columns are returned if param is empty.
+func pickColumns[T any](all []exportColumn[T], param string) ([]exportColumn[T], error) {
+	if param == "" {
+		return all, nil
+	}
+
+	var columns []exportColumn[T]
+	for _, name := range strings.Split(param, ",") {
+		found := false
+		for _, column := range all {
+			if column.name == strings.TrimSpace(name) {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+
+	return columns, nil
+}
+
+// writeTable writes header and every row produced by source, rows are written
+// as soon as source yields them, so nothing is kept in memory.
+func writeTable[T any](writer export.Writer, columns []exportColumn[T], source func(func(T) error) error) error {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+
+	if err := writer.WriteHeader(header); err != nil {
+		return err
+	}
+
+	err := source(func(item T) error {
+		values := make([]interface{}, len(columns))
+		for i, column := range columns {
+			values[i] = column.value(item)
+		}
+
+		return writer.WriteRow(values)
+	})
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_exportDocuments(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	documents := []models.Document{
+		{ID: 1, CarID: "1111 AA-1", Waybill: 1111, DriverName: "Test", GasAmount: 10, IssueDate: toMyTime("2023-01-01")},
+		{ID: 2, CarID: "2222 BB-2", Waybill: 2222, DriverName: "Test, Jr", GasAmount: 20, IssueDate: toMyTime("2023-01-02")},
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		filter               models.DocumentFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "ok",
+			query:  "?columns=id,car_id,driver_name,issue_date",
+			filter: models.DocumentFilter{Limit: models.DefaultPageLimit},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().Export(worker, filter, gomock.Any()).DoAndReturn(
+					func(worker models.WorkerAttributes, filter models.DocumentFilter, fn func(models.Document) error) error {
+						for _, document := range documents {
+							if err := fn(document); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "id,car_id,driver_name,issue_date\n" +
+				"1,1111 AA-1,Test,2023-01-01\n" +
+				"2,2222 BB-2,\"Test, Jr\",2023-01-02\n",
+		},
+		{
+			name:   "delimiter",
+			query:  "?columns=waybill,gas_amount&delimiter=%3B&gas_type=95",
+			filter: models.DocumentFilter{GasType: "95", Limit: models.DefaultPageLimit},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().Export(worker, filter, gomock.Any()).DoAndReturn(
+					func(worker models.WorkerAttributes, filter models.DocumentFilter, fn func(models.Document) error) error {
+						return fn(documents[0])
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "waybill;gas_amount\n1111;10\n",
+		},
+		{
+			name:                 "unknown column",
+			query:                "?columns=id,password_hash",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"unknown column \\\"password_hash\\\"\"}\n",
+		},
+		{
+			name:                 "invalid delimiter",
+			query:                "?delimiter=x",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"unsupported delimiter \\\"x\\\"\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService, tc.filter)
+
+			service := &services.Service{DocumentAccess: accessService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/gsm/export", handler.exportDocuments)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/gsm/export"+tc.query, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
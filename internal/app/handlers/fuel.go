@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+func (h *Handler) createFuel(w http.ResponseWriter, r *http.Request) {
+	var fuelInput models.CreateFuelInput
+
+	if err := json.NewDecoder(r.Body).Decode(&fuelInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := fuelInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).FuelInterface.Create(fuelInput); err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"code": fuelInput.Code,
+	})
+}
+
+func (h *Handler) getAllFuels(w http.ResponseWriter, r *http.Request) {
+	fuels, err := h.tenant(r).FuelInterface.GetAll(false)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, fuels)
+}
+
+func (h *Handler) getActiveFuels(w http.ResponseWriter, r *http.Request) {
+	fuels, err := h.tenant(r).FuelInterface.GetAll(true)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, fuels)
+}
+
+func (h *Handler) getFuelByCode(w http.ResponseWriter, r *http.Request) {
+	fuel, err := h.tenant(r).FuelInterface.GetByCode(chi.URLParam(r, "code"))
+	if err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, fuel)
+}
+
+func (h *Handler) updateFuel(w http.ResponseWriter, r *http.Request) {
+	var fuelInput models.UpdateFuelInput
+
+	if err := json.NewDecoder(r.Body).Decode(&fuelInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).FuelInterface.Update(chi.URLParam(r, "code"), fuelInput); err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "updated",
+	})
+}
+
+func (h *Handler) deleteFuel(w http.ResponseWriter, r *http.Request) {
+	if err := h.tenant(r).FuelInterface.Delete(chi.URLParam(r, "code")); err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func (h *Handler) getFuelPrices(w http.ResponseWriter, r *http.Request) {
+	prices, err := h.tenant(r).FuelInterface.GetPrices(chi.URLParam(r, "code"))
+	if err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, prices)
+}
+
+func (h *Handler) setFuelPrice(w http.ResponseWriter, r *http.Request) {
+	var priceInput models.FuelPriceInput
+
+	if err := json.NewDecoder(r.Body).Decode(&priceInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := priceInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.tenant(r).FuelInterface.SetPrice(chi.URLParam(r, "code"), priceInput)
+	if err != nil {
+		h.newErrResponse(w, fuelErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func fuelErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrFuelNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrFuelExists), errors.Is(err, models.ErrFuelReferenced):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
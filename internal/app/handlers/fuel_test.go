@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createFuel(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockFuelInterface, fuel models.CreateFuelInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputFuel            models.CreateFuelInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"code":"95", "name":"AI-95", "unit":"l"}`,
+			inputFuel: models.CreateFuelInput{Code: "95", Name: "AI-95", Unit: "l"},
+			mockBehavior: func(s *mock_services.MockFuelInterface, fuel models.CreateFuelInput) {
+				s.EXPECT().Create(fuel).Return(nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"code\":\"95\"}\n",
+		},
+		{
+			name:                 "invalid code",
+			inputBody:            `{"code":"AI 95", "name":"AI-95", "unit":"l"}`,
+			mockBehavior:         func(s *mock_services.MockFuelInterface, fuel models.CreateFuelInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid code\"}\n",
+		},
+		{
+			name:      "code taken",
+			inputBody: `{"code":"95", "name":"AI-95", "unit":"l"}`,
+			inputFuel: models.CreateFuelInput{Code: "95", Name: "AI-95", Unit: "l"},
+			mockBehavior: func(s *mock_services.MockFuelInterface, fuel models.CreateFuelInput) {
+				s.EXPECT().Create(fuel).Return(models.ErrFuelExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"fuel type with this code already exists\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			fuelService := mock_services.NewMockFuelInterface(c)
+			tc.mockBehavior(fuelService, tc.inputFuel)
+
+			service := &services.Service{FuelInterface: fuelService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/superadmin/fuel/", handler.createFuel)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/superadmin/fuel/", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_setFuelPrice(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockFuelInterface, price models.FuelPriceInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputPrice           models.FuelPriceInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "ok",
+			inputBody:  `{"price":2.45, "effective_from":"2023-01-01"}`,
+			inputPrice: models.FuelPriceInput{Price: 2.45, EffectiveFrom: toMyTime("2023-01-01")},
+			mockBehavior: func(s *mock_services.MockFuelInterface, price models.FuelPriceInput) {
+				s.EXPECT().SetPrice("95", price).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:                 "empty effective_from",
+			inputBody:            `{"price":2.45}`,
+			mockBehavior:         func(s *mock_services.MockFuelInterface, price models.FuelPriceInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"empty effective_from\"}\n",
+		},
+		{
+			name:                 "negative price",
+			inputBody:            `{"price":-1, "effective_from":"2023-01-01"}`,
+			mockBehavior:         func(s *mock_services.MockFuelInterface, price models.FuelPriceInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"price can't be less than zero\"}\n",
+		},
+		{
+			name:       "unknown fuel",
+			inputBody:  `{"price":2.45, "effective_from":"2023-01-01"}`,
+			inputPrice: models.FuelPriceInput{Price: 2.45, EffectiveFrom: toMyTime("2023-01-01")},
+			mockBehavior: func(s *mock_services.MockFuelInterface, price models.FuelPriceInput) {
+				s.EXPECT().SetPrice("95", price).Return(0, models.ErrFuelNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"fuel type doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			fuelService := mock_services.NewMockFuelInterface(c)
+			tc.mockBehavior(fuelService, tc.inputPrice)
+
+			service := &services.Service{FuelInterface: fuelService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/fuel/{code}/prices", handler.setFuelPrice)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/fuel/95/prices", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteFuel(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockFuelInterface)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockFuelInterface) {
+				s.EXPECT().Delete("95").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name: "referenced",
+			mockBehavior: func(s *mock_services.MockFuelInterface) {
+				s.EXPECT().Delete("95").Return(models.ErrFuelReferenced)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"fuel type is referenced by documents, deactivate it instead\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			fuelService := mock_services.NewMockFuelInterface(c)
+			tc.mockBehavior(fuelService)
+
+			service := &services.Service{FuelInterface: fuelService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/superadmin/fuel/{code}", handler.deleteFuel)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "/api/superadmin/fuel/95", bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
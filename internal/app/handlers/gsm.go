@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/go-chi/chi/v5"
 	"net/http"
@@ -17,7 +19,7 @@ func (h *Handler) createDocument(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := docInput.Validate(); err != nil {
-		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		h.newInputErrResponse(w, err)
 		return
 	}
 
@@ -27,9 +29,13 @@ func (h *Handler) createDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	docID, err := h.service.GSMInterface.Create(workerID, docInput)
+	docID, err := h.tenant(r).GSMInterface.Create(workerID, docInput)
 	if err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		if errors.As(err, new(*models.ValidationError)) {
+			h.newInputErrResponse(w, err)
+			return
+		}
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
 		return
 	}
 
@@ -39,7 +45,19 @@ func (h *Handler) createDocument(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) getAllDocuments(w http.ResponseWriter, r *http.Request) {
-	documents, err := h.service.GSMInterface.GetAll()
+	filter, err := parseDocumentFilter(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	documents, err := h.tenant(r).DocumentAccess.GetAll(worker, filter)
 	if err != nil {
 		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -55,15 +73,47 @@ func (h *Handler) getDocumentByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	document, err := h.service.GSMInterface.GetByID(docID)
+	worker, err := getWorkerAttributes(r)
 	if err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	document, err := h.tenant(r).DocumentAccess.GetByID(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
 		return
 	}
 
+	setETag(w, document.Version)
 	newResponse(w, http.StatusOK, document)
 }
 
+func (h *Handler) getDocumentPDF(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := h.tenant(r).DocumentAccess.WaybillPDF(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="waybill_%d.pdf"`, docID))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file)
+}
+
 func (h *Handler) getDocumentsWithWorkerID(w http.ResponseWriter, r *http.Request) {
 	workerID, err := getWorkerID(r)
 	if err != nil {
@@ -71,7 +121,13 @@ func (h *Handler) getDocumentsWithWorkerID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	documents, err := h.service.GSMInterface.GetAllWithID(workerID)
+	filter, err := parseDocumentFilter(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	documents, err := h.tenant(r).GSMInterface.GetAllWithID(workerID, filter)
 	if err != nil {
 		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
 		return
@@ -88,14 +144,86 @@ func (h *Handler) updateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := docInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
 	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
 	if err != nil {
 		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
 		return
 	}
 
-	if err := h.service.GSMInterface.Update(docID, docInput); err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.newErrResponse(w, versionErrStatus(err), err.Error())
+		return
+	}
+
+	if err := h.tenant(r).GSMInterface.Update(docID, workerID, version, docInput); err != nil {
+		switch {
+		case errors.Is(err, models.ErrVersionMismatch):
+			h.documentPreconditionFailed(w, r, docID)
+		case errors.As(err, new(*models.ValidationError)):
+			h.newInputErrResponse(w, err)
+		default:
+			h.newErrResponse(w, documentErrStatus(err), err.Error())
+		}
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "updated",
+	})
+}
+
+func (h *Handler) updateOwnDocument(w http.ResponseWriter, r *http.Request) {
+	var docInput models.UpdateDocInput
+
+	if err := json.NewDecoder(r.Body).Decode(&docInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := docInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.newErrResponse(w, versionErrStatus(err), err.Error())
+		return
+	}
+
+	if err := h.tenant(r).DocumentAccess.UpdateOwn(worker, docID, version, docInput); err != nil {
+		switch {
+		case errors.Is(err, models.ErrVersionMismatch):
+			h.documentPreconditionFailed(w, r, docID)
+		case errors.As(err, new(*models.ValidationError)):
+			h.newInputErrResponse(w, err)
+		default:
+			h.newErrResponse(w, documentErrStatus(err), err.Error())
+		}
 		return
 	}
 
@@ -111,8 +239,14 @@ func (h *Handler) deleteDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.GSMInterface.Delete(docID); err != nil {
-		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).GSMInterface.Delete(docID, workerID); err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
 		return
 	}
 
@@ -120,3 +254,23 @@ func (h *Handler) deleteDocument(w http.ResponseWriter, r *http.Request) {
 		"status": "deleted",
 	})
 }
+
+func documentErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound), errors.Is(err, models.ErrRevisionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrVehicleNotFound), errors.Is(err, models.ErrVehicleInactive),
+		errors.Is(err, models.ErrFuelNotFound), errors.Is(err, models.ErrFuelInactive),
+		errors.Is(err, models.ErrOdometerDecreased):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrNotEnoughRights):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrWaybillTaken), errors.Is(err, models.ErrWaybillsExhausted),
+		errors.Is(err, models.ErrTransitionNotAllowed),
+		errors.Is(err, models.ErrDocumentReadOnly), errors.Is(err, models.ErrStockShortage),
+		errors.Is(err, models.ErrPeriodClosed), errors.Is(err, models.ErrLimitExceeded):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
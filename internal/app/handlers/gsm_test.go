@@ -22,6 +22,10 @@ var toMyTime = func(ts string) models.MyTime {
 	return models.MyTime(t)
 }
 
+var intPtr = func(i int) *int {
+	return &i
+}
+
 func TestHandler_createDocument(t *testing.T) {
 	type mockBehavior func(s *mock_services.MockGSMInterface, document models.CreateDocInput)
 
@@ -36,11 +40,10 @@ func TestHandler_createDocument(t *testing.T) {
 	}{
 		{
 			name: "ok",
-			inputBody: `{"car":"test_car", "car_id":"1111 AA-1", "waybill": 1111, "driver_name":"test_name", 
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
 						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
 			inputDocument: models.CreateDocInput{
-				Car:        "test_car",
-				CarID:      "1111 AA-1",
+				VehicleID:  1,
 				Waybill:    1111,
 				DriverName: "test_name",
 				GasAmount:  1,
@@ -59,38 +62,236 @@ func TestHandler_createDocument(t *testing.T) {
 			expectedResponseBody: "{\"documentID\":1}\n",
 		},
 		{
-			name: "invalid car_id",
-			inputBody: `{"car":"test_car", "car_id":"111 AA-1", "waybill": 1111, "driver_name":"test_name", 
+			name: "allocated waybill",
+			inputBody: `{"vehicle_id":1, "driver_name":"test_name", 
 						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:  1,
+				DriverName: "test_name",
+				GasAmount:  1,
+				GasType:    "95",
+				IssueDate:  toMyTime("2023-01-01"),
+			},
 			workerAtr: models.WorkerAttributes{
 				ID:   1,
 				Role: "admin",
 				Name: "Test",
 			},
-			mockBehavior:         func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
-			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: "{\"message\":\"invalid car_id\"}\n",
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"documentID\":1}\n",
+		},
+		{
+			name: "invalid vehicle_id",
+			inputBody: `{"vehicle_id":0, "waybill": 1111, "driver_name":"test_name", 
+						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior:       func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"vehicle_id\",\"code\":\"invalid\",\"message\":\"invalid vehicle_id\"}]}\n",
 		},
 		{
 			name: "invalid waybill",
-			inputBody: `{"car":"test_car", "car_id":"1111 AA-1", "waybill": 111, "driver_name":"test_name", 
+			inputBody: `{"vehicle_id":1, "waybill": 111, "driver_name":"test_name", 
+						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior:       func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"waybill\",\"code\":\"out_of_range\",\"message\":\"invalid waybill value\"}]}\n",
+		},
+		{
+			name:      "several invalid fields",
+			inputBody: `{"vehicle_id":0, "waybill": 1, "gas_amount": 0, "issue_date":"2023-01-01"}`,
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior:       func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"vehicle_id\",\"code\":\"invalid\",\"message\":\"invalid vehicle_id\"}," +
+				"{\"field\":\"waybill\",\"code\":\"out_of_range\",\"message\":\"invalid waybill value\"}," +
+				"{\"field\":\"driver_name\",\"code\":\"required\",\"message\":\"empty driver_name\"}," +
+				"{\"field\":\"gas_amount\",\"code\":\"out_of_range\",\"message\":\"gas_amount can't be less than zero\"}," +
+				"{\"field\":\"gas_type\",\"code\":\"required\",\"message\":\"empty gas_type\"}]}\n",
+		},
+		{
+			name: "invalid odometer",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", "gas_amount": 1,
+						"gas_type":"95", "issue_date":"2023-01-01", "odometer_start": 1500, "odometer_end": 1200}`,
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior:       func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"odometer_end\",\"code\":\"out_of_range\",\"message\":\"odometer_end can't be less than odometer_start\"}]}\n",
+		},
+		{
+			name: "odometer decreased",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", "gas_amount": 1,
+						"gas_type":"95", "issue_date":"2023-01-01", "odometer_start": 1200, "odometer_end": 1500}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:     1,
+				Waybill:       1111,
+				DriverName:    "test_name",
+				GasAmount:     1,
+				GasType:       "95",
+				IssueDate:     toMyTime("2023-01-01"),
+				OdometerStart: intPtr(1200),
+				OdometerEnd:   intPtr(1500),
+			},
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.ErrOdometerDecreased)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"odometer_start can't be less than " +
+				"odometer_end of the vehicle's previous waybill\"}\n",
+		},
+		{
+			name: "unknown custom field",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", "gas_amount": 1,
+						"gas_type":"95", "issue_date":"2023-01-01", "tags": ["urgent"], "custom_fields": {"card": "12"}}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:    1,
+				Waybill:      1111,
+				DriverName:   "test_name",
+				GasAmount:    1,
+				GasType:      "95",
+				IssueDate:    toMyTime("2023-01-01"),
+				Tags:         []string{"urgent"},
+				CustomFields: models.CustomValues{"card": "12"},
+			},
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.CheckCustomValues(nil, document.CustomFields))
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"custom_fields.card\",\"code\":\"invalid\",\"message\":\"unknown custom field\"}]}\n",
+		},
+		{
+			name: "unknown vehicle",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
 						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:  1,
+				Waybill:    1111,
+				DriverName: "test_name",
+				GasAmount:  1,
+				GasType:    "95",
+				IssueDate:  toMyTime("2023-01-01"),
+			},
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.ErrVehicleNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"vehicle doesn't exist\"}\n",
+		},
+		{
+			name: "unknown gas type",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
+						"gas_amount": 1, "gas_type":"AI-100", "issue_date":"2023-01-01"}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:  1,
+				Waybill:    1111,
+				DriverName: "test_name",
+				GasAmount:  1,
+				GasType:    "AI-100",
+				IssueDate:  toMyTime("2023-01-01"),
+			},
 			workerAtr: models.WorkerAttributes{
 				ID:   1,
 				Role: "admin",
 				Name: "Test",
 			},
-			mockBehavior:         func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.ErrFuelNotFound)
+			},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: "{\"message\":\"invalid waybill value\"}\n",
+			expectedResponseBody: "{\"message\":\"fuel type doesn't exist\"}\n",
+		},
+		{
+			name: "waybill taken",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
+						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:  1,
+				Waybill:    1111,
+				DriverName: "test_name",
+				GasAmount:  1,
+				GasType:    "95",
+				IssueDate:  toMyTime("2023-01-01"),
+			},
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.ErrWaybillTaken)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"waybill number is already used in this year\"}\n",
+		},
+		{
+			name: "limit exceeded",
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
+						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
+			inputDocument: models.CreateDocInput{
+				VehicleID:  1,
+				Waybill:    1111,
+				DriverName: "test_name",
+				GasAmount:  1,
+				GasType:    "95",
+				IssueDate:  toMyTime("2023-01-01"),
+			},
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "admin",
+				Name: "Test",
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, document models.CreateDocInput) {
+				s.EXPECT().Create(1, document).Return(0, models.ErrLimitExceeded)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"document exceeds the fuel limit\"}\n",
 		},
 		{
 			name: "service failure",
-			inputBody: `{"car":"test_car", "car_id":"1111 AA-1", "waybill": 1111, "driver_name":"test_name", 
+			inputBody: `{"vehicle_id":1, "waybill": 1111, "driver_name":"test_name", 
 						"gas_amount": 1, "gas_type":"95", "issue_date":"2023-01-01"}`,
 			inputDocument: models.CreateDocInput{
-				Car:        "test_car",
-				CarID:      "1111 AA-1",
+				VehicleID:  1,
 				Waybill:    1111,
 				DriverName: "test_name",
 				GasAmount:  1,
@@ -137,7 +338,11 @@ func TestHandler_createDocument(t *testing.T) {
 }
 
 func TestHandler_getDocumentByID(t *testing.T) {
-	type mockBehavior func(s *mock_services.MockGSMInterface, docID any)
+	type mockBehavior func(s *mock_services.MockDocumentAccess, docID any)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	cost := 2.45
 
 	testTable := []struct {
 		name                 string
@@ -149,35 +354,48 @@ func TestHandler_getDocumentByID(t *testing.T) {
 		{
 			name:  "ok",
 			docID: 1,
-			mockBehavior: func(s *mock_services.MockGSMInterface, docID any) {
-				s.EXPECT().GetByID(docID).Return(models.Document{
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().GetByID(worker, docID).Return(models.Document{
 					ID:         1,
 					Car:        "test_car",
 					CarID:      "1111 AA-1",
+					VehicleID:  1,
 					Waybill:    1111,
 					DriverName: "test_name",
 					GasAmount:  1,
 					GasType:    "95",
 					IssueDate:  toMyTime("2023-01-01"),
+					Status:     models.StatusApproved,
+					Cost:       &cost,
 				}, nil)
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponseBody: fmt.Sprintf("{\"ID\":1,\"car\":\"test_car\"," +
-				"\"car_id\":\"1111 AA-1\",\"waybill\":1111,\"driver_name\":\"test_name\"," +
-				"\"gas_amount\":1,\"gas_type\":\"95\",\"issue_date\":\"2023-01-01\"}\n"),
+				"\"car_id\":\"1111 AA-1\",\"vehicle_id\":1,\"waybill\":1111,\"driver_name\":\"test_name\"," +
+				"\"gas_amount\":1,\"gas_type\":\"95\",\"issue_date\":\"2023-01-01\",\"status\":\"approved\"," +
+				"\"cost\":2.45}\n"),
 		},
 		{
 			name:                 "invalid document_id param",
 			docID:                "bad_id",
-			mockBehavior:         func(s *mock_services.MockGSMInterface, docID any) {},
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, docID any) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: "{\"message\":\"invalid document_id param\"}\n",
 		},
+		{
+			name:  "document out of scope",
+			docID: 2,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().GetByID(worker, docID).Return(models.Document{}, models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
 		{
 			name:  "service failure",
 			docID: 1,
-			mockBehavior: func(s *mock_services.MockGSMInterface, docID any) {
-				s.EXPECT().GetByID(docID).Return(models.Document{}, errors.New("service failure"))
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().GetByID(worker, docID).Return(models.Document{}, errors.New("service failure"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: "{\"message\":\"service failure\"}\n",
@@ -189,10 +407,10 @@ func TestHandler_getDocumentByID(t *testing.T) {
 			c := gomock.NewController(t)
 			defer c.Finish()
 
-			gsmService := mock_services.NewMockGSMInterface(c)
-			tc.mockBehavior(gsmService, tc.docID)
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService, tc.docID)
 
-			service := &services.Service{GSMInterface: gsmService}
+			service := &services.Service{DocumentAccess: accessService}
 			handler := NewHandler(service)
 
 			router := chi.NewRouter()
@@ -200,11 +418,208 @@ func TestHandler_getDocumentByID(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest("GET", fmt.Sprintf("/api/gsm/%v", tc.docID), bytes.NewBufferString(""))
-			// r = r.WithContext(context.WithValue(r.Context(), workerCtx, tc.workerAtr))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getAllDocuments(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	issuedFrom := toMyTime("2023-01-01")
+
+	testTable := []struct {
+		name                 string
+		query                string
+		filter               models.DocumentFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			query: "?gas_type=95&issue_date_from=2023-01-01&sort=-issue_date&limit=1",
+			filter: models.DocumentFilter{
+				GasType:    "95",
+				IssuedFrom: &issuedFrom,
+				Sort:       "-issue_date",
+				Limit:      1,
+			},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().GetAll(worker, filter).Return(models.DocumentList{
+					Documents: []models.Document{{
+						ID:         1,
+						Car:        "test_car",
+						CarID:      "1111 AA-1",
+						VehicleID:  1,
+						Waybill:    1111,
+						DriverName: "test_name",
+						GasAmount:  1,
+						GasType:    "95",
+						IssueDate:  toMyTime("2023-01-01"),
+						Status:     models.StatusApproved,
+					}},
+					Total:         2,
+					NextPageToken: "MQ",
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"documents\":[{\"ID\":1,\"car\":\"test_car\"," +
+				"\"car_id\":\"1111 AA-1\",\"vehicle_id\":1,\"waybill\":1111,\"driver_name\":\"test_name\"," +
+				"\"gas_amount\":1,\"gas_type\":\"95\",\"issue_date\":\"2023-01-01\",\"status\":\"approved\"}]," +
+				"\"total\":2,\"next_page_token\":\"MQ\"}\n",
+		},
+		{
+			name:  "tags and custom fields",
+			query: "?tag=urgent&tag=night&field.card=12",
+			filter: models.DocumentFilter{
+				Tags:         []string{"urgent", "night"},
+				CustomFields: models.CustomValues{"card": "12"},
+				Limit:        models.DefaultPageLimit,
+			},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().GetAll(worker, filter).Return(models.DocumentList{Documents: []models.Document{}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"documents\":[],\"total\":0}\n",
+		},
+		{
+			name:  "page token",
+			query: "?page_token=MQ",
+			filter: models.DocumentFilter{
+				Limit:  models.DefaultPageLimit,
+				Offset: 1,
+			},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().GetAll(worker, filter).Return(models.DocumentList{Documents: []models.Document{}, Total: 1}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"documents\":[],\"total\":1}\n",
+		},
+		{
+			name:                 "invalid sort",
+			query:                "?sort=password",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid sort field\"}\n",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?issue_date_to=01.01.2023",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid issue_date_to param\"}\n",
+		},
+		{
+			name:   "service failure",
+			query:  "",
+			filter: models.DocumentFilter{Limit: models.DefaultPageLimit},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, filter models.DocumentFilter) {
+				s.EXPECT().GetAll(worker, filter).Return(models.DocumentList{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService, tc.filter)
+
+			service := &services.Service{DocumentAccess: accessService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/gsm/", handler.getAllDocuments)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/gsm/"+tc.query, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getDocumentPDF(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, docID any)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	testTable := []struct {
+		name                 string
+		docID                any
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			docID: 1,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().WaybillPDF(worker, docID).Return([]byte("%PDF-1.4"), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/pdf",
+			expectedResponseBody: "%PDF-1.4",
+		},
+		{
+			name:                 "invalid document_id param",
+			docID:                "bad_id",
+			mockBehavior:         func(s *mock_services.MockDocumentAccess, docID any) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid document_id param\"}\n",
+		},
+		{
+			name:  "service failure",
+			docID: 1,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().WaybillPDF(worker, docID).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService, tc.docID)
+
+			service := &services.Service{DocumentAccess: accessService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/gsm/{document_id}/pdf", handler.getDocumentPDF)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", fmt.Sprintf("/api/gsm/%v/pdf", tc.docID), bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
 
 			router.ServeHTTP(w, r)
 
 			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			if tc.expectedContentType != "" {
+				assert.Equal(t, tc.expectedContentType, w.Header().Get("Content-Type"))
+			}
 			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
 		})
 	}
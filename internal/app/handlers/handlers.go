@@ -47,7 +47,8 @@ func InitRoutes(h *Handler, wsh *WebSocketHandler) http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match"},
+		ExposedHeaders:   []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -58,18 +59,85 @@ func InitRoutes(h *Handler, wsh *WebSocketHandler) http.Handler {
 			r.Post("/sign-in", h.signIn)
 		})
 
+		r.Route("/superadmin", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Use(h.checkSuperAdmin)
+			r.Route("/organizations", func(r chi.Router) {
+				r.Post("/", h.createOrganization)
+				r.Get("/", h.getAllOrganizations)
+				r.Post("/{org_id}/workers", h.createOrganizationWorker)
+			})
+			// the fuel catalog is shared by all organizations, they set only their own prices
+			r.Route("/fuel", func(r chi.Router) {
+				r.Post("/", h.createFuel)
+				r.Put("/{code}", h.updateFuel)
+				r.Delete("/{code}", h.deleteFuel)
+			})
+		})
+
 		r.Route("/admin", func(r chi.Router) {
 			r.Use(h.identifyUser)
 			r.Use(h.checkRole)
 			r.Route("/worker", func(r chi.Router) {
 				r.Post("/sign-up", h.createWorker)
 				r.Get("/get-all/", h.getAllWorkers)
+				r.Get("/export", h.exportWorkers)
 				r.Get("/get/{worker_id}", h.getWorkerByID)
 				r.Put("/update/{worker_id}", h.updateWorker)
 			})
 			r.Route("/gsm", func(r chi.Router) {
+				r.Get("/trash", h.getTrash)
+				r.Delete("/trash", h.purgeTrash)
 				r.Put("/{document_id}", h.updateDocument)
 				r.Delete("/{document_id}", h.deleteDocument)
+				r.Post("/{document_id}/restore", h.restoreDocument)
+				r.Post("/{document_id}/history/{revision_id}/restore", h.restoreRevision)
+			})
+			r.Route("/vehicle", func(r chi.Router) {
+				r.Post("/", h.createVehicle)
+				r.Get("/", h.getAllVehicles)
+				r.Get("/{vehicle_id}", h.getVehicleByID)
+				r.Put("/{vehicle_id}", h.updateVehicle)
+				r.Delete("/{vehicle_id}", h.deleteVehicle)
+			})
+			r.Route("/department", func(r chi.Router) {
+				r.Post("/", h.createDepartment)
+				r.Get("/", h.getAllDepartments)
+			})
+			r.Route("/tags", func(r chi.Router) {
+				r.Post("/", h.createTag)
+				r.Get("/", h.getAllTags)
+				r.Delete("/{tag_id}", h.deleteTag)
+			})
+			r.Route("/custom-fields", func(r chi.Router) {
+				r.Post("/", h.createCustomField)
+				r.Get("/", h.getAllCustomFields)
+				r.Delete("/{field_id}", h.deleteCustomField)
+			})
+			r.Route("/limits", func(r chi.Router) {
+				r.Post("/", h.createLimit)
+				r.Get("/", h.getAllLimits)
+				r.Get("/dashboard", h.getLimitsDashboard)
+				r.Delete("/{limit_id}", h.deleteLimit)
+			})
+			r.Route("/fuel", func(r chi.Router) {
+				r.Get("/", h.getAllFuels)
+				r.Get("/{code}", h.getFuelByCode)
+				r.Get("/{code}/prices", h.getFuelPrices)
+				r.Post("/{code}/prices", h.setFuelPrice)
+			})
+			r.Route("/stock", func(r chi.Router) {
+				r.Post("/receipts", h.createReceipt)
+				r.Get("/movements", h.getStockMovements)
+			})
+			r.Route("/waybill", func(r chi.Router) {
+				r.Get("/sequences", h.getWaybillSequences)
+				r.Put("/sequences/{year}", h.resetWaybillSequence)
+			})
+			r.Route("/periods", func(r chi.Router) {
+				r.Get("/", h.getPeriods)
+				r.Post("/{period}/close", h.closePeriod)
+				r.Post("/{period}/reopen", h.reopenPeriod)
 			})
 		})
 
@@ -82,14 +150,61 @@ func InitRoutes(h *Handler, wsh *WebSocketHandler) http.Handler {
 
 		r.Route("/gsm", func(r chi.Router) {
 			r.Use(h.identifyUser)
+			r.Use(h.denyAuditorWrites)
 			r.Post("/", h.createDocument)
 			r.Get("/", h.getAllDocuments)
+			r.Get("/export", h.exportDocuments)
+			r.Post("/import", h.importDocuments)
 			r.Get("/{document_id}", h.getDocumentByID)
+			r.Put("/{document_id}", h.updateOwnDocument)
+			r.Post("/{document_id}/status", h.changeDocumentStatus)
+			r.Get("/{document_id}/transitions", h.getDocumentTransitions)
+			r.Get("/{document_id}/pdf", h.getDocumentPDF)
+			r.Get("/{document_id}/history", h.getDocumentHistory)
+			r.Post("/{document_id}/attachments", h.uploadAttachment)
+			r.Get("/{document_id}/attachments", h.getAttachments)
+			r.Get("/{document_id}/attachments/{attachment_id}", h.downloadAttachment)
+			r.Delete("/{document_id}/attachments/{attachment_id}", h.deleteAttachment)
+			r.Get("/{document_id}/comments", h.getComments)
+			r.Post("/{document_id}/comments", h.createComment)
+			r.Put("/{document_id}/comments/{comment_id}", h.updateComment)
+			r.Delete("/{document_id}/comments/{comment_id}", h.deleteComment)
 			r.Get("/my", h.getDocumentsWithWorkerID)
 		})
 
+		r.Route("/vehicle", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Get("/", h.getActiveVehicles)
+		})
+
+		r.Route("/fuel", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Get("/", h.getActiveFuels)
+		})
+
+		r.Route("/tags", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Get("/", h.getAllTags)
+		})
+
+		r.Route("/custom-fields", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Get("/", h.getAllCustomFields)
+		})
+
+		r.Route("/stock", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Get("/balance", h.getStockBalances)
+		})
+
+		r.Route("/reports", func(r chi.Router) {
+			r.Use(h.identifyUser)
+			r.Use(h.checkReader)
+			r.Get("/fuel", h.getFuelReport)
+		})
+
 		r.Route("/chat", func(r chi.Router) {
-			// r.Use(h.identifyUser)
+			r.Use(h.identifyUser)
 			r.Post("/create-room", wsh.createRoom)
 			r.Get("/join-room/{room_id}", wsh.joinRoom)
 			r.Get("/get-rooms", wsh.getRooms)
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) getDocumentHistory(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	revisions, err := h.tenant(r).DocumentAccess.History(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, revisions)
+}
+
+func (h *Handler) restoreRevision(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revision_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid revision_id param")
+		return
+	}
+
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).GSMInterface.RestoreRevision(docID, revisionID, workerID); err != nil {
+		if errors.As(err, new(*models.ValidationError)) {
+			h.newInputErrResponse(w, err)
+			return
+		}
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "restored",
+	})
+}
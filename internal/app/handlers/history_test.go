@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getDocumentHistory(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, docID any)
+
+	worker := models.WorkerAttributes{ID: 1, Role: models.RoleWorker, Name: "Test"}
+
+	workerID := 2
+	old := models.Document{ID: 1, VehicleID: 1, Waybill: 1111, GasAmount: 10, IssueDate: toMyTime("2023-01-01")}
+	updated := old
+	updated.GasAmount = 20
+
+	testTable := []struct {
+		name                 string
+		docID                any
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			docID: 1,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().History(worker, docID).Return(models.Timeline([]models.Revision{{
+					ID:         3,
+					DocumentID: 1,
+					WorkerID:   &workerID,
+					CreatedAt:  time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC),
+					Changes:    models.DiffDocuments(old, updated),
+				}}, []models.Comment{{
+					ID:         5,
+					DocumentID: 1,
+					WorkerID:   &workerID,
+					Author:     "Test Tested",
+					Body:       "@1 why 20?",
+					CreatedAt:  time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC),
+				}}), nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "[{\"kind\":\"comment\",\"at\":\"2023-01-02T09:00:00Z\"," +
+				"\"comment\":{\"id\":5,\"document_id\":1,\"worker_id\":2,\"author\":\"Test Tested\"," +
+				"\"body\":\"@1 why 20?\",\"created_at\":\"2023-01-02T09:00:00Z\",\"updated_at\":null}}," +
+				"{\"kind\":\"revision\",\"at\":\"2023-01-02T10:00:00Z\",\"revision\":{\"id\":3,\"document_id\":1,\"worker_id\":2," +
+				"\"old_values\":{\"ID\":0,\"car\":\"\",\"car_id\":\"\",\"vehicle_id\":0,\"waybill\":0,\"driver_name\":\"\"," +
+				"\"gas_amount\":0,\"gas_type\":\"\",\"issue_date\":\"0001-01-01\",\"status\":\"\"}," +
+				"\"new_values\":{\"ID\":0,\"car\":\"\",\"car_id\":\"\",\"vehicle_id\":0,\"waybill\":0,\"driver_name\":\"\"," +
+				"\"gas_amount\":0,\"gas_type\":\"\",\"issue_date\":\"0001-01-01\",\"status\":\"\"}," +
+				"\"created_at\":\"2023-01-02T10:00:00Z\",\"changes\":[{\"field\":\"gas_amount\",\"old\":10,\"new\":20}]}}]\n",
+		},
+		{
+			name:  "not found",
+			docID: 1,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, docID any) {
+				s.EXPECT().History(worker, docID).Return(nil, models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			accessService := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(accessService, tc.docID)
+
+			service := &services.Service{DocumentAccess: accessService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/gsm/{document_id}/history", handler.getDocumentHistory)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", fmt.Sprintf("/api/gsm/%v/history", tc.docID), bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_restoreRevision(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			path: "/api/admin/gsm/1/history/3/restore",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().RestoreRevision(1, 3, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"restored\"}\n",
+		},
+		{
+			name: "foreign revision",
+			path: "/api/admin/gsm/1/history/4/restore",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().RestoreRevision(1, 4, 2).Return(models.ErrRevisionNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"revision doesn't exist\"}\n",
+		},
+		{
+			name: "tag removed since the revision",
+			path: "/api/admin/gsm/1/history/3/restore",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				validationErr := &models.ValidationError{}
+				validationErr.Add("tags", models.CodeInvalid, "unknown tag urgent")
+				s.EXPECT().RestoreRevision(1, 3, 2).Return(validationErr)
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"tags\",\"code\":\"invalid\",\"message\":\"unknown tag urgent\"}]}\n",
+		},
+		{
+			name:                 "invalid revision_id param",
+			path:                 "/api/admin/gsm/1/history/bad_id/restore",
+			mockBehavior:         func(s *mock_services.MockGSMInterface) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid revision_id param\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/gsm/{document_id}/history/{revision_id}/restore", handler.restoreRevision)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", tc.path, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 2, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
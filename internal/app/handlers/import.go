@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/pkg/export"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxImportSize = 10 << 20
+
+// importColumns are required, waybill and odometer columns may be omitted as well as
+// the tag column with the tags separated by commas and field.<code> columns of custom fields
+var importColumns = []string{"vehicle_id", "driver_name", "gas_amount", "gas_type", "issue_date"}
+
+func (h *Handler) importDocuments(w http.ResponseWriter, r *http.Request) {
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	delimiter, err := export.ParseDelimiter(r.URL.Query().Get("delimiter"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	body, err := importBody(w, r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer body.Close()
+
+	rows, rowErrors, err := parseImportCSV(body, delimiter)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.tenant(r).GSMInterface.Import(workerID, rows, dryRun)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	report.Total += len(rowErrors)
+	report.Errors = append(rowErrors, report.Errors...)
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	newResponse(w, http.StatusOK, report)
+}
+
+// importBody returns the uploaded file of a multipart request or the request body itself.
+func importBody(w http.ResponseWriter, r *http.Request) (io.ReadCloser, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.New("file field is required")
+	}
+
+	return file, nil
+}
+
+// parseImportCSV reads documents from csv with a header row, rows which can't be
+// parsed or don't pass validation are returned as errors with their line number.
+func parseImportCSV(body io.Reader, delimiter rune) ([]models.ImportRow, []models.ImportRowError, error) {
+	reader := csv.NewReader(body)
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("csv header is missing")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range importColumns {
+		if _, ok := index[column]; !ok {
+			return nil, nil, fmt.Errorf("column %q is missing", column)
+		}
+	}
+
+	rows := []models.ImportRow{}
+	rowErrors := []models.ImportRowError{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+			rowErrors = append(rowErrors, models.ImportRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		input, err := parseImportRecord(record, index)
+		if err == nil {
+			err = input.Validate()
+		}
+
+		if err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		rows = append(rows, models.ImportRow{Line: line, Input: input})
+	}
+
+	return rows, rowErrors, nil
+}
+
+func parseImportRecord(record []string, index map[string]int) (models.CreateDocInput, error) {
+	field := func(column string) string {
+		return strings.TrimSpace(record[index[column]])
+	}
+
+	var (
+		input models.CreateDocInput
+		err   error
+	)
+
+	if input.VehicleID, err = strconv.Atoi(field("vehicle_id")); err != nil {
+		return models.CreateDocInput{}, errors.New("invalid vehicle_id")
+	}
+
+	// empty waybill is allocated automatically
+	if _, ok := index["waybill"]; ok && field("waybill") != "" {
+		if input.Waybill, err = strconv.Atoi(field("waybill")); err != nil {
+			return models.CreateDocInput{}, errors.New("invalid waybill value")
+		}
+	}
+
+	if input.GasAmount, err = strconv.Atoi(field("gas_amount")); err != nil {
+		return models.CreateDocInput{}, errors.New("invalid gas_amount value")
+	}
+
+	issueDate, err := time.Parse("2006-01-02", field("issue_date"))
+	if err != nil {
+		return models.CreateDocInput{}, errors.New("invalid issue_date value")
+	}
+
+	// odometer columns are optional, empty values mean the readings weren't taken
+	for column, reading := range map[string]**int{
+		"odometer_start": &input.OdometerStart,
+		"odometer_end":   &input.OdometerEnd,
+	} {
+		if _, ok := index[column]; !ok || field(column) == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(field(column))
+		if err != nil {
+			return models.CreateDocInput{}, fmt.Errorf("invalid %s value", column)
+		}
+		*reading = &value
+	}
+
+	if _, ok := index["tag"]; ok {
+		for _, tag := range strings.Split(field("tag"), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				input.Tags = append(input.Tags, tag)
+			}
+		}
+	}
+
+	// custom field values are typed by the service which knows the field definitions,
+	// empty cells mean the field isn't set
+	for column := range index {
+		code := strings.TrimPrefix(column, customFieldParam)
+		if code == column || code == "" || field(column) == "" {
+			continue
+		}
+
+		if input.CustomFields == nil {
+			input.CustomFields = make(models.CustomValues)
+		}
+		input.CustomFields[code] = field(column)
+	}
+
+	input.DriverName = field("driver_name")
+	input.GasType = field("gas_type")
+	input.IssueDate = models.MyTime(issueDate)
+
+	return input, nil
+}
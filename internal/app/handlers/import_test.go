@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_importDocuments(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface, rows []models.ImportRow)
+
+	validRow := models.ImportRow{
+		Line: 2,
+		Input: models.CreateDocInput{
+			VehicleID:  1,
+			Waybill:    1111,
+			DriverName: "test_name",
+			GasAmount:  10,
+			GasType:    "95",
+			IssueDate:  toMyTime("2023-01-01"),
+		},
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		inputBody            string
+		rows                 []models.ImportRow
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			inputBody: "vehicle_id,waybill,driver_name,gas_amount,gas_type,issue_date\n" +
+				"1,1111,test_name,10,95,2023-01-01\n" +
+				"1,111,test_name,10,95,2023-01-01\n" +
+				"1,1112,test_name,ten,95,2023-01-01\n",
+			rows: []models.ImportRow{validRow},
+			mockBehavior: func(s *mock_services.MockGSMInterface, rows []models.ImportRow) {
+				s.EXPECT().Import(1, rows, false).Return(models.ImportReport{
+					Total:       1,
+					Valid:       1,
+					Imported:    1,
+					DocumentIDs: []int{5},
+					Errors:      []models.ImportRowError{},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"dry_run\":false,\"total\":3,\"valid\":1,\"imported\":1,\"document_ids\":[5]," +
+				"\"errors\":[{\"line\":3,\"message\":\"invalid waybill value\"}," +
+				"{\"line\":4,\"message\":\"invalid gas_amount value\"}]}\n",
+		},
+		{
+			name:  "dry run",
+			query: "?dry_run=true",
+			inputBody: "vehicle_id,waybill,driver_name,gas_amount,gas_type,issue_date\n" +
+				"1,1111,test_name,10,95,2023-01-01\n",
+			rows: []models.ImportRow{validRow},
+			mockBehavior: func(s *mock_services.MockGSMInterface, rows []models.ImportRow) {
+				s.EXPECT().Import(1, rows, true).Return(models.ImportReport{
+					DryRun:      true,
+					Total:       1,
+					Valid:       1,
+					DocumentIDs: []int{},
+					Errors:      []models.ImportRowError{},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"dry_run\":true,\"total\":1,\"valid\":1,\"imported\":0,\"document_ids\":[]," +
+				"\"errors\":[]}\n",
+		},
+		{
+			name: "tags and custom fields",
+			inputBody: "vehicle_id,waybill,driver_name,gas_amount,gas_type,issue_date,tag,field.card,field.trip_km\n" +
+				"1,1111,test_name,10,95,2023-01-01,\"intercity, repair trip\",12,340\n" +
+				"1,1112,test_name,10,95,2023-01-01,,,\n",
+			rows: []models.ImportRow{
+				{Line: 2, Input: models.CreateDocInput{
+					VehicleID:    1,
+					Waybill:      1111,
+					DriverName:   "test_name",
+					GasAmount:    10,
+					GasType:      "95",
+					IssueDate:    toMyTime("2023-01-01"),
+					Tags:         []string{"intercity", "repair trip"},
+					CustomFields: models.CustomValues{"card": "12", "trip_km": "340"},
+				}},
+				{Line: 3, Input: models.CreateDocInput{
+					VehicleID:  1,
+					Waybill:    1112,
+					DriverName: "test_name",
+					GasAmount:  10,
+					GasType:    "95",
+					IssueDate:  toMyTime("2023-01-01"),
+				}},
+			},
+			mockBehavior: func(s *mock_services.MockGSMInterface, rows []models.ImportRow) {
+				s.EXPECT().Import(1, rows, false).Return(models.ImportReport{
+					Total:       2,
+					Valid:       2,
+					Imported:    2,
+					DocumentIDs: []int{5, 6},
+					Errors:      []models.ImportRowError{},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"dry_run\":false,\"total\":2,\"valid\":2,\"imported\":2,\"document_ids\":[5,6]," +
+				"\"errors\":[]}\n",
+		},
+		{
+			name:                 "missing column",
+			inputBody:            "vehicle_id,waybill,driver_name,gas_amount,gas_type\n1,1111,test_name,10,95\n",
+			mockBehavior:         func(s *mock_services.MockGSMInterface, rows []models.ImportRow) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"column \\\"issue_date\\\" is missing\"}\n",
+		},
+		{
+			name: "service failure",
+			inputBody: "vehicle_id,waybill,driver_name,gas_amount,gas_type,issue_date\n" +
+				"1,1111,test_name,10,95,2023-01-01\n",
+			rows: []models.ImportRow{validRow},
+			mockBehavior: func(s *mock_services.MockGSMInterface, rows []models.ImportRow) {
+				s.EXPECT().Import(1, rows, false).Return(models.ImportReport{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService, tc.rows)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/gsm/import", handler.importDocuments)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/gsm/import"+tc.query, bytes.NewBufferString(tc.inputBody))
+			r.Header.Set("Content-Type", "text/csv")
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 1}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
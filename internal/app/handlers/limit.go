@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (h *Handler) createLimit(w http.ResponseWriter, r *http.Request) {
+	var limitInput models.CreateLimitInput
+
+	if err := json.NewDecoder(r.Body).Decode(&limitInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := limitInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.tenant(r).Limits.Create(limitInput)
+	if err != nil {
+		h.newErrResponse(w, limitErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.tenant(r).Limits.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, limits)
+}
+
+// getLimitsDashboard shows usage of the limits in the periods containing the date, today by default.
+func (h *Handler) getLimitsDashboard(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDateParam(r.URL.Query().Get("date"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid date param")
+		return
+	}
+
+	if date == nil {
+		today := models.MyTime(time.Now())
+		date = &today
+	}
+
+	usages, err := h.tenant(r).Limits.Dashboard(*date)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, usages)
+}
+
+func (h *Handler) deleteLimit(w http.ResponseWriter, r *http.Request) {
+	limitID, err := strconv.Atoi(chi.URLParam(r, "limit_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid limit_id param")
+		return
+	}
+
+	if err := h.tenant(r).Limits.Delete(limitID); err != nil {
+		h.newErrResponse(w, limitErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func limitErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrLimitNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrVehicleNotFound), errors.Is(err, models.ErrDepartmentNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrLimitExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
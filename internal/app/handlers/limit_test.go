@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createLimit(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockLimits, limit models.CreateLimitInput)
+
+	alertPercent := 90.0
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputLimit           models.CreateLimitInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"vehicle_id":1, "period":"month", "unit":"liters", "amount":400}`,
+			inputLimit: models.CreateLimitInput{
+				VehicleID: intPtr(1),
+				Period:    "month",
+				Unit:      "liters",
+				Amount:    400,
+			},
+			mockBehavior: func(s *mock_services.MockLimits, limit models.CreateLimitInput) {
+				s.EXPECT().Create(limit).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:               "vehicle and department",
+			inputBody:          `{"vehicle_id":1, "department_id":2, "period":"week", "unit":"liters", "amount":400}`,
+			mockBehavior:       func(s *mock_services.MockLimits, limit models.CreateLimitInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"department_id\",\"code\":\"invalid\"," +
+				"\"message\":\"limit can't be set for a vehicle and a department at once\"}," +
+				"{\"field\":\"period\",\"code\":\"invalid\",\"message\":\"invalid period, allowed: month, quarter\"}]}\n",
+		},
+		{
+			name:      "department not found",
+			inputBody: `{"department_id":2, "period":"quarter", "unit":"money", "amount":1500.5, "alert_percent":90}`,
+			inputLimit: models.CreateLimitInput{
+				DepartmentID: intPtr(2),
+				Period:       "quarter",
+				Unit:         "money",
+				Amount:       1500.5,
+				AlertPercent: &alertPercent,
+			},
+			mockBehavior: func(s *mock_services.MockLimits, limit models.CreateLimitInput) {
+				s.EXPECT().Create(limit).Return(0, models.ErrDepartmentNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"department doesn't exist\"}\n",
+		},
+		{
+			name:      "limit exists",
+			inputBody: `{"vehicle_id":1, "period":"month", "unit":"liters", "amount":400}`,
+			inputLimit: models.CreateLimitInput{
+				VehicleID: intPtr(1),
+				Period:    "month",
+				Unit:      "liters",
+				Amount:    400,
+			},
+			mockBehavior: func(s *mock_services.MockLimits, limit models.CreateLimitInput) {
+				s.EXPECT().Create(limit).Return(0, models.ErrLimitExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"limit with this period and unit is already set\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			limitService := mock_services.NewMockLimits(c)
+			tc.mockBehavior(limitService, tc.inputLimit)
+
+			service := &services.Service{Limits: limitService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/limits", handler.createLimit)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/limits", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getLimitsDashboard(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockLimits)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			query: "?date=2023-05-10",
+			mockBehavior: func(s *mock_services.MockLimits) {
+				s.EXPECT().Dashboard(toMyTime("2023-05-10")).Return([]models.LimitUsage{
+					{
+						Limit: models.Limit{
+							ID: 1, VehicleID: intPtr(1), Period: "quarter", Unit: "liters", Amount: 1000, AlertPercent: 80,
+						},
+						From:    toMyTime("2023-04-01"),
+						To:      toMyTime("2023-06-30"),
+						Used:    850,
+						Percent: 85,
+						State:   "warning",
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "[{\"id\":1,\"vehicle_id\":1,\"period\":\"quarter\",\"unit\":\"liters\",\"amount\":1000," +
+				"\"alert_percent\":80,\"from\":\"2023-04-01\",\"to\":\"2023-06-30\",\"used\":850,\"percent\":85," +
+				"\"state\":\"warning\"}]\n",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?date=10.05.2023",
+			mockBehavior:         func(s *mock_services.MockLimits) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid date param\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			limitService := mock_services.NewMockLimits(c)
+			tc.mockBehavior(limitService)
+
+			service := &services.Service{Limits: limitService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/admin/limits/dashboard", handler.getLimitsDashboard)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/admin/limits/dashboard"+tc.query, bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteLimit(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockLimits)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockLimits) {
+				s.EXPECT().Delete(1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name: "limit not found",
+			mockBehavior: func(s *mock_services.MockLimits) {
+				s.EXPECT().Delete(1).Return(models.ErrLimitNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"limit doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			limitService := mock_services.NewMockLimits(c)
+			tc.mockBehavior(limitService)
+
+			service := &services.Service{Limits: limitService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/admin/limits/{limit_id}", handler.deleteLimit)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "/api/admin/limits/1", bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
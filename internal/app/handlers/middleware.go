@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
 	"net/http"
 	"strings"
 )
@@ -54,20 +55,61 @@ func (h *Handler) identifyUser(next http.Handler) http.Handler {
 }
 
 func (h *Handler) checkRole(next http.Handler) http.Handler {
+	return h.requireRole(models.RoleAdmin)(next)
+}
+
+func (h *Handler) checkSuperAdmin(next http.Handler) http.Handler {
+	return h.requireRole(models.RoleSuperAdmin)(next)
+}
+
+// checkReader lets in the workers who may read documents of the whole organization.
+func (h *Handler) checkReader(next http.Handler) http.Handler {
+	return h.requireRole(models.RoleAdmin, models.RoleAuditor)(next)
+}
+
+func (h *Handler) requireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				newResponse(w, http.StatusOK, "")
+				return
+			}
+
+			workerCtxValue := r.Context().Value(workerCtx)
+			workerAttributes, ok := workerCtxValue.(models.WorkerAttributes)
+			if !ok {
+				h.newErrResponse(w, http.StatusBadRequest, "workerCtx value is not of type WorkerAttributes")
+				return
+			}
+
+			for _, role := range roles {
+				if workerAttributes.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			h.newErrResponse(w, http.StatusForbidden, "you don't have enough rules")
+		})
+	}
+}
+
+// denyAuditorWrites keeps auditors read-only, they may only send safe requests.
+func (h *Handler) denyAuditorWrites(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			newResponse(w, http.StatusOK, "")
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		workerCtxValue := r.Context().Value(workerCtx)
-		workerAttributes, ok := workerCtxValue.(models.WorkerAttributes)
-		if !ok {
-			h.newErrResponse(w, http.StatusBadRequest, "workerCtx value is not of type WorkerAttributes")
+		workerAttributes, err := getWorkerAttributes(r)
+		if err != nil {
+			h.newErrResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		if workerAttributes.Role != "admin" {
+		if workerAttributes.Role == models.RoleAuditor {
 			h.newErrResponse(w, http.StatusForbidden, "you don't have enough rules")
 			return
 		}
@@ -76,12 +118,29 @@ func (h *Handler) checkRole(next http.Handler) http.Handler {
 	})
 }
 
+// tenant returns services scoped to the organization of the worker who made the request,
+// without the worker they belong to no organization and see no data.
+func (h *Handler) tenant(r *http.Request) *services.Service {
+	workerAttributes, _ := getWorkerAttributes(r)
+
+	return h.service.Tenant(workerAttributes.OrganizationID)
+}
+
 func getWorkerID(r *http.Request) (int, error) {
+	workerAttributes, err := getWorkerAttributes(r)
+	if err != nil {
+		return 0, err
+	}
+
+	return workerAttributes.ID, nil
+}
+
+func getWorkerAttributes(r *http.Request) (models.WorkerAttributes, error) {
 	workerCtxValue := r.Context().Value(workerCtx)
 	workerAttributes, ok := workerCtxValue.(models.WorkerAttributes)
 	if !ok {
-		return 0, errors.New("workerCtx value is not of type WorkerAttributes")
+		return models.WorkerAttributes{}, errors.New("workerCtx value is not of type WorkerAttributes")
 	}
 
-	return workerAttributes.ID, nil
+	return workerAttributes, nil
 }
@@ -144,6 +144,16 @@ func TestHandler_checkRole(t *testing.T) {
 			expectedStatusCode:   http.StatusForbidden,
 			expectedResponseBody: "{\"message\":\"you don't have enough rules\"}\n",
 		},
+		{
+			name: "super-admin",
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "superadmin",
+				Name: "Test",
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"you don't have enough rules\"}\n",
+		},
 	}
 
 	for _, tc := range testTable {
@@ -176,3 +186,71 @@ func TestHandler_checkRole(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_denyAuditorWrites(t *testing.T) {
+
+	testTable := []struct {
+		name                 string
+		method               string
+		workerAtr            any
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "auditor reads",
+			method: "GET",
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "auditor",
+				Name: "Test",
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:   "auditor writes",
+			method: "POST",
+			workerAtr: models.WorkerAttributes{
+				ID:   1,
+				Role: "auditor",
+				Name: "Test",
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"you don't have enough rules\"}\n",
+		},
+		{
+			name:   "worker writes",
+			method: "POST",
+			workerAtr: models.WorkerAttributes{
+				ID:   2,
+				Role: "worker",
+				Name: "Test",
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "2",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(&services.Service{})
+
+			router := chi.NewRouter()
+			router.Use(handler.denyAuditorWrites)
+			router.MethodFunc(tc.method, "/protected", func(w http.ResponseWriter, r *http.Request) {
+				workerAttributes, _ := r.Context().Value(workerCtx).(models.WorkerAttributes)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(fmt.Sprintf("%d", workerAttributes.ID)))
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/protected", nil)
+			ctx := context.WithValue(r.Context(), workerCtx, tc.workerAtr)
+
+			router.ServeHTTP(w, r.WithContext(ctx))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createOrganization(w http.ResponseWriter, r *http.Request) {
+	var organizationInput models.CreateOrganizationInput
+
+	if err := json.NewDecoder(r.Body).Decode(&organizationInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := organizationInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.service.Organizations.Create(organizationInput)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, models.ErrOrganizationExists) {
+			status = http.StatusConflict
+		}
+		h.newErrResponse(w, status, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.service.Organizations.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, organizations)
+}
+
+func (h *Handler) createOrganizationWorker(w http.ResponseWriter, r *http.Request) {
+	orgID, err := strconv.Atoi(chi.URLParam(r, "org_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid org_id param")
+		return
+	}
+
+	var workerInput models.CreateWorkerInput
+
+	if err := json.NewDecoder(r.Body).Decode(&workerInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := workerInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.service.Organizations.CreateWorker(orgID, workerInput)
+	if err != nil {
+		h.newErrResponse(w, workerErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
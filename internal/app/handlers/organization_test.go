@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createOrganization(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockOrganizations, organization models.CreateOrganizationInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputOrganization    models.CreateOrganizationInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:              "ok",
+			inputBody:         `{"name":"North depot"}`,
+			inputOrganization: models.CreateOrganizationInput{Name: "North depot"},
+			mockBehavior: func(s *mock_services.MockOrganizations, organization models.CreateOrganizationInput) {
+				s.EXPECT().Create(organization).Return(2, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":2}\n",
+		},
+		{
+			name:               "empty name",
+			inputBody:          `{"name":""}`,
+			mockBehavior:       func(s *mock_services.MockOrganizations, organization models.CreateOrganizationInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"name\",\"code\":\"required\",\"message\":\"empty name\"}]}\n",
+		},
+		{
+			name:              "organization exists",
+			inputBody:         `{"name":"North depot"}`,
+			inputOrganization: models.CreateOrganizationInput{Name: "North depot"},
+			mockBehavior: func(s *mock_services.MockOrganizations, organization models.CreateOrganizationInput) {
+				s.EXPECT().Create(organization).Return(0, models.ErrOrganizationExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"organization with this name already exists\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			organizationService := mock_services.NewMockOrganizations(c)
+			tc.mockBehavior(organizationService, tc.inputOrganization)
+
+			service := &services.Service{Organizations: organizationService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/superadmin/organizations", handler.createOrganization)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/superadmin/organizations", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_createOrganizationWorker(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockOrganizations, worker models.CreateWorkerInput)
+
+	testTable := []struct {
+		name                 string
+		orgID                string
+		inputBody            string
+		inputWorker          models.CreateWorkerInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			orgID: "2",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester",
+							"phone":"+375 11 111-11-11", "role":"admin", "password":"qwerty"}`,
+			inputWorker: models.CreateWorkerInput{
+				Name:        "Test",
+				Surname:     "Tested",
+				FathersName: "Tester",
+				Phone:       "+375 11 111-11-11",
+				Role:        "admin",
+				Password:    "qwerty",
+			},
+			mockBehavior: func(s *mock_services.MockOrganizations, worker models.CreateWorkerInput) {
+				s.EXPECT().CreateWorker(2, worker).Return(5, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":5}\n",
+		},
+		{
+			name:                 "invalid org_id",
+			orgID:                "bad_id",
+			inputBody:            `{}`,
+			mockBehavior:         func(s *mock_services.MockOrganizations, worker models.CreateWorkerInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid org_id param\"}\n",
+		},
+		{
+			name:  "organization not found",
+			orgID: "9",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester",
+							"phone":"+375 11 111-11-11", "role":"admin", "password":"qwerty"}`,
+			inputWorker: models.CreateWorkerInput{
+				Name:        "Test",
+				Surname:     "Tested",
+				FathersName: "Tester",
+				Phone:       "+375 11 111-11-11",
+				Role:        "admin",
+				Password:    "qwerty",
+			},
+			mockBehavior: func(s *mock_services.MockOrganizations, worker models.CreateWorkerInput) {
+				s.EXPECT().CreateWorker(9, worker).Return(0, models.ErrOrganizationNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"organization doesn't exist\"}\n",
+		},
+		{
+			name:  "department of another organization",
+			orgID: "2",
+			inputBody: `{"name":"Test", "surname":"Tested", "fathers_name":"Tester",
+							"phone":"+375 11 111-11-11", "role":"worker", "password":"qwerty", "department_id":3}`,
+			inputWorker: models.CreateWorkerInput{
+				Name:         "Test",
+				Surname:      "Tested",
+				FathersName:  "Tester",
+				Phone:        "+375 11 111-11-11",
+				Role:         "worker",
+				Password:     "qwerty",
+				DepartmentID: intPtr(3),
+			},
+			mockBehavior: func(s *mock_services.MockOrganizations, worker models.CreateWorkerInput) {
+				s.EXPECT().CreateWorker(2, worker).Return(0, models.ErrDepartmentNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"department doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			organizationService := mock_services.NewMockOrganizations(c)
+			tc.mockBehavior(organizationService, tc.inputWorker)
+
+			service := &services.Service{Organizations: organizationService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/superadmin/organizations/{org_id}/workers", handler.createOrganizationWorker)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/superadmin/organizations/"+tc.orgID+"/workers",
+				bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time"
+)
+
+func (h *Handler) getPeriods(w http.ResponseWriter, r *http.Request) {
+	closings, err := h.tenant(r).Periods.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, closings)
+}
+
+func (h *Handler) closePeriod(w http.ResponseWriter, r *http.Request) {
+	h.changePeriod(w, r, h.tenant(r).Periods.Close, "closed")
+}
+
+func (h *Handler) reopenPeriod(w http.ResponseWriter, r *http.Request) {
+	h.changePeriod(w, r, h.tenant(r).Periods.Reopen, "reopened")
+}
+
+func (h *Handler) changePeriod(w http.ResponseWriter, r *http.Request,
+	change func(period time.Time, workerID int, input models.PeriodInput) error, status string) {
+	period, err := models.ParsePeriod(chi.URLParam(r, "period"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var periodInput models.PeriodInput
+
+	if err := json.NewDecoder(r.Body).Decode(&periodInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := periodInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := change(period, workerID, periodInput); err != nil {
+		h.newErrResponse(w, periodErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": status,
+	})
+}
+
+func periodErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrPeriodAlreadyClosed), errors.Is(err, models.ErrPeriodNotClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
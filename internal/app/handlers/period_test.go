@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getPeriods(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockPeriods)
+
+	closedAt := time.Date(2023, 4, 3, 9, 0, 0, 0, time.UTC)
+	reopenReason := "late receipts"
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockPeriods) {
+				s.EXPECT().GetAll().Return([]models.PeriodClosing{
+					{ID: 2, Period: "2023-03", Reason: "month closed", ClosedBy: intPtr(1), ClosedAt: closedAt},
+					{
+						ID: 1, Period: "2023-02", Reason: "month closed", ClosedBy: intPtr(1), ClosedAt: closedAt,
+						ReopenReason: &reopenReason, ReopenedBy: intPtr(1), ReopenedAt: &closedAt,
+					},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "[{\"id\":2,\"period\":\"2023-03\",\"reason\":\"month closed\",\"closed_by\":1," +
+				"\"closed_at\":\"2023-04-03T09:00:00Z\"}," +
+				"{\"id\":1,\"period\":\"2023-02\",\"reason\":\"month closed\",\"closed_by\":1," +
+				"\"closed_at\":\"2023-04-03T09:00:00Z\",\"reopen_reason\":\"late receipts\",\"reopened_by\":1," +
+				"\"reopened_at\":\"2023-04-03T09:00:00Z\"}]\n",
+		},
+		{
+			name: "service failure",
+			mockBehavior: func(s *mock_services.MockPeriods) {
+				s.EXPECT().GetAll().Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			periodService := mock_services.NewMockPeriods(c)
+			tc.mockBehavior(periodService)
+
+			service := &services.Service{Periods: periodService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/admin/periods", handler.getPeriods)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/admin/periods", bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_closePeriod(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput)
+
+	march := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		period               string
+		inputBody            string
+		inputPeriod          models.PeriodInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "ok",
+			period:      "2023-03",
+			inputBody:   `{"reason":"month closed"}`,
+			inputPeriod: models.PeriodInput{Reason: "month closed"},
+			mockBehavior: func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {
+				s.EXPECT().Close(period, 2, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"closed\"}\n",
+		},
+		{
+			name:                 "invalid period",
+			period:               "2023-3-1",
+			inputBody:            `{"reason":"month closed"}`,
+			mockBehavior:         func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid period, it has to be written as YYYY-MM\"}\n",
+		},
+		{
+			name:               "empty reason",
+			period:             "2023-03",
+			inputBody:          `{}`,
+			mockBehavior:       func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":" +
+				"[{\"field\":\"reason\",\"code\":\"required\",\"message\":\"empty reason\"}]}\n",
+		},
+		{
+			name:        "already closed",
+			period:      "2023-03",
+			inputBody:   `{"reason":"month closed"}`,
+			inputPeriod: models.PeriodInput{Reason: "month closed"},
+			mockBehavior: func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {
+				s.EXPECT().Close(period, 2, input).Return(models.ErrPeriodAlreadyClosed)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"period is already closed\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			periodService := mock_services.NewMockPeriods(c)
+			tc.mockBehavior(periodService, march, tc.inputPeriod)
+
+			service := &services.Service{Periods: periodService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/periods/{period}/close", handler.closePeriod)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/periods/"+tc.period+"/close", bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 2, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_reopenPeriod(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput)
+
+	march := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	input := models.PeriodInput{Reason: "late receipts"}
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {
+				s.EXPECT().Reopen(period, 2, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"reopened\"}\n",
+		},
+		{
+			name: "not closed",
+			mockBehavior: func(s *mock_services.MockPeriods, period time.Time, input models.PeriodInput) {
+				s.EXPECT().Reopen(period, 2, input).Return(models.ErrPeriodNotClosed)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"period isn't closed\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			periodService := mock_services.NewMockPeriods(c)
+			tc.mockBehavior(periodService, march, input)
+
+			service := &services.Service{Periods: periodService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/periods/{period}/reopen", handler.reopenPeriod)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/periods/2023-03/reopen",
+				bytes.NewBufferString(`{"reason":"late receipts"}`))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 2, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const customFieldParam = "field."
+
+func parseDocumentFilter(r *http.Request) (models.DocumentFilter, error) {
+	q := r.URL.Query()
+
+	filter := models.DocumentFilter{
+		CarID:      q.Get("car_id"),
+		DriverName: q.Get("driver_name"),
+		GasType:    q.Get("gas_type"),
+		Status:     q.Get("status"),
+		Sort:       q.Get("sort"),
+		Tags:       q["tag"],
+	}
+
+	// custom fields are matched by params like field.<code>=value
+	for param, values := range q {
+		if code := strings.TrimPrefix(param, customFieldParam); code != param && code != "" {
+			if filter.CustomFields == nil {
+				filter.CustomFields = make(models.CustomValues)
+			}
+			filter.CustomFields[code] = values[0]
+		}
+	}
+
+	// plates are stored normalized, so the filter matches regardless of spacing and case
+	if plate, ok := models.NormalizePlate(filter.CarID); ok {
+		filter.CarID = plate
+	}
+
+	var err error
+	if filter.IssuedFrom, err = parseDateParam(q.Get("issue_date_from")); err != nil {
+		return models.DocumentFilter{}, errors.New("invalid issue_date_from param")
+	}
+
+	if filter.IssuedTo, err = parseDateParam(q.Get("issue_date_to")); err != nil {
+		return models.DocumentFilter{}, errors.New("invalid issue_date_to param")
+	}
+
+	if vehicleID := q.Get("vehicle_id"); vehicleID != "" {
+		if filter.VehicleID, err = strconv.Atoi(vehicleID); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid vehicle_id param")
+		}
+	}
+
+	if departmentID := q.Get("department_id"); departmentID != "" {
+		if filter.DepartmentID, err = strconv.Atoi(departmentID); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid department_id param")
+		}
+	}
+
+	if normExceeded := q.Get("norm_exceeded"); normExceeded != "" {
+		if filter.NormExceeded, err = strconv.ParseBool(normExceeded); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid norm_exceeded param")
+		}
+	}
+
+	if limitExceeded := q.Get("limit_exceeded"); limitExceeded != "" {
+		if filter.LimitExceeded, err = strconv.ParseBool(limitExceeded); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid limit_exceeded param")
+		}
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid limit param")
+		}
+	}
+
+	if offset := q.Get("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil {
+			return models.DocumentFilter{}, errors.New("invalid offset param")
+		}
+	}
+
+	if token := q.Get("page_token"); token != "" {
+		if filter.Offset, err = models.ParsePageToken(token); err != nil {
+			return models.DocumentFilter{}, err
+		}
+	}
+
+	if err := filter.Validate(); err != nil {
+		return models.DocumentFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseDateParam(value string) (*models.MyTime, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+
+	date := models.MyTime(t)
+	return &date, nil
+}
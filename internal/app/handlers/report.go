@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/pkg/export"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (h *Handler) getFuelReport(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFuelReportFilter(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.tenant(r).Reporting.FuelReport(filter)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if format := r.URL.Query().Get("format"); format == "" || format == "json" {
+		newResponse(w, http.StatusOK, report)
+		return
+	}
+
+	writer, err := newExportWriter(w, r, "fuel_report", "documents", "gas_amount", "cost")
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := writeFuelReport(writer, report); err != nil {
+		h.errLogger.Error(err.Error())
+	}
+}
+
+func parseFuelReportFilter(r *http.Request) (models.FuelReportFilter, error) {
+	q := r.URL.Query()
+
+	from, err := parseDateParam(q.Get("from"))
+	if err != nil || from == nil {
+		return models.FuelReportFilter{}, errors.New("empty or invalid from param")
+	}
+
+	to, err := parseDateParam(q.Get("to"))
+	if err != nil || to == nil {
+		return models.FuelReportFilter{}, errors.New("empty or invalid to param")
+	}
+
+	filter := models.FuelReportFilter{
+		From:   *from,
+		To:     *to,
+		Period: q.Get("period"),
+	}
+
+	if groupBy := q.Get("group_by"); groupBy != "" {
+		filter.GroupBy = strings.Split(groupBy, ",")
+	}
+
+	if err := filter.Validate(); err != nil {
+		return models.FuelReportFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func writeFuelReport(writer export.Writer, report models.FuelReport) error {
+	var header []string
+	for _, group := range report.GroupBy {
+		if group == models.GroupByVehicle {
+			header = append(header, "vehicle_id", "car_id")
+			continue
+		}
+		header = append(header, group)
+	}
+
+	if err := writer.WriteHeader(append(header, "documents", "gas_amount", "cost")); err != nil {
+		return err
+	}
+
+	for _, row := range report.Rows {
+		var values []interface{}
+		for _, group := range report.GroupBy {
+			switch group {
+			case models.GroupByVehicle:
+				values = append(values, *row.VehicleID, *row.CarID)
+			case models.GroupByDriver:
+				values = append(values, *row.DriverName)
+			case models.GroupByGasType:
+				values = append(values, *row.GasType)
+			case models.GroupByPeriod:
+				values = append(values, time.Time(*row.Period))
+			}
+		}
+
+		if err := writer.WriteRow(append(values, row.Documents, row.GasAmount, row.Cost)); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
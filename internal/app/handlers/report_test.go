@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_getFuelReport(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockReporting, filter models.FuelReportFilter)
+
+	gasType := "95"
+	period := toMyTime("2023-01-01")
+	report := models.FuelReport{
+		From:    toMyTime("2023-01-01"),
+		To:      toMyTime("2023-03-31"),
+		GroupBy: []string{"gas_type", "period"},
+		Period:  "month",
+		Rows: []models.FuelReportRow{
+			{GasType: &gasType, Period: &period, Documents: 2, GasAmount: 30, Cost: 73.5},
+		},
+		Documents: 2,
+		GasAmount: 30,
+		Cost:      73.5,
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		filter               models.FuelReportFilter
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			query: "?from=2023-01-01&to=2023-03-31&group_by=gas_type,period",
+			filter: models.FuelReportFilter{
+				From:    toMyTime("2023-01-01"),
+				To:      toMyTime("2023-03-31"),
+				GroupBy: []string{"gas_type", "period"},
+				Period:  "month",
+			},
+			mockBehavior: func(s *mock_services.MockReporting, filter models.FuelReportFilter) {
+				s.EXPECT().FuelReport(filter).Return(report, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "{\"from\":\"2023-01-01\",\"to\":\"2023-03-31\",\"group_by\":[\"gas_type\",\"period\"]," +
+				"\"period\":\"month\",\"rows\":[{\"gas_type\":\"95\",\"period\":\"2023-01-01\",\"documents\":2," +
+				"\"gas_amount\":30,\"cost\":73.5}],\"documents\":2,\"gas_amount\":30,\"cost\":73.5}\n",
+		},
+		{
+			name:  "csv",
+			query: "?from=2023-01-01&to=2023-03-31&group_by=gas_type,period&format=csv",
+			filter: models.FuelReportFilter{
+				From:    toMyTime("2023-01-01"),
+				To:      toMyTime("2023-03-31"),
+				GroupBy: []string{"gas_type", "period"},
+				Period:  "month",
+			},
+			mockBehavior: func(s *mock_services.MockReporting, filter models.FuelReportFilter) {
+				s.EXPECT().FuelReport(filter).Return(report, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "gas_type,period,documents,gas_amount,cost\n95,2023-01-01,2,30,73.5\n",
+		},
+		{
+			name:                 "no range",
+			query:                "?to=2023-03-31",
+			mockBehavior:         func(s *mock_services.MockReporting, filter models.FuelReportFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"empty or invalid from param\"}\n",
+		},
+		{
+			name:                 "invalid group_by",
+			query:                "?from=2023-01-01&to=2023-03-31&group_by=worker",
+			mockBehavior:         func(s *mock_services.MockReporting, filter models.FuelReportFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid group_by value\"}\n",
+		},
+		{
+			name:  "service failure",
+			query: "?from=2023-01-01&to=2023-03-31",
+			filter: models.FuelReportFilter{
+				From:    toMyTime("2023-01-01"),
+				To:      toMyTime("2023-03-31"),
+				GroupBy: []string{"vehicle"},
+				Period:  "month",
+			},
+			mockBehavior: func(s *mock_services.MockReporting, filter models.FuelReportFilter) {
+				s.EXPECT().FuelReport(filter).Return(models.FuelReport{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reportService := mock_services.NewMockReporting(c)
+			tc.mockBehavior(reportService, tc.filter)
+
+			service := &services.Service{Reporting: reportService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/reports/fuel", handler.getFuelReport)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/reports/fuel"+tc.query, bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
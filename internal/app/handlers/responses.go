@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"net/http"
 )
 
@@ -9,6 +11,11 @@ type response struct {
 	Message string `json:"message"`
 }
 
+type validationResponse struct {
+	Message string              `json:"message"`
+	Errors  []models.FieldError `json:"errors"`
+}
+
 func (h *Handler) newErrResponse(w http.ResponseWriter, code int, errorMsg string) {
 	h.errLogger.Error(errorMsg)
 	newResponse(w, code, response{
@@ -16,6 +23,22 @@ func (h *Handler) newErrResponse(w http.ResponseWriter, code int, errorMsg strin
 	})
 }
 
+// newInputErrResponse responds with 422 and every invalid field if the input didn't pass
+// validation, any other error is a malformed request.
+func (h *Handler) newInputErrResponse(w http.ResponseWriter, err error) {
+	var validationErr *models.ValidationError
+	if !errors.As(err, &validationErr) {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.errLogger.Error(err.Error())
+	newResponse(w, http.StatusUnprocessableEntity, validationResponse{
+		Message: "validation failed",
+		Errors:  validationErr.Fields,
+	})
+}
+
 func (h *WebSocketHandler) newErrResponse(w http.ResponseWriter, code int, errorMsg string) {
 	h.errLogger.Error(errorMsg)
 	newResponse(w, code, response{
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) changeDocumentStatus(w http.ResponseWriter, r *http.Request) {
+	var input models.TransitionInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).DocumentAccess.Transition(worker, docID, input); err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": input.Status,
+	})
+}
+
+func (h *Handler) getDocumentTransitions(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	transitions, err := h.tenant(r).DocumentAccess.Transitions(worker, docID)
+	if err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, transitions)
+}
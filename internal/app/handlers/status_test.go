@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_changeDocumentStatus(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput)
+
+	dispatcher := models.WorkerAttributes{ID: 3, Role: models.RoleDispatcher}
+	worker := models.WorkerAttributes{ID: 2, Role: models.RoleWorker}
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		input                models.TransitionInput
+		worker               models.WorkerAttributes
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"status":"approved"}`,
+			input:     models.TransitionInput{Status: models.StatusApproved},
+			worker:    dispatcher,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+				s.EXPECT().Transition(worker, 1, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"approved\"}\n",
+		},
+		{
+			name:      "document out of scope",
+			inputBody: `{"status":"submitted"}`,
+			input:     models.TransitionInput{Status: models.StatusSubmitted},
+			worker:    worker,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+				s.EXPECT().Transition(worker, 1, input).Return(models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+		{
+			name:      "reject without reason",
+			inputBody: `{"status":"rejected"}`,
+			worker:    dispatcher,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"reason is required to reject the document\"}\n",
+		},
+		{
+			name:      "invalid status",
+			inputBody: `{"status":"archived"}`,
+			worker:    dispatcher,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid status\"}\n",
+		},
+		{
+			name:      "worker approves",
+			inputBody: `{"status":"approved"}`,
+			input:     models.TransitionInput{Status: models.StatusApproved},
+			worker:    worker,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+				s.EXPECT().Transition(worker, 1, input).Return(models.ErrNotEnoughRights)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"you don't have enough rights\"}\n",
+		},
+		{
+			name:      "transition not allowed",
+			inputBody: `{"status":"submitted"}`,
+			input:     models.TransitionInput{Status: models.StatusSubmitted},
+			worker:    worker,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+				s.EXPECT().Transition(worker, 1, input).Return(models.ErrTransitionNotAllowed)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"document can't be moved to this status\"}\n",
+		},
+		{
+			name:      "stock shortage",
+			inputBody: `{"status":"approved"}`,
+			input:     models.TransitionInput{Status: models.StatusApproved},
+			worker:    dispatcher,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, worker models.WorkerAttributes, input models.TransitionInput) {
+				s.EXPECT().Transition(worker, 1, input).Return(models.ErrStockShortage)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"there isn't enough fuel in stock for the document\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			access := mock_services.NewMockDocumentAccess(c)
+			tc.mockBehavior(access, tc.worker, tc.input)
+
+			service := &services.Service{DocumentAccess: access}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/gsm/{document_id}/status", handler.changeDocumentStatus)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/gsm/1/status", bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, tc.worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateOwnDocument(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+		worker models.WorkerAttributes, input models.UpdateDocInput)
+
+	worker := models.WorkerAttributes{ID: 2, Role: models.RoleWorker}
+	gasAmount := 20
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		ifMatch              string
+		input                models.UpdateDocInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `"3"`,
+			input:     models.UpdateDocInput{GasAmount: &gasAmount},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"updated\"}\n",
+		},
+		{
+			name:      "approved document",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `"3"`,
+			input:     models.UpdateDocInput{GasAmount: &gasAmount},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(models.ErrDocumentReadOnly)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"document can't be changed in its current status\"}\n",
+		},
+		{
+			name:      "foreign document",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `"3"`,
+			input:     models.UpdateDocInput{GasAmount: &gasAmount},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(models.ErrNotEnoughRights)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"you don't have enough rights\"}\n",
+		},
+		{
+			name:      "invalid gas_amount",
+			inputBody: `{"gas_amount":0}`,
+			ifMatch:   `"3"`,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"gas_amount\",\"code\":\"out_of_range\",\"message\":\"gas_amount can't be less than zero\"}]}\n",
+		},
+		{
+			name:      "incomplete odometer",
+			inputBody: `{"odometer_start":1200}`,
+			ifMatch:   `"3"`,
+			input:     models.UpdateDocInput{OdometerStart: intPtr(1200)},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				validationErr := &models.ValidationError{}
+				validationErr.Add("odometer_end", models.CodeRequired, "odometer_start and odometer_end have to be set together")
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(validationErr)
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"odometer_end\",\"code\":\"required\"," +
+				"\"message\":\"odometer_start and odometer_end have to be set together\"}]}\n",
+		},
+		{
+			name:      "weak etag",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `W/"3"`,
+			input:     models.UpdateDocInput{GasAmount: &gasAmount},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"updated\"}\n",
+		},
+		{
+			name:      "missing If-Match",
+			inputBody: `{"gas_amount":20}`,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+			},
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedResponseBody: "{\"message\":\"If-Match header is required\"}\n",
+		},
+		{
+			name:      "invalid If-Match",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `"abc"`,
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid If-Match header\"}\n",
+		},
+		{
+			name:      "stale version",
+			inputBody: `{"gas_amount":20}`,
+			ifMatch:   `"3"`,
+			input:     models.UpdateDocInput{GasAmount: &gasAmount},
+			mockBehavior: func(s *mock_services.MockDocumentAccess, documents *mock_services.MockGSMInterface,
+				worker models.WorkerAttributes, input models.UpdateDocInput) {
+				s.EXPECT().UpdateOwn(worker, 1, 3, input).Return(models.ErrVersionMismatch)
+				documents.EXPECT().GetByID(1).Return(models.Document{
+					ID:         1,
+					CarID:      "1111 AA-1",
+					VehicleID:  1,
+					Waybill:    1111,
+					DriverName: "Test",
+					GasAmount:  30,
+					GasType:    "95",
+					IssueDate:  toMyTime("2023-01-01"),
+					Status:     models.StatusDraft,
+					Version:    4,
+				}, nil)
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedETag:       `"4"`,
+			expectedResponseBody: "{\"ID\":1,\"car\":\"\",\"car_id\":\"1111 AA-1\",\"vehicle_id\":1,\"waybill\":1111," +
+				"\"driver_name\":\"Test\",\"gas_amount\":30,\"gas_type\":\"95\",\"issue_date\":\"2023-01-01\"," +
+				"\"status\":\"draft\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			access := mock_services.NewMockDocumentAccess(c)
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(access, gsmService, worker, tc.input)
+
+			service := &services.Service{DocumentAccess: access, GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Put("/api/gsm/{document_id}", handler.updateOwnDocument)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "/api/gsm/1", bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, worker))
+			if tc.ifMatch != "" {
+				r.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedETag, w.Header().Get("ETag"))
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
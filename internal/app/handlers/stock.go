@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"net/http"
+)
+
+func (h *Handler) createReceipt(w http.ResponseWriter, r *http.Request) {
+	var receiptInput models.ReceiptInput
+
+	if err := json.NewDecoder(r.Body).Decode(&receiptInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := receiptInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.tenant(r).Stock.Receive(workerID, receiptInput)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, models.ErrFuelNotFound) {
+			status = http.StatusBadRequest
+		}
+		h.newErrResponse(w, status, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getStockMovements(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	from, err := parseDateParam(q.Get("from"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid from param")
+		return
+	}
+
+	to, err := parseDateParam(q.Get("to"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid to param")
+		return
+	}
+
+	filter := models.StockFilter{
+		FuelCode: q.Get("fuel_code"),
+		From:     from,
+		To:       to,
+	}
+
+	if err := filter.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	movements, err := h.tenant(r).Stock.Movements(filter)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, movements)
+}
+
+func (h *Handler) getStockBalances(w http.ResponseWriter, r *http.Request) {
+	asOf, err := parseDateParam(r.URL.Query().Get("as_of"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid as_of param")
+		return
+	}
+
+	balances, err := h.tenant(r).Stock.Balances(asOf)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, balances)
+}
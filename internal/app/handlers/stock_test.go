@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createReceipt(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockStock, receipt models.ReceiptInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputReceipt         models.ReceiptInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"fuel_code":"95", "amount":5000, "received_at":"2023-01-01", "note":"tank truck"}`,
+			inputReceipt: models.ReceiptInput{
+				FuelCode:   "95",
+				Amount:     5000,
+				ReceivedAt: toMyTime("2023-01-01"),
+				Note:       "tank truck",
+			},
+			mockBehavior: func(s *mock_services.MockStock, receipt models.ReceiptInput) {
+				s.EXPECT().Receive(1, receipt).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:                 "invalid amount",
+			inputBody:            `{"fuel_code":"95", "amount":0, "received_at":"2023-01-01"}`,
+			mockBehavior:         func(s *mock_services.MockStock, receipt models.ReceiptInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"amount can't be less than zero\"}\n",
+		},
+		{
+			name:      "unknown fuel",
+			inputBody: `{"fuel_code":"AI-100", "amount":5000, "received_at":"2023-01-01"}`,
+			inputReceipt: models.ReceiptInput{
+				FuelCode:   "AI-100",
+				Amount:     5000,
+				ReceivedAt: toMyTime("2023-01-01"),
+			},
+			mockBehavior: func(s *mock_services.MockStock, receipt models.ReceiptInput) {
+				s.EXPECT().Receive(1, receipt).Return(0, models.ErrFuelNotFound)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"fuel type doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			stockService := mock_services.NewMockStock(c)
+			tc.mockBehavior(stockService, tc.inputReceipt)
+
+			service := &services.Service{Stock: stockService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/stock/receipts", handler.createReceipt)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/stock/receipts", bytes.NewBufferString(tc.inputBody))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 1, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getStockBalances(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockStock)
+
+	asOf := toMyTime("2023-02-01")
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "ok",
+			query: "?as_of=2023-02-01",
+			mockBehavior: func(s *mock_services.MockStock) {
+				s.EXPECT().Balances(&asOf).Return([]models.StockBalance{
+					{FuelCode: "95", Received: 5000, Issued: 1200, Balance: 3800},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "[{\"fuel_code\":\"95\",\"received\":5000,\"issued\":1200," +
+				"\"balance\":3800}]\n",
+		},
+		{
+			name:  "today",
+			query: "",
+			mockBehavior: func(s *mock_services.MockStock) {
+				s.EXPECT().Balances(nil).Return([]models.StockBalance{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "[]\n",
+		},
+		{
+			name:                 "invalid as_of param",
+			query:                "?as_of=01.02.2023",
+			mockBehavior:         func(s *mock_services.MockStock) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid as_of param\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			stockService := mock_services.NewMockStock(c)
+			tc.mockBehavior(stockService)
+
+			service := &services.Service{Stock: stockService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/stock/balance", handler.getStockBalances)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/stock/balance"+tc.query, bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
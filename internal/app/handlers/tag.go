@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createTag(w http.ResponseWriter, r *http.Request) {
+	var tagInput models.CreateTagInput
+
+	if err := json.NewDecoder(r.Body).Decode(&tagInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := tagInput.Validate(); err != nil {
+		h.newInputErrResponse(w, err)
+		return
+	}
+
+	id, err := h.tenant(r).Tags.Create(tagInput)
+	if err != nil {
+		h.newErrResponse(w, tagErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tenant(r).Tags.GetAll()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, tags)
+}
+
+func (h *Handler) deleteTag(w http.ResponseWriter, r *http.Request) {
+	tagID, err := strconv.Atoi(chi.URLParam(r, "tag_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid tag_id param")
+		return
+	}
+
+	if err := h.tenant(r).Tags.Delete(tagID); err != nil {
+		h.newErrResponse(w, tagErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func tagErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrTagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrTagExists), errors.Is(err, models.ErrTagReferenced):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createTag(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockTags, tag models.CreateTagInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputTag             models.CreateTagInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"name":" urgent "}`,
+			inputTag:  models.CreateTagInput{Name: "urgent"},
+			mockBehavior: func(s *mock_services.MockTags, tag models.CreateTagInput) {
+				s.EXPECT().Create(tag).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:               "empty name",
+			inputBody:          `{"name":" "}`,
+			mockBehavior:       func(s *mock_services.MockTags, tag models.CreateTagInput) {},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponseBody: "{\"message\":\"validation failed\",\"errors\":[" +
+				"{\"field\":\"name\",\"code\":\"required\",\"message\":\"empty tag name\"}]}\n",
+		},
+		{
+			name:      "tag exists",
+			inputBody: `{"name":"urgent"}`,
+			inputTag:  models.CreateTagInput{Name: "urgent"},
+			mockBehavior: func(s *mock_services.MockTags, tag models.CreateTagInput) {
+				s.EXPECT().Create(tag).Return(0, models.ErrTagExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"tag with this name already exists\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagService := mock_services.NewMockTags(c)
+			tc.mockBehavior(tagService, tc.inputTag)
+
+			service := &services.Service{Tags: tagService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/tags", handler.createTag)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/tags", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteTag(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockTags)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockTags) {
+				s.EXPECT().Delete(1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name: "tag in use",
+			mockBehavior: func(s *mock_services.MockTags) {
+				s.EXPECT().Delete(1).Return(models.ErrTagReferenced)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"tag is set on documents\"}\n",
+		},
+		{
+			name: "tag not found",
+			mockBehavior: func(s *mock_services.MockTags) {
+				s.EXPECT().Delete(1).Return(models.ErrTagNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"tag doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tagService := mock_services.NewMockTags(c)
+			tc.mockBehavior(tagService)
+
+			service := &services.Service{Tags: tagService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/admin/tags/{tag_id}", handler.deleteTag)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", "/api/admin/tags/1", bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
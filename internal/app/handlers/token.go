@@ -15,7 +15,7 @@ func (h *Handler) getMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	worker, err := h.service.Administration.GetByID(workerID)
+	worker, err := h.tenant(r).Administration.GetByID(workerID)
 	if err != nil {
 		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
 		return
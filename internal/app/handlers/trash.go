@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) getTrash(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDocumentFilter(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	documents, err := h.tenant(r).GSMInterface.Trash(filter)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, documents)
+}
+
+func (h *Handler) restoreDocument(w http.ResponseWriter, r *http.Request) {
+	docID, err := strconv.Atoi(chi.URLParam(r, "document_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid document_id param")
+		return
+	}
+
+	workerID, err := getWorkerID(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).GSMInterface.Restore(docID, workerID); err != nil {
+		h.newErrResponse(w, documentErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "restored",
+	})
+}
+
+func (h *Handler) purgeTrash(w http.ResponseWriter, r *http.Request) {
+	var olderThanDays *int
+	if param := r.URL.Query().Get("older_than_days"); param != "" {
+		days, err := strconv.Atoi(param)
+		if err != nil || days < 0 {
+			h.newErrResponse(w, http.StatusBadRequest, "invalid older_than_days param")
+			return
+		}
+		olderThanDays = &days
+	}
+
+	purged, err := h.tenant(r).GSMInterface.Purge(olderThanDays)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"purged": purged,
+	})
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_deleteDocument(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			path: "/api/admin/gsm/1",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Delete(1, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name: "already deleted",
+			path: "/api/admin/gsm/1",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Delete(1, 2).Return(models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+		{
+			name: "closed period",
+			path: "/api/admin/gsm/1",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Delete(1, 2).Return(models.ErrPeriodClosed)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"" + models.ErrPeriodClosed.Error() + "\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/admin/gsm/{document_id}", handler.deleteDocument)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", tc.path, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 2, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTrash(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			path: "/api/admin/gsm/trash?sort=-deleted_at&limit=10",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Trash(models.DocumentFilter{Sort: "-deleted_at", Limit: 10}).
+					Return(models.DocumentList{Documents: []models.Document{}, Total: 0}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"documents\":[],\"total\":0}\n",
+		},
+		{
+			name:                 "invalid sort field",
+			path:                 "/api/admin/gsm/trash?sort=password",
+			mockBehavior:         func(s *mock_services.MockGSMInterface) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid sort field\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/admin/gsm/trash", handler.getTrash)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tc.path, bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_restoreDocument(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			path: "/api/admin/gsm/1/restore",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Restore(1, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"restored\"}\n",
+		},
+		{
+			name: "not in trash",
+			path: "/api/admin/gsm/1/restore",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Restore(1, 2).Return(models.ErrDocumentNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"document doesn't exist\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/gsm/{document_id}/restore", handler.restoreDocument)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", tc.path, bytes.NewBufferString(""))
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, models.WorkerAttributes{ID: 2, Role: "admin"}))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_purgeTrash(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockGSMInterface)
+
+	days := 7
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "configured retention",
+			path: "/api/admin/gsm/trash",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Purge(nil).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"purged\":3}\n",
+		},
+		{
+			name: "older than days",
+			path: "/api/admin/gsm/trash?older_than_days=7",
+			mockBehavior: func(s *mock_services.MockGSMInterface) {
+				s.EXPECT().Purge(&days).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"purged\":1}\n",
+		},
+		{
+			name:                 "invalid older_than_days param",
+			path:                 "/api/admin/gsm/trash?older_than_days=-1",
+			mockBehavior:         func(s *mock_services.MockGSMInterface) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid older_than_days param\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			gsmService := mock_services.NewMockGSMInterface(c)
+			tc.mockBehavior(gsmService)
+
+			service := &services.Service{GSMInterface: gsmService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/admin/gsm/trash", handler.purgeTrash)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", tc.path, bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) createVehicle(w http.ResponseWriter, r *http.Request) {
+	var vehicleInput models.CreateVehicleInput
+
+	if err := json.NewDecoder(r.Body).Decode(&vehicleInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := vehicleInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.tenant(r).VehicleInterface.Create(vehicleInput)
+	if err != nil {
+		h.newErrResponse(w, vehicleErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusCreated, map[string]interface{}{
+		"id": id,
+	})
+}
+
+func (h *Handler) getAllVehicles(w http.ResponseWriter, r *http.Request) {
+	vehicles, err := h.tenant(r).VehicleInterface.GetAll(false)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, vehicles)
+}
+
+func (h *Handler) getActiveVehicles(w http.ResponseWriter, r *http.Request) {
+	vehicles, err := h.tenant(r).VehicleInterface.GetAll(true)
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, vehicles)
+}
+
+func (h *Handler) getVehicleByID(w http.ResponseWriter, r *http.Request) {
+	vehicleID, err := strconv.Atoi(chi.URLParam(r, "vehicle_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid vehicle_id param")
+		return
+	}
+
+	vehicle, err := h.tenant(r).VehicleInterface.GetByID(vehicleID)
+	if err != nil {
+		h.newErrResponse(w, vehicleErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, vehicle)
+}
+
+func (h *Handler) updateVehicle(w http.ResponseWriter, r *http.Request) {
+	var vehicleInput models.UpdateVehicleInput
+
+	if err := json.NewDecoder(r.Body).Decode(&vehicleInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := vehicleInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	vehicleID, err := strconv.Atoi(chi.URLParam(r, "vehicle_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid vehicle_id param")
+		return
+	}
+
+	if err := h.tenant(r).VehicleInterface.Update(vehicleID, vehicleInput); err != nil {
+		h.newErrResponse(w, vehicleErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "updated",
+	})
+}
+
+func (h *Handler) deleteVehicle(w http.ResponseWriter, r *http.Request) {
+	vehicleID, err := strconv.Atoi(chi.URLParam(r, "vehicle_id"))
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid vehicle_id param")
+		return
+	}
+
+	if err := h.tenant(r).VehicleInterface.Delete(vehicleID); err != nil {
+		h.newErrResponse(w, vehicleErrStatus(err), err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "deleted",
+	})
+}
+
+func vehicleErrStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrVehicleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrDepartmentNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, models.ErrVehicleReferenced), errors.Is(err, models.ErrVehicleExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
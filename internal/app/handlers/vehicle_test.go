@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_createVehicle(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputVehicle         models.CreateVehicleInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"plate_number":"1111 AA-1", "model":"MAZ", "fuel_type":"DT", "tank_capacity":350}`,
+			inputVehicle: models.CreateVehicleInput{
+				PlateNumber:  "1111 AA-1",
+				Model:        "MAZ",
+				FuelType:     "DT",
+				TankCapacity: 350,
+			},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {
+				s.EXPECT().Create(vehicle).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:      "normalized plate_number",
+			inputBody: `{"plate_number":"1111aa1", "model":"MAZ", "fuel_type":"DT", "tank_capacity":350}`,
+			inputVehicle: models.CreateVehicleInput{
+				PlateNumber:  "1111 AA-1",
+				Model:        "MAZ",
+				FuelType:     "DT",
+				TankCapacity: 350,
+			},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {
+				s.EXPECT().Create(vehicle).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: "{\"id\":1}\n",
+		},
+		{
+			name:                 "invalid plate_number",
+			inputBody:            `{"plate_number":"1111 AA-9", "model":"MAZ", "fuel_type":"DT", "tank_capacity":350}`,
+			mockBehavior:         func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid plate_number\"}\n",
+		},
+		{
+			name:                 "invalid tank_capacity",
+			inputBody:            `{"plate_number":"1111 AA-1", "model":"MAZ", "fuel_type":"DT", "tank_capacity":0}`,
+			mockBehavior:         func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"tank_capacity can't be less than zero\"}\n",
+		},
+		{
+			name:      "plate_number taken",
+			inputBody: `{"plate_number":"1111 AA-1", "model":"MAZ", "fuel_type":"DT", "tank_capacity":350}`,
+			inputVehicle: models.CreateVehicleInput{
+				PlateNumber:  "1111 AA-1",
+				Model:        "MAZ",
+				FuelType:     "DT",
+				TankCapacity: 350,
+			},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {
+				s.EXPECT().Create(vehicle).Return(0, models.ErrVehicleExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"vehicle with this plate number already exists\"}\n",
+		},
+		{
+			name:      "service failure",
+			inputBody: `{"plate_number":"1111 AA-1", "model":"MAZ", "fuel_type":"DT", "tank_capacity":350}`,
+			inputVehicle: models.CreateVehicleInput{
+				PlateNumber:  "1111 AA-1",
+				Model:        "MAZ",
+				FuelType:     "DT",
+				TankCapacity: 350,
+			},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.CreateVehicleInput) {
+				s.EXPECT().Create(vehicle).Return(0, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			vehicleService := mock_services.NewMockVehicleInterface(c)
+			tc.mockBehavior(vehicleService, tc.inputVehicle)
+
+			service := &services.Service{VehicleInterface: vehicleService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Post("/api/admin/vehicle/", handler.createVehicle)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/api/admin/vehicle/", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateVehicle(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockVehicleInterface, vehicle models.UpdateVehicleInput)
+
+	plate, capacity, norm := "1111 AA-1", 400, 31.5
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputVehicle         models.UpdateVehicleInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:         "ok",
+			inputBody:    `{"plate_number":"1111aa1", "tank_capacity":400, "consumption_norm":31.5}`,
+			inputVehicle: models.UpdateVehicleInput{PlateNumber: &plate, TankCapacity: &capacity, ConsumptionNorm: &norm},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.UpdateVehicleInput) {
+				s.EXPECT().Update(1, vehicle).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"updated\"}\n",
+		},
+		{
+			name:                 "invalid tank_capacity",
+			inputBody:            `{"tank_capacity":0}`,
+			mockBehavior:         func(s *mock_services.MockVehicleInterface, vehicle models.UpdateVehicleInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"tank_capacity can't be less than zero\"}\n",
+		},
+		{
+			name:                 "invalid consumption_norm",
+			inputBody:            `{"consumption_norm":-2}`,
+			mockBehavior:         func(s *mock_services.MockVehicleInterface, vehicle models.UpdateVehicleInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"consumption_norm has to be greater than zero\"}\n",
+		},
+		{
+			name:         "plate_number taken",
+			inputBody:    `{"plate_number":"1111 AA-1"}`,
+			inputVehicle: models.UpdateVehicleInput{PlateNumber: &plate},
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicle models.UpdateVehicleInput) {
+				s.EXPECT().Update(1, vehicle).Return(models.ErrVehicleExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"vehicle with this plate number already exists\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			vehicleService := mock_services.NewMockVehicleInterface(c)
+			tc.mockBehavior(vehicleService, tc.inputVehicle)
+
+			service := &services.Service{VehicleInterface: vehicleService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Put("/api/admin/vehicle/{vehicle_id}", handler.updateVehicle)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "/api/admin/vehicle/1", bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteVehicle(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockVehicleInterface, vehicleID any)
+
+	testTable := []struct {
+		name                 string
+		vehicleID            any
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "ok",
+			vehicleID: 1,
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicleID any) {
+				s.EXPECT().Delete(vehicleID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"deleted\"}\n",
+		},
+		{
+			name:      "not found",
+			vehicleID: 1,
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicleID any) {
+				s.EXPECT().Delete(vehicleID).Return(models.ErrVehicleNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"vehicle doesn't exist\"}\n",
+		},
+		{
+			name:      "referenced",
+			vehicleID: 1,
+			mockBehavior: func(s *mock_services.MockVehicleInterface, vehicleID any) {
+				s.EXPECT().Delete(vehicleID).Return(models.ErrVehicleReferenced)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: "{\"message\":\"vehicle is referenced by documents, deactivate it instead\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			vehicleService := mock_services.NewMockVehicleInterface(c)
+			tc.mockBehavior(vehicleService, tc.vehicleID)
+
+			service := &services.Service{VehicleInterface: vehicleService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Delete("/api/admin/vehicle/{vehicle_id}", handler.deleteVehicle)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("DELETE", fmt.Sprintf("/api/admin/vehicle/%v", tc.vehicleID), bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) getWaybillSequences(w http.ResponseWriter, r *http.Request) {
+	sequences, err := h.tenant(r).Waybills.Sequences()
+	if err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, sequences)
+}
+
+func (h *Handler) resetWaybillSequence(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(chi.URLParam(r, "year"))
+	if err != nil || year <= 0 {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid year param")
+		return
+	}
+
+	var resetInput models.ResetSequenceInput
+
+	if err := json.NewDecoder(r.Body).Decode(&resetInput); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := resetInput.Validate(); err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tenant(r).Waybills.ResetSequence(year, resetInput); err != nil {
+		h.newErrResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newResponse(w, http.StatusOK, map[string]interface{}{
+		"status": "reset",
+	})
+}
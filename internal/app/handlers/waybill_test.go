@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/services"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_getWaybillSequences(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockWaybills)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(s *mock_services.MockWaybills) {
+				s.EXPECT().Sequences().Return([]models.WaybillSequence{
+					{Year: 2022, LastValue: 1873},
+					{Year: 2023, LastValue: 1042},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "[{\"year\":2022,\"last_value\":1873}," +
+				"{\"year\":2023,\"last_value\":1042}]\n",
+		},
+		{
+			name: "service failure",
+			mockBehavior: func(s *mock_services.MockWaybills) {
+				s.EXPECT().Sequences().Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "{\"message\":\"service failure\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			waybillService := mock_services.NewMockWaybills(c)
+			tc.mockBehavior(waybillService)
+
+			service := &services.Service{Waybills: waybillService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Get("/api/admin/waybill/sequences", handler.getWaybillSequences)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/api/admin/waybill/sequences", bytes.NewBufferString(""))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_resetWaybillSequence(t *testing.T) {
+	type mockBehavior func(s *mock_services.MockWaybills, year int, resetInput models.ResetSequenceInput)
+
+	lastValue := 999
+
+	testTable := []struct {
+		name                 string
+		year                 string
+		inputBody            string
+		inputYear            int
+		inputReset           models.ResetSequenceInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "ok",
+			year:       "2023",
+			inputBody:  `{"last_value":999}`,
+			inputYear:  2023,
+			inputReset: models.ResetSequenceInput{LastValue: &lastValue},
+			mockBehavior: func(s *mock_services.MockWaybills, year int, resetInput models.ResetSequenceInput) {
+				s.EXPECT().ResetSequence(year, resetInput).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "{\"status\":\"reset\"}\n",
+		},
+		{
+			name:                 "invalid year param",
+			year:                 "last",
+			inputBody:            `{"last_value":999}`,
+			mockBehavior:         func(s *mock_services.MockWaybills, year int, resetInput models.ResetSequenceInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid year param\"}\n",
+		},
+		{
+			name:                 "empty last_value",
+			year:                 "2023",
+			inputBody:            `{}`,
+			mockBehavior:         func(s *mock_services.MockWaybills, year int, resetInput models.ResetSequenceInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"empty last_value\"}\n",
+		},
+		{
+			name:                 "invalid last_value",
+			year:                 "2023",
+			inputBody:            `{"last_value":10000}`,
+			mockBehavior:         func(s *mock_services.MockWaybills, year int, resetInput models.ResetSequenceInput) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid last_value\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			waybillService := mock_services.NewMockWaybills(c)
+			tc.mockBehavior(waybillService, tc.inputYear, tc.inputReset)
+
+			service := &services.Service{Waybills: waybillService}
+			handler := NewHandler(service)
+
+			router := chi.NewRouter()
+			router.Put("/api/admin/waybill/sequences/{year}", handler.resetWaybillSequence)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "/api/admin/waybill/sequences/"+tc.year, bytes.NewBufferString(tc.inputBody))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
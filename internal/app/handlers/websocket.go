@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/HeadHardener/tp_lab/internal/app/models/websocket"
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/websocket"
@@ -17,10 +18,26 @@ func (h *WebSocketHandler) createRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.hub.Rooms[roomInput.ID] = &ws.Room{
-		ID:      roomInput.ID,
-		Name:    roomInput.Name,
-		Clients: make(map[int]*ws.Client),
+	// rooms with negative ids are the alert rooms of organizations
+	if roomInput.ID <= 0 {
+		h.newErrResponse(w, http.StatusBadRequest, "invalid room id, it has to be positive")
+		return
+	}
+
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.hub.AddRoom(&ws.Room{
+		ID:             roomInput.ID,
+		Name:           roomInput.Name,
+		OrganizationID: worker.OrganizationID,
+		Clients:        make(map[int]*ws.Client),
+	}) {
+		h.newErrResponse(w, http.StatusConflict, "room already exists")
+		return
 	}
 
 	newResponse(w, http.StatusOK, map[string]interface{}{
@@ -36,39 +53,50 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// joinRoom connects the worker to a room of the worker's organization, the client is identified
+// by the token, so messages addressed to a worker reach only that worker.
 func (h *WebSocketHandler) joinRoom(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	roomID, err := strconv.Atoi(chi.URLParam(r, "room_id"))
 	if err != nil {
-		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		h.newErrResponse(w, http.StatusBadRequest, "invalid room_id param")
 		return
 	}
 
-	roomID, err := strconv.Atoi(chi.URLParam(r, "room_id"))
+	worker, err := getWorkerAttributes(r)
 	if err != nil {
-		h.newErrResponse(w, http.StatusBadRequest, "invalid room_id param")
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	q := r.URL.Query()
-	clientID, err := strconv.Atoi(q.Get("user_id"))
+	// limit and overspend alerts are meant for admins only
+	if roomID == ws.AlertRoomID(worker.OrganizationID) && worker.Role != models.RoleAdmin {
+		h.newErrResponse(w, http.StatusForbidden, models.ErrNotEnoughRights.Error())
+		return
+	}
+
+	if !h.hub.CanJoin(worker.OrganizationID, roomID) {
+		h.newErrResponse(w, http.StatusNotFound, "room doesn't exist")
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		// the upgrader has responded already
 		return
 	}
-	username := q.Get("username")
 
 	client := &ws.Client{
 		Conn:     conn,
 		Message:  make(chan *ws.Message, 10),
-		ID:       clientID,
+		ID:       worker.ID,
 		RoomID:   roomID,
-		Username: username,
+		Username: worker.Name,
 	}
 
 	m := &ws.Message{
 		Content:  "new user has joined the room",
 		RoomID:   roomID,
-		Username: username,
+		Username: worker.Name,
 	}
 
 	// register new client
@@ -81,36 +109,32 @@ func (h *WebSocketHandler) joinRoom(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *WebSocketHandler) getRooms(w http.ResponseWriter, r *http.Request) {
-	var rooms []ws.RoomResponse
-
-	for _, r := range h.hub.Rooms {
-		rooms = append(rooms, ws.RoomResponse{
-			ID:   r.ID,
-			Name: r.Name,
-		})
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	newResponse(w, http.StatusOK, rooms)
+	newResponse(w, http.StatusOK, h.hub.OrganizationRooms(worker.OrganizationID))
 }
 
 func (h *WebSocketHandler) getClients(w http.ResponseWriter, r *http.Request) {
-	var clients []ws.ClientResponse
 	roomID, err := strconv.Atoi(chi.URLParam(r, "room_id"))
 	if err != nil {
 		h.newErrResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if _, ok := h.hub.Rooms[roomID]; !ok {
-		h.newErrResponse(w, http.StatusBadRequest, "room doesn't exist")
+	worker, err := getWorkerAttributes(r)
+	if err != nil {
+		h.newErrResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	for _, c := range h.hub.Rooms[roomID].Clients {
-		clients = append(clients, ws.ClientResponse{
-			ID:       c.ID,
-			Username: c.Username,
-		})
+	clients, ok := h.hub.Clients(worker.OrganizationID, roomID)
+	if !ok {
+		h.newErrResponse(w, http.StatusBadRequest, "room doesn't exist")
+		return
 	}
 
 	newResponse(w, http.StatusOK, clients)
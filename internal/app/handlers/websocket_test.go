@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/models/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebSocketHandler_joinRoom(t *testing.T) {
+	testTable := []struct {
+		name                 string
+		url                  string
+		worker               models.WorkerAttributes
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "alert room by a worker",
+			url:                  "/api/chat/join-room/-2",
+			worker:               models.WorkerAttributes{ID: 3, Role: models.RoleWorker, OrganizationID: 2},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"" + models.ErrNotEnoughRights.Error() + "\"}\n",
+		},
+		{
+			name:                 "alert room by a dispatcher",
+			url:                  "/api/chat/join-room/-2",
+			worker:               models.WorkerAttributes{ID: 3, Role: models.RoleDispatcher, OrganizationID: 2},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "{\"message\":\"" + models.ErrNotEnoughRights.Error() + "\"}\n",
+		},
+		{
+			name:                 "alert room of another organization",
+			url:                  "/api/chat/join-room/-5",
+			worker:               models.WorkerAttributes{ID: 3, Role: models.RoleAdmin, OrganizationID: 2},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"room doesn't exist\"}\n",
+		},
+		{
+			name:                 "room of another organization",
+			url:                  "/api/chat/join-room/7",
+			worker:               models.WorkerAttributes{ID: 3, Role: models.RoleWorker, OrganizationID: 2},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "{\"message\":\"room doesn't exist\"}\n",
+		},
+		{
+			name:                 "invalid room_id",
+			url:                  "/api/chat/join-room/alerts",
+			worker:               models.WorkerAttributes{ID: 3, Role: models.RoleAdmin, OrganizationID: 2},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "{\"message\":\"invalid room_id param\"}\n",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := ws.NewHub()
+			hub.AddRoom(&ws.Room{ID: 7, Name: "drivers", OrganizationID: 5, Clients: make(map[int]*ws.Client)})
+			handler := NewWSHandler(hub)
+
+			router := chi.NewRouter()
+			router.Get("/api/chat/join-room/{room_id}", handler.joinRoom)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tc.url, nil)
+			r = r.WithContext(context.WithValue(r.Context(), workerCtx, tc.worker))
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+// DocumentScope narrows documents the worker may read down to the ones the worker created
+// and, if DepartmentID is set, the ones of the worker's department.
+type DocumentScope struct {
+	WorkerID     int
+	DepartmentID *int
+}
+
+// DocumentScopeOf returns the scope of documents the worker may read. Admins, dispatchers
+// and auditors read every document of the organization and get nil scope.
+func DocumentScopeOf(worker Worker) *DocumentScope {
+	switch worker.Role {
+	case RoleAdmin, RoleDispatcher, RoleAuditor:
+		return nil
+	}
+
+	scope := &DocumentScope{WorkerID: worker.ID}
+	if worker.DepartmentAccess {
+		scope.DepartmentID = worker.DepartmentID
+	}
+
+	return scope
+}
+
+// Permits tells if the document created by the author is in the scope.
+func (s *DocumentScope) Permits(document Document, authorID int) bool {
+	if s == nil || authorID == s.WorkerID {
+		return true
+	}
+
+	return s.DepartmentID != nil && document.DepartmentID != nil && *s.DepartmentID == *document.DepartmentID
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDocumentScopeOf(t *testing.T) {
+	department := 4
+
+	testTable := []struct {
+		name          string
+		worker        Worker
+		expectedScope *DocumentScope
+	}{
+		{
+			name:   "admin",
+			worker: Worker{ID: 1, Role: RoleAdmin, DepartmentID: &department},
+		},
+		{
+			name:   "dispatcher",
+			worker: Worker{ID: 1, Role: RoleDispatcher, DepartmentID: &department},
+		},
+		{
+			name:   "auditor",
+			worker: Worker{ID: 1, Role: RoleAuditor},
+		},
+		{
+			name:   "admin with department access",
+			worker: Worker{ID: 1, Role: RoleAdmin, DepartmentID: &department, DepartmentAccess: true},
+		},
+		{
+			name:          "worker",
+			worker:        Worker{ID: 1, Role: RoleWorker, DepartmentID: &department},
+			expectedScope: &DocumentScope{WorkerID: 1},
+		},
+		{
+			name:          "worker with department access",
+			worker:        Worker{ID: 1, Role: RoleWorker, DepartmentID: &department, DepartmentAccess: true},
+			expectedScope: &DocumentScope{WorkerID: 1, DepartmentID: &department},
+		},
+		{
+			name:          "worker with department access out of departments",
+			worker:        Worker{ID: 1, Role: RoleWorker, DepartmentAccess: true},
+			expectedScope: &DocumentScope{WorkerID: 1},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedScope, DocumentScopeOf(tc.worker))
+		})
+	}
+}
+
+func TestDocumentScope_Permits(t *testing.T) {
+	department, otherDepartment := 4, 5
+
+	testTable := []struct {
+		name     string
+		scope    *DocumentScope
+		document Document
+		authorID int
+		expected bool
+	}{
+		{
+			name:     "no scope",
+			document: Document{DepartmentID: &otherDepartment},
+			authorID: 2,
+			expected: true,
+		},
+		{
+			name:     "own document",
+			scope:    &DocumentScope{WorkerID: 1},
+			document: Document{DepartmentID: &otherDepartment},
+			authorID: 1,
+			expected: true,
+		},
+		{
+			name:     "document of another worker",
+			scope:    &DocumentScope{WorkerID: 1},
+			document: Document{DepartmentID: &department},
+			authorID: 2,
+		},
+		{
+			name:     "document of the department",
+			scope:    &DocumentScope{WorkerID: 1, DepartmentID: &department},
+			document: Document{DepartmentID: &department},
+			authorID: 2,
+			expected: true,
+		},
+		{
+			name:     "document of another department",
+			scope:    &DocumentScope{WorkerID: 1, DepartmentID: &department},
+			document: Document{DepartmentID: &otherDepartment},
+			authorID: 2,
+		},
+		{
+			name:     "document out of departments",
+			scope:    &DocumentScope{WorkerID: 1, DepartmentID: &department},
+			document: Document{},
+			authorID: 2,
+		},
+		{
+			name:     "own document out of departments",
+			scope:    &DocumentScope{WorkerID: 1, DepartmentID: &department},
+			document: Document{},
+			authorID: 1,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.scope.Permits(tc.document, tc.authorID))
+		})
+	}
+}
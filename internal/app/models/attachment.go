@@ -0,0 +1,26 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment doesn't exist")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrAttachmentType     = errors.New("attachment type isn't allowed")
+	ErrAttachmentEmpty    = errors.New("attachment is empty")
+)
+
+type Attachment struct {
+	ID          int    `json:"id" db:"id"`
+	DocumentID  int    `json:"document_id" db:"document_id"`
+	FileName    string `json:"file_name" db:"file_name"`
+	ContentType string `json:"content_type" db:"content_type"`
+	Size        int64  `json:"size" db:"size"`
+	// Checksum is the hex encoded sha256 of the content
+	Checksum   string    `json:"checksum" db:"checksum"`
+	StorageKey string    `json:"-" db:"storage_key"`
+	UploadedBy *int      `json:"uploaded_by" db:"uploaded_by"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const MaxCommentLength = 2000
+
+var ErrCommentNotFound = errors.New("comment doesn't exist")
+
+// mentionPattern matches @<worker id> at the beginning of the text or after a space.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@(\d+)\b`)
+
+type Comment struct {
+	ID         int    `json:"id" db:"id"`
+	DocumentID int    `json:"document_id" db:"document_id"`
+	WorkerID   *int   `json:"worker_id" db:"worker_id"`
+	Author     string `json:"author" db:"author"`
+	// Body may mention workers as @<worker id>, they are notified about the comment
+	Body      string     `json:"body" db:"body"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CommentInput struct {
+	Body string `json:"body"`
+}
+
+func (c *CommentInput) Validate() error {
+	v := &ValidationError{}
+
+	c.Body = strings.TrimSpace(c.Body)
+	if c.Body == "" {
+		v.Add("body", CodeRequired, "empty comment")
+	} else if len([]rune(c.Body)) > MaxCommentLength {
+		v.Add("body", CodeInvalid, "comment is longer than "+strconv.Itoa(MaxCommentLength)+" characters")
+	}
+
+	return v.Err()
+}
+
+// Mentions returns ids of the workers mentioned in the text, every id is returned once.
+func Mentions(text string) []int {
+	seen := make(map[int]bool)
+	mentions := []int{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		workerID, err := strconv.Atoi(match[1])
+		if err != nil || seen[workerID] {
+			continue
+		}
+
+		seen[workerID] = true
+		mentions = append(mentions, workerID)
+	}
+
+	return mentions
+}
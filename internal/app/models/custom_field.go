@@ -0,0 +1,206 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CustomString = "string"
+	CustomNumber = "number"
+	CustomDate   = "date"
+	CustomEnum   = "enum"
+)
+
+var (
+	ErrCustomFieldNotFound = errors.New("custom field doesn't exist")
+	ErrCustomFieldExists   = errors.New("custom field with this code already exists")
+	// ErrCustomFieldReferenced is returned on deleting the field which documents have values of
+	ErrCustomFieldReferenced = errors.New("custom field is set on documents")
+)
+
+var customFieldCode = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CustomField is an extra attribute of documents defined by admins of the organization,
+// values are kept in the document by the field code.
+type CustomField struct {
+	ID   int    `json:"id" db:"id"`
+	Code string `json:"code" db:"code"`
+	Name string `json:"name" db:"name"`
+	Type string `json:"type" db:"type"`
+	// Options are the allowed values of enum fields
+	Options  StringList `json:"options,omitempty" db:"options"`
+	Required bool       `json:"required" db:"required"`
+}
+
+type CreateCustomFieldInput struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+func (f *CreateCustomFieldInput) Validate() error {
+	v := &ValidationError{}
+
+	if !customFieldCode.MatchString(f.Code) {
+		v.Add("code", CodeInvalid, "code has to start with a letter and contain only a-z, 0-9 and _")
+	}
+
+	if f.Name == "" {
+		v.Add("name", CodeRequired, "empty name")
+	}
+
+	switch f.Type {
+	case CustomString, CustomNumber, CustomDate:
+		if len(f.Options) != 0 {
+			v.Add("options", CodeInvalid, "options can be set only for enum fields")
+		}
+	case CustomEnum:
+		if len(f.Options) == 0 {
+			v.Add("options", CodeRequired, "enum field needs options")
+		}
+		for _, option := range f.Options {
+			if option == "" {
+				v.Add("options", CodeInvalid, "empty option")
+			}
+		}
+	default:
+		v.Add("type", CodeInvalid, "invalid type, allowed: string, number, date, enum")
+	}
+
+	return v.Err()
+}
+
+// CustomValues are values of the custom fields of the document stored as jsonb object.
+type CustomValues map[string]interface{}
+
+// Value implements the driver Valuer interface, nil values are stored as empty object.
+func (c CustomValues) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(map[string]interface{}(c))
+}
+
+// Scan implements the Scanner interface.
+func (c *CustomValues) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*map[string]interface{})(c))
+	case string:
+		return json.Unmarshal([]byte(v), (*map[string]interface{})(c))
+	default:
+		return errors.New("unsupported custom values value")
+	}
+}
+
+// Parse types the values given as text, like csv cells or query params, by the field definitions.
+// Text of number fields which isn't a number is kept, so the validation reports it.
+func (c CustomValues) Parse(fields []CustomField) CustomValues {
+	types := make(map[string]string, len(fields))
+	for _, field := range fields {
+		types[field.Code] = field.Type
+	}
+
+	values := make(CustomValues, len(c))
+	for code, value := range c {
+		values[code] = value
+
+		text, ok := value.(string)
+		if !ok || types[code] != CustomNumber {
+			continue
+		}
+
+		if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			values[code] = number
+		}
+	}
+
+	return values
+}
+
+// Equal reports whether the documents have the same values, nil and empty values are equal.
+func (c CustomValues) Equal(other CustomValues) bool {
+	if len(c) == 0 || len(other) == 0 {
+		return len(c) == len(other)
+	}
+
+	return reflect.DeepEqual(c, other)
+}
+
+// CheckCustomValues validates values of the document against the field definitions,
+// every value has to be defined and every required field has to have a value.
+func CheckCustomValues(fields []CustomField, values CustomValues) error {
+	v := &ValidationError{}
+
+	defined := make(map[string]CustomField, len(fields))
+	for _, field := range fields {
+		defined[field.Code] = field
+
+		if _, ok := values[field.Code]; field.Required && !ok {
+			v.Add("custom_fields."+field.Code, CodeRequired, field.Name+" is required")
+		}
+	}
+
+	codes := make([]string, 0, len(values))
+	for code := range values {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		field, ok := defined[code]
+		if !ok {
+			v.Add("custom_fields."+code, CodeInvalid, "unknown custom field")
+			continue
+		}
+
+		if err := field.check(values[code]); err != nil {
+			v.Add("custom_fields."+code, CodeInvalid, err.Error())
+		}
+	}
+
+	return v.Err()
+}
+
+func (f CustomField) check(value interface{}) error {
+	switch f.Type {
+	case CustomNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s has to be a number", f.Name)
+		}
+		return nil
+	}
+
+	text, ok := value.(string)
+	if !ok || text == "" {
+		return fmt.Errorf("%s has to be a non-empty string", f.Name)
+	}
+
+	switch f.Type {
+	case CustomDate:
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return fmt.Errorf("%s has to be a date in format YYYY-MM-DD", f.Name)
+		}
+	case CustomEnum:
+		for _, option := range f.Options {
+			if option == text {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s has to be one of: %s", f.Name, strings.Join(f.Options, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var customFields = []CustomField{
+	{Code: "card", Name: "Fuel card", Type: CustomString, Required: true},
+	{Code: "trip_km", Name: "Trip length", Type: CustomNumber},
+	{Code: "returned", Name: "Return date", Type: CustomDate},
+	{Code: "purpose", Name: "Purpose", Type: CustomEnum, Options: StringList{"intercity", "repair"}},
+}
+
+func TestCheckCustomValues(t *testing.T) {
+	testTable := []struct {
+		name           string
+		values         CustomValues
+		expectedFields []FieldError
+	}{
+		{
+			name: "ok",
+			values: CustomValues{
+				"card":     "12",
+				"trip_km":  340.5,
+				"returned": "2023-01-02",
+				"purpose":  "repair",
+			},
+		},
+		{
+			name:   "only required",
+			values: CustomValues{"card": "12"},
+		},
+		{
+			name:   "required missing",
+			values: CustomValues{"trip_km": 340.0},
+			expectedFields: []FieldError{
+				{Field: "custom_fields.card", Code: CodeRequired, Message: "Fuel card is required"},
+			},
+		},
+		{
+			name: "invalid values",
+			values: CustomValues{
+				"card":     "",
+				"trip_km":  "340",
+				"returned": "02.01.2023",
+				"purpose":  "vacation",
+				"cost":     "15",
+			},
+			expectedFields: []FieldError{
+				{Field: "custom_fields.card", Code: CodeInvalid, Message: "Fuel card has to be a non-empty string"},
+				{Field: "custom_fields.cost", Code: CodeInvalid, Message: "unknown custom field"},
+				{Field: "custom_fields.purpose", Code: CodeInvalid,
+					Message: "Purpose has to be one of: intercity, repair"},
+				{Field: "custom_fields.returned", Code: CodeInvalid,
+					Message: "Return date has to be a date in format YYYY-MM-DD"},
+				{Field: "custom_fields.trip_km", Code: CodeInvalid, Message: "Trip length has to be a number"},
+			},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckCustomValues(customFields, tc.values)
+
+			if tc.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.IsType(t, &ValidationError{}, err) {
+				assert.Equal(t, tc.expectedFields, err.(*ValidationError).Fields)
+			}
+		})
+	}
+}
+
+func TestCustomValues_Parse(t *testing.T) {
+	values := CustomValues{
+		"card":     "0012",
+		"trip_km":  " 340.5 ",
+		"returned": "2023-01-02",
+		"cost":     "15",
+	}
+
+	assert.Equal(t, CustomValues{
+		"card":     "0012",
+		"trip_km":  340.5,
+		"returned": "2023-01-02",
+		"cost":     "15",
+	}, values.Parse(customFields))
+
+	// text which isn't a number is left for the validation to report
+	assert.Equal(t, CustomValues{"trip_km": "far"}, CustomValues{"trip_km": "far"}.Parse(customFields))
+}
+
+func TestCustomValues_Equal(t *testing.T) {
+	testTable := []struct {
+		name     string
+		a, b     CustomValues
+		expected bool
+	}{
+		{name: "nil and empty", a: nil, b: CustomValues{}, expected: true},
+		{name: "same", a: CustomValues{"card": "12", "trip_km": 5.0}, b: CustomValues{"trip_km": 5.0, "card": "12"},
+			expected: true},
+		{name: "value changed", a: CustomValues{"card": "12"}, b: CustomValues{"card": "13"}},
+		{name: "value removed", a: CustomValues{"card": "12"}, b: CustomValues{}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.a.Equal(tc.b))
+			assert.Equal(t, tc.expected, tc.b.Equal(tc.a))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrDepartmentNotFound = errors.New("department doesn't exist")
+	ErrDepartmentExists   = errors.New("department with this name already exists")
+)
+
+type Department struct {
+	ID             int    `json:"id" db:"id"`
+	Name           string `json:"name" db:"name"`
+	OrganizationID int    `json:"organization_id" db:"organization_id"`
+}
+
+type CreateDepartmentInput struct {
+	Name string `json:"name"`
+}
+
+func (d *CreateDepartmentInput) Validate() error {
+	v := &ValidationError{}
+
+	if d.Name == "" {
+		v.Add("name", CodeRequired, "empty name")
+	}
+
+	return v.Err()
+}
@@ -4,73 +4,190 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 )
 
 type MyTime time.Time
 
-var checkCarID = regexp.MustCompile(`^[0-9]{4}\s[A-Z]{2}-[1-7]$`)
+var (
+	ErrDocumentNotFound  = errors.New("document doesn't exist")
+	ErrWaybillTaken      = errors.New("waybill number is already used in this year")
+	ErrOdometerDecreased = errors.New("odometer_start can't be less than odometer_end of the vehicle's previous waybill")
+)
 
 type Document struct {
 	ID         int    `json:"ID" db:"id"`
 	Car        string `json:"car" db:"car"`
 	CarID      string `json:"car_id" db:"car_id"`
+	VehicleID  int    `json:"vehicle_id" db:"vehicle_id"`
 	Waybill    int    `json:"waybill" db:"waybill"`
 	DriverName string `json:"driver_name" db:"driver_name"`
 	GasAmount  int    `json:"gas_amount" db:"gas_amount"`
 	GasType    string `json:"gas_type" db:"gas_type"`
 	IssueDate  MyTime `json:"issue_date" db:"issue_date"`
+	Status     string `json:"status" db:"status"`
+	// odometer readings on departure and return, documents issued before they were introduced don't have them
+	OdometerStart *int `json:"odometer_start,omitempty" db:"odometer_start"`
+	OdometerEnd   *int `json:"odometer_end,omitempty" db:"odometer_end"`
+	// Mileage and Consumption in liters per 100 km are computed by the database from the odometer readings
+	Mileage     *int     `json:"mileage,omitempty" db:"mileage"`
+	Consumption *float64 `json:"consumption,omitempty" db:"consumption"`
+	// NormExceeded marks documents which consumption exceeds the vehicle norm more than allowed
+	NormExceeded bool `json:"norm_exceeded,omitempty" db:"norm_exceeded"`
+	// LimitExceeded marks documents which took a fuel limit of the vehicle or its department over the amount
+	LimitExceeded bool `json:"limit_exceeded,omitempty" db:"limit_exceeded"`
+	// Cost is computed from the fuel price in effect on the issue date, it's empty if there is no such price
+	Cost *float64 `json:"cost,omitempty" db:"cost"`
+	// StockShortage marks approved documents which left the fuel stock below zero
+	StockShortage bool `json:"stock_shortage,omitempty" db:"stock_shortage"`
+	// DeletedAt is set when the document was moved to the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	DeletedBy *int       `json:"deleted_by,omitempty" db:"deleted_by"`
+	// Version is sent as the ETag header
+	Version int `json:"-" db:"version"`
+	// OrganizationID and DepartmentID are taken from the author when the document is created
+	OrganizationID int  `json:"organization_id,omitempty" db:"organization_id"`
+	DepartmentID   *int `json:"department_id,omitempty" db:"department_id"`
+	// Tags are names of the tags defined in the organization
+	Tags         StringList   `json:"tags,omitempty" db:"tags"`
+	CustomFields CustomValues `json:"custom_fields,omitempty" db:"custom_fields"`
 }
 
 type CreateDocInput struct {
-	Car        string `json:"car" db:"car"`
-	CarID      string `json:"car_id" db:"car_id"`
-	Waybill    int    `json:"waybill" db:"waybill"`
-	DriverName string `json:"driver_name" db:"driver_name"`
-	GasAmount  int    `json:"gas_amount" db:"gas_amount"`
-	GasType    string `json:"gas_type" db:"gas_type"`
-	IssueDate  MyTime `json:"issue_date" db:"issue_date"`
+	VehicleID     int    `json:"vehicle_id" db:"vehicle_id"`
+	Waybill       int    `json:"waybill" db:"waybill"`
+	DriverName    string `json:"driver_name" db:"driver_name"`
+	GasAmount     int    `json:"gas_amount" db:"gas_amount"`
+	GasType       string `json:"gas_type" db:"gas_type"`
+	IssueDate     MyTime `json:"issue_date" db:"issue_date"`
+	OdometerStart *int   `json:"odometer_start" db:"odometer_start"`
+	OdometerEnd   *int   `json:"odometer_end" db:"odometer_end"`
+	// Tags and CustomFields are checked against the definitions of the organization by the service
+	Tags         []string     `json:"tags"`
+	CustomFields CustomValues `json:"custom_fields"`
 }
 
 type UpdateDocInput struct {
-	Car        *string `json:"car" db:"car"`
-	CarID      *string `json:"car_id" db:"car_id"`
-	Waybill    *int    `json:"waybill" db:"waybill"`
-	DriverName *string `json:"driver_name" db:"driver_name"`
-	GasAmount  *int    `json:"gas_amount" db:"gas_amount"`
-	GasType    *string `json:"gas_type" db:"gas_type"`
-	IssueDate  *MyTime `json:"issue_date" db:"issue_date"`
+	VehicleID     *int    `json:"vehicle_id" db:"vehicle_id"`
+	Waybill       *int    `json:"waybill" db:"waybill"`
+	DriverName    *string `json:"driver_name" db:"driver_name"`
+	GasAmount     *int    `json:"gas_amount" db:"gas_amount"`
+	GasType       *string `json:"gas_type" db:"gas_type"`
+	IssueDate     *MyTime `json:"issue_date" db:"issue_date"`
+	OdometerStart *int    `json:"odometer_start" db:"odometer_start"`
+	OdometerEnd   *int    `json:"odometer_end" db:"odometer_end"`
+	// Tags and CustomFields replace the whole lists of the document if they are set
+	Tags         []string     `json:"tags"`
+	CustomFields CustomValues `json:"custom_fields"`
 }
 
 func (d *CreateDocInput) Validate() error {
-	if d.Car == "" || d.DriverName == "" || d.GasType == "" {
-		return errors.New("there can't be empty fields")
+	v := &ValidationError{}
+
+	if d.VehicleID <= 0 {
+		v.Add("vehicle_id", CodeInvalid, "invalid vehicle_id")
 	}
 
-	if !checkCarID.MatchString(d.CarID) {
-		return errors.New("invalid car_id")
+	// zero waybill is allocated from the sequence of the issue year
+	if d.Waybill != 0 && !validWaybill(d.Waybill) {
+		v.Add("waybill", CodeOutOfRange, "invalid waybill value")
 	}
 
-	if d.Waybill < 1000 || d.Waybill > 9999 {
-		return errors.New("invalid waybill value")
+	if d.DriverName == "" {
+		v.Add("driver_name", CodeRequired, "empty driver_name")
 	}
 
 	if d.GasAmount <= 0 {
-		return errors.New("gas_amount can't be less than zero")
+		v.Add("gas_amount", CodeOutOfRange, "gas_amount can't be less than zero")
 	}
 
-	return nil
+	if d.GasType == "" {
+		v.Add("gas_type", CodeRequired, "empty gas_type")
+	}
+
+	if time.Time(d.IssueDate).IsZero() {
+		v.Add("issue_date", CodeRequired, "empty issue_date")
+	}
+
+	validateOdometer(v, d.OdometerStart, d.OdometerEnd)
+	validateTags(v, d.Tags)
+
+	return v.Err()
 }
 
-func (d *UpdateDocInput) ToDocument(doc *Document) {
-	if d.Car != nil && doc.Car != *d.Car {
-		doc.Car = *d.Car
+// Validate checks the fields which are going to be changed, readings of the odometer
+// are checked together with the stored ones by Document.CheckOdometer.
+func (d *UpdateDocInput) Validate() error {
+	v := &ValidationError{}
+
+	if d.VehicleID != nil && *d.VehicleID <= 0 {
+		v.Add("vehicle_id", CodeInvalid, "invalid vehicle_id")
+	}
+
+	if d.Waybill != nil && !validWaybill(*d.Waybill) {
+		v.Add("waybill", CodeOutOfRange, "invalid waybill value")
+	}
+
+	if d.DriverName != nil && *d.DriverName == "" {
+		v.Add("driver_name", CodeRequired, "empty driver_name")
+	}
+
+	if d.GasAmount != nil && *d.GasAmount <= 0 {
+		v.Add("gas_amount", CodeOutOfRange, "gas_amount can't be less than zero")
+	}
+
+	if d.GasType != nil && *d.GasType == "" {
+		v.Add("gas_type", CodeRequired, "empty gas_type")
+	}
+
+	if d.IssueDate != nil && time.Time(*d.IssueDate).IsZero() {
+		v.Add("issue_date", CodeRequired, "empty issue_date")
 	}
 
-	if d.CarID != nil && doc.CarID != *d.CarID && checkCarID.MatchString(*d.CarID) {
-		doc.CarID = *d.CarID
+	if d.OdometerStart != nil && d.OdometerEnd != nil {
+		validateOdometer(v, d.OdometerStart, d.OdometerEnd)
+	}
+
+	validateTags(v, d.Tags)
+
+	return v.Err()
+}
+
+// CheckOdometer validates odometer readings of the document after an update.
+func (d *Document) CheckOdometer() error {
+	v := &ValidationError{}
+	validateOdometer(v, d.OdometerStart, d.OdometerEnd)
+
+	return v.Err()
+}
+
+// FuelConsumption returns consumption in liters per 100 km, ok is false if the mileage is unknown.
+func (d *Document) FuelConsumption() (consumption float64, ok bool) {
+	if d.OdometerStart == nil || d.OdometerEnd == nil || *d.OdometerEnd == *d.OdometerStart {
+		return 0, false
+	}
+
+	return float64(d.GasAmount) * 100 / float64(*d.OdometerEnd-*d.OdometerStart), true
+}
+
+func validateOdometer(v *ValidationError, start, end *int) {
+	switch {
+	case start == nil && end == nil:
+	case start == nil:
+		v.Add("odometer_start", CodeRequired, "odometer_start and odometer_end have to be set together")
+	case end == nil:
+		v.Add("odometer_end", CodeRequired, "odometer_start and odometer_end have to be set together")
+	case *start < 0:
+		v.Add("odometer_start", CodeOutOfRange, "odometer_start can't be less than zero")
+	case *end < *start:
+		v.Add("odometer_end", CodeOutOfRange, "odometer_end can't be less than odometer_start")
+	}
+}
+
+func (d *UpdateDocInput) ToDocument(doc *Document) {
+	if d.VehicleID != nil && doc.VehicleID != *d.VehicleID {
+		doc.VehicleID = *d.VehicleID
 	}
 
 	if d.Waybill != nil && doc.Waybill != *d.Waybill {
@@ -92,6 +209,22 @@ func (d *UpdateDocInput) ToDocument(doc *Document) {
 	if d.IssueDate != nil && doc.IssueDate != *d.IssueDate {
 		doc.IssueDate = *d.IssueDate
 	}
+
+	if d.OdometerStart != nil {
+		doc.OdometerStart = d.OdometerStart
+	}
+
+	if d.OdometerEnd != nil {
+		doc.OdometerEnd = d.OdometerEnd
+	}
+
+	if d.Tags != nil {
+		doc.Tags = d.Tags
+	}
+
+	if d.CustomFields != nil {
+		doc.CustomFields = d.CustomFields
+	}
 }
 
 func (mt *MyTime) UnmarshalJSON(b []byte) error {
@@ -112,6 +245,10 @@ func (mt MyTime) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + time.Time(mt).Format("2006-01-02") + `"`), nil
 }
 
+func (mt MyTime) Before(t MyTime) bool {
+	return time.Time(mt).Before(time.Time(t))
+}
+
 // Value implements the driver Valuer interface.
 func (mt MyTime) Value() (driver.Value, error) {
 	return driver.Value(time.Time(mt)), nil
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+var documentSortFields = map[string]bool{
+	"id":          true,
+	"car_id":      true,
+	"vehicle_id":  true,
+	"waybill":     true,
+	"driver_name": true,
+	"gas_amount":  true,
+	"gas_type":    true,
+	"issue_date":  true,
+	"status":      true,
+	"mileage":     true,
+	"consumption": true,
+	"deleted_at":  true,
+}
+
+type DocumentFilter struct {
+	WorkerID  int
+	VehicleID int
+	// DepartmentID selects documents of the department
+	DepartmentID int
+	CarID        string
+	DriverName   string
+	GasType      string
+	IssuedFrom   *MyTime
+	IssuedTo     *MyTime
+	Status       string
+	// NormExceeded selects only documents with consumption above the vehicle norm
+	NormExceeded bool
+	// LimitExceeded selects only documents which exceeded a fuel limit
+	LimitExceeded bool
+	// Deleted selects documents from the trash instead of the active ones
+	Deleted bool
+	// Tags selects documents having all of the tags
+	Tags []string
+	// CustomFields selects documents with the values of the custom fields by their codes,
+	// values come as text and are typed by the service from the field definitions
+	CustomFields CustomValues
+	// Scope is set by the access policy, it's nil for workers who may read every document
+	Scope  *DocumentScope
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+type DocumentList struct {
+	Documents     []Document `json:"documents"`
+	Total         int        `json:"total"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+func (f *DocumentFilter) Validate() error {
+	if f.Limit == 0 {
+		f.Limit = DefaultPageLimit
+	}
+
+	if f.Limit < 0 || f.Limit > MaxPageLimit {
+		return errors.New("invalid limit value")
+	}
+
+	if f.Offset < 0 {
+		return errors.New("invalid offset value")
+	}
+
+	if f.Sort != "" && !documentSortFields[strings.TrimPrefix(f.Sort, "-")] {
+		return errors.New("invalid sort field")
+	}
+
+	if f.Status != "" && !IsStatus(f.Status) {
+		return errors.New("invalid status")
+	}
+
+	if f.IssuedFrom != nil && f.IssuedTo != nil && f.IssuedTo.Before(*f.IssuedFrom) {
+		return errors.New("issue_date_to can't be before issue_date_from")
+	}
+
+	return nil
+}
+
+// OrderBy returns the column and direction the list has to be sorted by.
+func (f *DocumentFilter) OrderBy() (string, string) {
+	if f.Sort == "" {
+		return "id", "asc"
+	}
+
+	if strings.HasPrefix(f.Sort, "-") {
+		return strings.TrimPrefix(f.Sort, "-"), "desc"
+	}
+
+	return f.Sort, "asc"
+}
+
+// NextPageToken returns the token of the page following the current one
+// or an empty string if the current page is the last one.
+func (f *DocumentFilter) NextPageToken(total int) string {
+	next := f.Offset + f.Limit
+	if next >= total {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(next)))
+}
+
+func ParsePageToken(token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid page_token")
+	}
+
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid page_token")
+	}
+
+	return offset, nil
+}
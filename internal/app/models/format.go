@@ -0,0 +1,26 @@
+package models
+
+import "github.com/HeadHardener/tp_lab/internal/pkg/format"
+
+// formats allowed in the deployment, see UseFormats
+var (
+	plateFormats, _ = format.Plates.Select([]string{"by"})
+	phoneFormats, _ = format.Phones.Select([]string{"by"})
+)
+
+// UseFormats sets the plate and phone number formats accepted by the inputs, it has to be
+// called on start before the inputs are validated.
+func UseFormats(plates, phones *format.Registry) {
+	plateFormats = plates
+	phoneFormats = phones
+}
+
+// NormalizePlate returns the plate number in the canonical form of its format.
+func NormalizePlate(plate string) (string, bool) {
+	return plateFormats.Normalize(plate)
+}
+
+// NormalizePhone returns the phone number in the canonical form of its format.
+func NormalizePhone(phone string) (string, bool) {
+	return phoneFormats.Normalize(phone)
+}
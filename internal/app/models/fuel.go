@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var checkFuelCode = regexp.MustCompile(`^[0-9A-Za-z_-]{1,50}$`)
+
+var (
+	ErrFuelNotFound   = errors.New("fuel type doesn't exist")
+	ErrFuelInactive   = errors.New("fuel type is inactive")
+	ErrFuelExists     = errors.New("fuel type with this code already exists")
+	ErrFuelReferenced = errors.New("fuel type is referenced by documents, deactivate it instead")
+)
+
+type Fuel struct {
+	Code   string `json:"code" db:"code"`
+	Name   string `json:"name" db:"name"`
+	Unit   string `json:"unit" db:"unit"`
+	Active bool   `json:"active" db:"active"`
+	// Price is the price in effect today, it's empty if no price was set yet
+	Price *float64 `json:"price" db:"price"`
+}
+
+type FuelPrice struct {
+	ID            int     `json:"id" db:"id"`
+	FuelCode      string  `json:"fuel_code" db:"fuel_code"`
+	Price         float64 `json:"price" db:"price"`
+	EffectiveFrom MyTime  `json:"effective_from" db:"effective_from"`
+}
+
+type CreateFuelInput struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+}
+
+type UpdateFuelInput struct {
+	Name   *string `json:"name"`
+	Unit   *string `json:"unit"`
+	Active *bool   `json:"active"`
+}
+
+type FuelPriceInput struct {
+	Price         float64 `json:"price"`
+	EffectiveFrom MyTime  `json:"effective_from"`
+}
+
+func (f *CreateFuelInput) Validate() error {
+	if f.Name == "" || f.Unit == "" {
+		return errors.New("there can't be empty fields")
+	}
+
+	if !checkFuelCode.MatchString(f.Code) {
+		return errors.New("invalid code")
+	}
+
+	return nil
+}
+
+func (f *UpdateFuelInput) ToFuel(fuel *Fuel) {
+	if f.Name != nil && *f.Name != "" {
+		fuel.Name = *f.Name
+	}
+
+	if f.Unit != nil && *f.Unit != "" {
+		fuel.Unit = *f.Unit
+	}
+
+	if f.Active != nil {
+		fuel.Active = *f.Active
+	}
+}
+
+func (f *FuelPriceInput) Validate() error {
+	if f.Price < 0 {
+		return errors.New("price can't be less than zero")
+	}
+
+	if time.Time(f.EffectiveFrom).IsZero() {
+		return errors.New("empty effective_from")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+)
+
+type ImportRow struct {
+	Line  int
+	Input CreateDocInput
+}
+
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	DryRun      bool             `json:"dry_run"`
+	Total       int              `json:"total"`
+	Valid       int              `json:"valid"`
+	Imported    int              `json:"imported"`
+	DocumentIDs []int            `json:"document_ids"`
+	Errors      []ImportRowError `json:"errors"`
+}
+
+// BatchError tells which item of a batch made the whole batch fail.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
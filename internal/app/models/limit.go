@@ -0,0 +1,150 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// limit periods
+const (
+	LimitMonth   = "month"
+	LimitQuarter = "quarter"
+)
+
+// limit units, money is counted from the fuel prices in effect on the issue dates
+const (
+	LimitLiters = "liters"
+	LimitMoney  = "money"
+)
+
+// DefaultAlertPercent is the usage share which triggers an alert if the limit doesn't set its own
+const DefaultAlertPercent = 80
+
+var (
+	ErrLimitNotFound = errors.New("limit doesn't exist")
+	ErrLimitExists   = errors.New("limit with this period and unit is already set")
+	ErrLimitExceeded = errors.New("document exceeds the fuel limit")
+)
+
+// Limit caps fuel issued for the vehicle or for all vehicles of the department in every month or quarter.
+type Limit struct {
+	ID           int     `json:"id" db:"id"`
+	VehicleID    *int    `json:"vehicle_id,omitempty" db:"vehicle_id"`
+	DepartmentID *int    `json:"department_id,omitempty" db:"department_id"`
+	Period       string  `json:"period" db:"period"`
+	Unit         string  `json:"unit" db:"unit"`
+	Amount       float64 `json:"amount" db:"amount"`
+	// AlertPercent is the share of the amount which is broadcast as a warning once crossed
+	AlertPercent float64 `json:"alert_percent" db:"alert_percent"`
+}
+
+type CreateLimitInput struct {
+	VehicleID    *int     `json:"vehicle_id"`
+	DepartmentID *int     `json:"department_id"`
+	Period       string   `json:"period"`
+	Unit         string   `json:"unit"`
+	Amount       float64  `json:"amount"`
+	AlertPercent *float64 `json:"alert_percent"`
+}
+
+// LimitUsage is the state of the limit in the period containing the requested date.
+type LimitUsage struct {
+	Limit
+	From    MyTime  `json:"from"`
+	To      MyTime  `json:"to"`
+	Used    float64 `json:"used"`
+	Percent float64 `json:"percent"`
+	// State is "ok", "warning" once the alert share is crossed or "exceeded"
+	State string `json:"state"`
+}
+
+// LimitAlert tells that a document has pushed the usage of the limit over its alert share or over the limit.
+type LimitAlert struct {
+	Limit    Limit
+	From     time.Time
+	Used     float64
+	Exceeded bool
+}
+
+func (l *CreateLimitInput) Validate() error {
+	v := &ValidationError{}
+
+	switch {
+	case l.VehicleID == nil && l.DepartmentID == nil:
+		v.Add("vehicle_id", CodeRequired, "either vehicle_id or department_id has to be set")
+	case l.VehicleID != nil && l.DepartmentID != nil:
+		v.Add("department_id", CodeInvalid, "limit can't be set for a vehicle and a department at once")
+	case l.VehicleID != nil && *l.VehicleID <= 0:
+		v.Add("vehicle_id", CodeInvalid, "invalid vehicle_id")
+	case l.DepartmentID != nil && *l.DepartmentID <= 0:
+		v.Add("department_id", CodeInvalid, "invalid department_id")
+	}
+
+	if l.Period != LimitMonth && l.Period != LimitQuarter {
+		v.Add("period", CodeInvalid, "invalid period, allowed: month, quarter")
+	}
+
+	if l.Unit != LimitLiters && l.Unit != LimitMoney {
+		v.Add("unit", CodeInvalid, "invalid unit, allowed: liters, money")
+	}
+
+	if l.Amount <= 0 {
+		v.Add("amount", CodeOutOfRange, "amount has to be greater than zero")
+	}
+
+	if l.AlertPercent != nil && (*l.AlertPercent <= 0 || *l.AlertPercent > 100) {
+		v.Add("alert_percent", CodeOutOfRange, "alert_percent has to be between 0 and 100")
+	}
+
+	return v.Err()
+}
+
+// Bounds returns the first day of the limit period containing the date and the first day of the next one.
+func (l Limit) Bounds(date MyTime) (from, to time.Time) {
+	from = PeriodOf(date)
+	months := 1
+	if l.Period == LimitQuarter {
+		from = from.AddDate(0, -int(from.Month()-1)%3, 0)
+		months = 3
+	}
+
+	return from, from.AddDate(0, months, 0)
+}
+
+// State tells how much of the limit is used.
+func (l Limit) State(used float64) string {
+	switch {
+	case used > l.Amount:
+		return "exceeded"
+	case used >= l.Amount*l.AlertPercent/100:
+		return "warning"
+	default:
+		return "ok"
+	}
+}
+
+func (a LimitAlert) String() string {
+	var owner string
+	switch {
+	case a.Limit.DepartmentID != nil:
+		owner = fmt.Sprintf("department %d", *a.Limit.DepartmentID)
+	case a.Limit.VehicleID != nil:
+		owner = fmt.Sprintf("vehicle %d", *a.Limit.VehicleID)
+	default:
+		owner = fmt.Sprintf("limit %d", a.Limit.ID)
+	}
+
+	period := a.From.Format(PeriodLayout)
+	if a.Limit.Period == LimitQuarter {
+		period = fmt.Sprintf("%d Q%d", a.From.Year(), (a.From.Month()-1)/3+1)
+	}
+
+	if a.Exceeded {
+		return fmt.Sprintf("%s has exceeded the %s %s limit of %.2f in %s: %.2f used",
+			owner, a.Limit.Period, a.Limit.Unit, a.Limit.Amount, period, a.Used)
+	}
+
+	return fmt.Sprintf("%s has used %.0f%% of the %s %s limit of %.2f in %s: %.2f used",
+		owner, a.Used*100/a.Limit.Amount, a.Limit.Period, a.Limit.Unit, a.Limit.Amount, period, a.Used)
+}
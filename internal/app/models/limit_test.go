@@ -0,0 +1,152 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLimit_Bounds(t *testing.T) {
+	testTable := []struct {
+		name         string
+		period       string
+		date         string
+		expectedFrom string
+		expectedTo   string
+	}{
+		{
+			name:         "month",
+			period:       LimitMonth,
+			date:         "2023-02-15",
+			expectedFrom: "2023-02-01",
+			expectedTo:   "2023-03-01",
+		},
+		{
+			name:         "last month of the year",
+			period:       LimitMonth,
+			date:         "2023-12-31",
+			expectedFrom: "2023-12-01",
+			expectedTo:   "2024-01-01",
+		},
+		{
+			name:         "first month of a quarter",
+			period:       LimitQuarter,
+			date:         "2023-04-01",
+			expectedFrom: "2023-04-01",
+			expectedTo:   "2023-07-01",
+		},
+		{
+			name:         "last month of a quarter",
+			period:       LimitQuarter,
+			date:         "2023-09-30",
+			expectedFrom: "2023-07-01",
+			expectedTo:   "2023-10-01",
+		},
+		{
+			name:         "last quarter",
+			period:       LimitQuarter,
+			date:         "2023-11-10",
+			expectedFrom: "2023-10-01",
+			expectedTo:   "2024-01-01",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			date, _ := time.Parse("2006-01-02", tc.date)
+
+			from, to := Limit{Period: tc.period}.Bounds(MyTime(date))
+
+			assert.Equal(t, tc.expectedFrom, from.Format("2006-01-02"))
+			assert.Equal(t, tc.expectedTo, to.Format("2006-01-02"))
+		})
+	}
+}
+
+func TestLimit_State(t *testing.T) {
+	limit := Limit{Amount: 200, AlertPercent: 80}
+
+	testTable := []struct {
+		name          string
+		used          float64
+		expectedState string
+	}{
+		{
+			name:          "nothing used",
+			used:          0,
+			expectedState: "ok",
+		},
+		{
+			name:          "below the alert share",
+			used:          159.99,
+			expectedState: "ok",
+		},
+		{
+			name:          "alert share",
+			used:          160,
+			expectedState: "warning",
+		},
+		{
+			name:          "whole amount",
+			used:          200,
+			expectedState: "warning",
+		},
+		{
+			name:          "over the amount",
+			used:          200.01,
+			expectedState: "exceeded",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedState, limit.State(tc.used))
+		})
+	}
+}
+
+func TestLimitAlert_String(t *testing.T) {
+	id := 7
+	from := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name     string
+		alert    LimitAlert
+		expected string
+	}{
+		{
+			name: "vehicle warning",
+			alert: LimitAlert{
+				Limit: Limit{VehicleID: &id, Period: LimitMonth, Unit: LimitLiters, Amount: 200},
+				From:  from,
+				Used:  170,
+			},
+			expected: "vehicle 7 has used 85% of the month liters limit of 200.00 in 2023-04: 170.00 used",
+		},
+		{
+			name: "department exceeded",
+			alert: LimitAlert{
+				Limit:    Limit{DepartmentID: &id, Period: LimitQuarter, Unit: LimitMoney, Amount: 1000},
+				From:     from,
+				Used:     1000.5,
+				Exceeded: true,
+			},
+			expected: "department 7 has exceeded the quarter money limit of 1000.00 in 2023 Q2: 1000.50 used",
+		},
+		{
+			name: "department warning",
+			alert: LimitAlert{
+				Limit: Limit{DepartmentID: &id, Period: LimitMonth, Unit: LimitLiters, Amount: 100},
+				From:  from,
+				Used:  80,
+			},
+			expected: "department 7 has used 80% of the month liters limit of 100.00 in 2023-04: 80.00 used",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.alert.String())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization doesn't exist")
+	ErrOrganizationExists   = errors.New("organization with this name already exists")
+)
+
+// Organization is a tenant, its workers see only the documents, departments and workers of their own organization.
+type Organization struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateOrganizationInput struct {
+	Name string `json:"name"`
+}
+
+func (o *CreateOrganizationInput) Validate() error {
+	v := &ValidationError{}
+
+	if o.Name == "" {
+		v.Add("name", CodeRequired, "empty name")
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// PeriodLayout is the format of periods in requests and responses
+const PeriodLayout = "2006-01"
+
+var (
+	ErrPeriodClosed        = errors.New("period of the issue date is closed, its documents can't be changed")
+	ErrPeriodAlreadyClosed = errors.New("period is already closed")
+	ErrPeriodNotClosed     = errors.New("period isn't closed")
+)
+
+// PeriodClosing records who closed the month and why, the closing stays
+// in effect until the period is reopened.
+type PeriodClosing struct {
+	ID           int        `json:"id" db:"id"`
+	Period       string     `json:"period" db:"period"`
+	Reason       string     `json:"reason" db:"reason"`
+	ClosedBy     *int       `json:"closed_by" db:"closed_by"`
+	ClosedAt     time.Time  `json:"closed_at" db:"closed_at"`
+	ReopenReason *string    `json:"reopen_reason,omitempty" db:"reopen_reason"`
+	ReopenedBy   *int       `json:"reopened_by,omitempty" db:"reopened_by"`
+	ReopenedAt   *time.Time `json:"reopened_at,omitempty" db:"reopened_at"`
+}
+
+type PeriodInput struct {
+	Reason string `json:"reason"`
+}
+
+func (p *PeriodInput) Validate() error {
+	v := &ValidationError{}
+
+	if p.Reason == "" {
+		v.Add("reason", CodeRequired, "empty reason")
+	}
+
+	return v.Err()
+}
+
+// ParsePeriod returns the first day of the month written as 2006-01.
+func ParsePeriod(value string) (time.Time, error) {
+	period, err := time.Parse(PeriodLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid period, it has to be written as YYYY-MM")
+	}
+
+	return period, nil
+}
+
+// PeriodOf returns the first day of the month the date falls in.
+func PeriodOf(date MyTime) time.Time {
+	t := time.Time(date)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	GroupByVehicle = "vehicle"
+	GroupByDriver  = "driver"
+	GroupByGasType = "gas_type"
+	GroupByPeriod  = "period"
+)
+
+var reportPeriods = map[string]bool{
+	"month":   true,
+	"quarter": true,
+	"year":    true,
+}
+
+type FuelReportFilter struct {
+	From    MyTime
+	To      MyTime
+	GroupBy []string
+	Period  string
+}
+
+type FuelReportRow struct {
+	VehicleID  *int    `json:"vehicle_id,omitempty" db:"vehicle_id"`
+	CarID      *string `json:"car_id,omitempty" db:"car_id"`
+	DriverName *string `json:"driver_name,omitempty" db:"driver_name"`
+	GasType    *string `json:"gas_type,omitempty" db:"gas_type"`
+	Period     *MyTime `json:"period,omitempty" db:"period"`
+	Documents  int     `json:"documents" db:"documents"`
+	GasAmount  int     `json:"gas_amount" db:"gas_amount"`
+	Cost       float64 `json:"cost" db:"cost"`
+}
+
+type FuelReport struct {
+	From      MyTime          `json:"from"`
+	To        MyTime          `json:"to"`
+	GroupBy   []string        `json:"group_by"`
+	Period    string          `json:"period,omitempty"`
+	Rows      []FuelReportRow `json:"rows"`
+	Documents int             `json:"documents"`
+	GasAmount int             `json:"gas_amount"`
+	Cost      float64         `json:"cost"`
+}
+
+func (f *FuelReportFilter) Validate() error {
+	if f.To.Before(f.From) {
+		return errors.New("to can't be before from")
+	}
+
+	if len(f.GroupBy) == 0 {
+		f.GroupBy = []string{GroupByVehicle}
+	}
+
+	seen := make(map[string]bool, len(f.GroupBy))
+	for _, group := range f.GroupBy {
+		switch group {
+		case GroupByVehicle, GroupByDriver, GroupByGasType, GroupByPeriod:
+		default:
+			return errors.New("invalid group_by value")
+		}
+
+		if seen[group] {
+			return errors.New("group_by values can't repeat")
+		}
+		seen[group] = true
+	}
+
+	if f.Period == "" {
+		f.Period = "month"
+	}
+
+	if !reportPeriods[f.Period] {
+		return errors.New("invalid period value, allowed: " + strings.Join([]string{"month", "quarter", "year"}, ", "))
+	}
+
+	return nil
+}
+
+func (f *FuelReportFilter) Grouped(group string) bool {
+	for _, g := range f.GroupBy {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("revision doesn't exist")
+
+// Snapshot is a state of the document stored as jsonb.
+type Snapshot Document
+
+type Revision struct {
+	ID         int           `json:"id" db:"id"`
+	DocumentID int           `json:"document_id" db:"document_id"`
+	WorkerID   *int          `json:"worker_id" db:"worker_id"`
+	OldValues  Snapshot      `json:"old_values" db:"old_values"`
+	NewValues  Snapshot      `json:"new_values" db:"new_values"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	Changes    []FieldChange `json:"changes" db:"-"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// DiffDocuments returns fields which values differ between the documents
+// using their json names, the result is sorted by field name.
+func DiffDocuments(before, after Document) []FieldChange {
+	oldValues, newValues := documentValues(before), documentValues(after)
+
+	changes := []FieldChange{}
+	for field, newValue := range newValues {
+		if field == "ID" {
+			continue
+		}
+
+		if oldValue := oldValues[field]; !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func documentValues(document Document) map[string]interface{} {
+	var values map[string]interface{}
+
+	raw, _ := json.Marshal(document)
+	json.Unmarshal(raw, &values)
+
+	return values
+}
+
+// ToUpdateInput returns input which brings the editable fields of a document back to the snapshot.
+func (s Snapshot) ToUpdateInput() UpdateDocInput {
+	return UpdateDocInput{
+		VehicleID:     &s.VehicleID,
+		Waybill:       &s.Waybill,
+		DriverName:    &s.DriverName,
+		GasAmount:     &s.GasAmount,
+		GasType:       &s.GasType,
+		IssueDate:     &s.IssueDate,
+		OdometerStart: s.OdometerStart,
+		OdometerEnd:   s.OdometerEnd,
+		// tags and custom fields are always set, so the empty ones of the snapshot are restored too
+		Tags:         append([]string{}, s.Tags...),
+		CustomFields: s.customValues(),
+	}
+}
+
+func (s Snapshot) customValues() CustomValues {
+	if s.CustomFields == nil {
+		return CustomValues{}
+	}
+
+	return s.CustomFields
+}
+
+// Value implements the driver Valuer interface.
+// The computed fields aren't stored, they are derived from the odometer readings,
+// the fuel prices and the stock ledger.
+func (s Snapshot) Value() (driver.Value, error) {
+	s.Mileage = nil
+	s.Consumption = nil
+	s.Cost = nil
+	s.StockShortage = false
+	return json.Marshal(Document(s))
+}
+
+// Scan implements the Scanner interface.
+func (s *Snapshot) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*Document)(s))
+	case string:
+		return json.Unmarshal([]byte(v), (*Document)(s))
+	default:
+		return errors.New("unsupported snapshot value")
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+)
+
+var (
+	ErrTransitionNotAllowed = errors.New("document can't be moved to this status")
+	ErrNotEnoughRights      = errors.New("you don't have enough rights")
+	ErrDocumentReadOnly     = errors.New("document can't be changed in its current status")
+)
+
+type transitionRule struct {
+	from  []string
+	roles []string
+}
+
+// transitionRules lists for every status the statuses a document can be moved from
+// and the roles allowed to do it, workers can move only their own documents.
+var transitionRules = map[string]transitionRule{
+	StatusSubmitted: {
+		from:  []string{StatusDraft, StatusRejected},
+		roles: []string{RoleWorker, RoleDispatcher, RoleAdmin},
+	},
+	StatusApproved: {
+		from:  []string{StatusSubmitted},
+		roles: []string{RoleDispatcher, RoleAdmin},
+	},
+	StatusRejected: {
+		from:  []string{StatusSubmitted},
+		roles: []string{RoleDispatcher, RoleAdmin},
+	},
+	StatusDraft: {
+		from:  []string{StatusApproved},
+		roles: []string{RoleAdmin},
+	},
+}
+
+type TransitionInput struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type Transition struct {
+	ID         int       `json:"id" db:"id"`
+	DocumentID int       `json:"document_id" db:"document_id"`
+	WorkerID   *int      `json:"worker_id" db:"worker_id"`
+	FromStatus string    `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	Reason     string    `json:"reason" db:"reason"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+func (t *TransitionInput) Validate() error {
+	if !IsStatus(t.Status) {
+		return errors.New("invalid status")
+	}
+
+	if t.Status == StatusRejected && t.Reason == "" {
+		return errors.New("reason is required to reject the document")
+	}
+
+	return nil
+}
+
+func IsStatus(status string) bool {
+	_, ok := transitionRules[status]
+	return ok
+}
+
+// CheckTransition reports whether the role may move a document from one status to another.
+func CheckTransition(from, to, role string) error {
+	rule, ok := transitionRules[to]
+	if !ok || !contains(rule.from, from) {
+		return ErrTransitionNotAllowed
+	}
+
+	if !contains(rule.roles, role) {
+		return ErrNotEnoughRights
+	}
+
+	return nil
+}
+
+// EditableByAuthor reports whether workers may still change their document in the status.
+func EditableByAuthor(status string) bool {
+	return status == StatusDraft || status == StatusRejected
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	MovementReceipt = "receipt"
+	MovementIssue   = "issue"
+)
+
+var ErrStockShortage = errors.New("there isn't enough fuel in stock for the document")
+
+// StockMovement is a ledger entry, receipts have positive amount,
+// issues made for approved documents have negative one.
+type StockMovement struct {
+	ID         int       `json:"id" db:"id"`
+	FuelCode   string    `json:"fuel_code" db:"fuel_code"`
+	Kind       string    `json:"kind" db:"kind"`
+	Amount     int       `json:"amount" db:"amount"`
+	MovedAt    MyTime    `json:"moved_at" db:"moved_at"`
+	DocumentID *int      `json:"document_id" db:"document_id"`
+	WorkerID   *int      `json:"worker_id" db:"worker_id"`
+	Note       string    `json:"note" db:"note"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	// OrganizationID is the depot the fuel is stored at
+	OrganizationID int `json:"-" db:"organization_id"`
+}
+
+type StockBalance struct {
+	FuelCode string `json:"fuel_code" db:"fuel_code"`
+	Received int    `json:"received" db:"received"`
+	Issued   int    `json:"issued" db:"issued"`
+	Balance  int    `json:"balance" db:"balance"`
+}
+
+type StockFilter struct {
+	FuelCode string
+	From     *MyTime
+	To       *MyTime
+}
+
+type ReceiptInput struct {
+	FuelCode   string `json:"fuel_code"`
+	Amount     int    `json:"amount"`
+	ReceivedAt MyTime `json:"received_at"`
+	Note       string `json:"note"`
+}
+
+func (r *ReceiptInput) Validate() error {
+	if r.FuelCode == "" {
+		return errors.New("empty fuel_code")
+	}
+
+	if r.Amount <= 0 {
+		return errors.New("amount can't be less than zero")
+	}
+
+	if time.Time(r.ReceivedAt).IsZero() {
+		return errors.New("empty received_at")
+	}
+
+	return nil
+}
+
+func (f *StockFilter) Validate() error {
+	if f.From != nil && f.To != nil && f.To.Before(*f.From) {
+		return errors.New("to can't be before from")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const MaxTagLength = 50
+
+var (
+	ErrTagNotFound   = errors.New("tag doesn't exist")
+	ErrTagExists     = errors.New("tag with this name already exists")
+	ErrTagReferenced = errors.New("tag is set on documents")
+)
+
+// Tag is defined by admins of the organization, documents refer to tags by name.
+type Tag struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
+type CreateTagInput struct {
+	Name string `json:"name"`
+}
+
+func (t *CreateTagInput) Validate() error {
+	v := &ValidationError{}
+
+	t.Name = strings.TrimSpace(t.Name)
+	validateTag(v, "name", t.Name)
+
+	return v.Err()
+}
+
+// StringList is a list of strings stored as jsonb array.
+type StringList []string
+
+// Value implements the driver Valuer interface, nil list is stored as empty array.
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]string(l))
+}
+
+// Scan implements the Scanner interface.
+func (l *StringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(l))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(l))
+	default:
+		return errors.New("unsupported string list value")
+	}
+}
+
+// validateTags checks tag names of the document, every tag can be set once.
+func validateTags(v *ValidationError, tags []string) {
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		validateTag(v, "tags", tag)
+
+		if seen[tag] {
+			v.Add("tags", CodeInvalid, "tag "+tag+" is set twice")
+		}
+		seen[tag] = true
+	}
+}
+
+func validateTag(v *ValidationError, field, name string) {
+	switch {
+	case name == "":
+		v.Add(field, CodeRequired, "empty tag name")
+	case len([]rune(name)) > MaxTagLength:
+		v.Add(field, CodeInvalid, "tag name is too long")
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	TimelineRevision = "revision"
+	TimelineComment  = "comment"
+)
+
+// TimelineEntry is a revision or a comment of the document, exactly one of them is set.
+type TimelineEntry struct {
+	Kind     string    `json:"kind"`
+	At       time.Time `json:"at"`
+	Revision *Revision `json:"revision,omitempty"`
+	Comment  *Comment  `json:"comment,omitempty"`
+}
+
+// Timeline merges revisions and comments of the document in chronological order,
+// a revision goes first if it was made at the same time as a comment.
+func Timeline(revisions []Revision, comments []Comment) []TimelineEntry {
+	entries := make([]TimelineEntry, 0, len(revisions)+len(comments))
+
+	for i := range revisions {
+		entries = append(entries, TimelineEntry{
+			Kind:     TimelineRevision,
+			At:       revisions[i].CreatedAt,
+			Revision: &revisions[i],
+		})
+	}
+
+	for i := range comments {
+		entries = append(entries, TimelineEntry{
+			Kind:    TimelineComment,
+			At:      comments[i].CreatedAt,
+			Comment: &comments[i],
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+
+	return entries
+}
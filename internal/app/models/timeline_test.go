@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTimeline(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2023, 3, 14, 10, minute, 0, 0, time.UTC)
+	}
+
+	revisions := []Revision{{ID: 1, CreatedAt: at(0)}, {ID: 2, CreatedAt: at(20)}}
+	comments := []Comment{{ID: 7, CreatedAt: at(10)}, {ID: 8, CreatedAt: at(20)}, {ID: 9, CreatedAt: at(30)}}
+
+	entries := Timeline(revisions, comments)
+
+	kinds := make([]string, 0, len(entries))
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		kinds = append(kinds, entry.Kind)
+		switch entry.Kind {
+		case TimelineRevision:
+			assert.Nil(t, entry.Comment)
+			assert.Equal(t, entry.Revision.CreatedAt, entry.At)
+			ids = append(ids, entry.Revision.ID)
+		case TimelineComment:
+			assert.Nil(t, entry.Revision)
+			assert.Equal(t, entry.Comment.CreatedAt, entry.At)
+			ids = append(ids, entry.Comment.ID)
+		}
+	}
+
+	// the revision made at the same time as the comment goes first
+	assert.Equal(t, []string{
+		TimelineRevision, TimelineComment, TimelineRevision, TimelineComment, TimelineComment,
+	}, kinds)
+	assert.Equal(t, []int{1, 7, 2, 8, 9}, ids)
+
+	assert.Equal(t, []TimelineEntry{}, Timeline(nil, nil))
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+)
+
+// validation error codes, the client maps them to its own messages
+const (
+	CodeRequired   = "required"
+	CodeInvalid    = "invalid"
+	CodeOutOfRange = "out_of_range"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError collects every invalid field of an input instead of stopping at the first one.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (v *ValidationError) Add(field, code, message string) {
+	v.Fields = append(v.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (v *ValidationError) Error() string {
+	messages := make([]string, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		messages = append(messages, field.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Err returns nil if no fields were added, so it can be returned from Validate as is.
+func (v *ValidationError) Err() error {
+	if len(v.Fields) == 0 {
+		return nil
+	}
+
+	return v
+}
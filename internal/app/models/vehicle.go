@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrVehicleNotFound   = errors.New("vehicle doesn't exist")
+	ErrVehicleExists     = errors.New("vehicle with this plate number already exists")
+	ErrVehicleInactive   = errors.New("vehicle is inactive")
+	ErrVehicleReferenced = errors.New("vehicle is referenced by documents, deactivate it instead")
+)
+
+type Vehicle struct {
+	ID           int    `json:"id" db:"id"`
+	PlateNumber  string `json:"plate_number" db:"plate_number"`
+	Model        string `json:"model" db:"model"`
+	FuelType     string `json:"fuel_type" db:"fuel_type"`
+	TankCapacity *int   `json:"tank_capacity" db:"tank_capacity"`
+	Active       bool   `json:"active" db:"active"`
+	// ConsumptionNorm is the allowed fuel consumption in liters per 100 km
+	ConsumptionNorm *float64 `json:"consumption_norm" db:"consumption_norm"`
+	DepartmentID    *int     `json:"department_id" db:"department_id"`
+}
+
+type CreateVehicleInput struct {
+	PlateNumber  string `json:"plate_number"`
+	Model        string `json:"model"`
+	FuelType     string `json:"fuel_type"`
+	TankCapacity int    `json:"tank_capacity"`
+	// ConsumptionNorm is optional, documents of vehicles without the norm aren't checked
+	ConsumptionNorm *float64 `json:"consumption_norm"`
+	DepartmentID    *int     `json:"department_id"`
+}
+
+type UpdateVehicleInput struct {
+	PlateNumber     *string  `json:"plate_number"`
+	Model           *string  `json:"model"`
+	FuelType        *string  `json:"fuel_type"`
+	TankCapacity    *int     `json:"tank_capacity"`
+	Active          *bool    `json:"active"`
+	ConsumptionNorm *float64 `json:"consumption_norm"`
+	DepartmentID    *int     `json:"department_id"`
+}
+
+func (v *CreateVehicleInput) Validate() error {
+	e := &ValidationError{}
+
+	if v.Model == "" || v.FuelType == "" {
+		e.Add("model", CodeRequired, "there can't be empty fields")
+	}
+
+	if plate, ok := NormalizePlate(v.PlateNumber); ok {
+		v.PlateNumber = plate
+	} else {
+		e.Add("plate_number", CodeInvalid, "invalid plate_number")
+	}
+
+	if v.TankCapacity <= 0 {
+		e.Add("tank_capacity", CodeOutOfRange, "tank_capacity can't be less than zero")
+	}
+
+	if v.ConsumptionNorm != nil && *v.ConsumptionNorm <= 0 {
+		e.Add("consumption_norm", CodeOutOfRange, "consumption_norm has to be greater than zero")
+	}
+
+	return e.Err()
+}
+
+// Validate rejects the values which can't be set, so ToVehicle copies every field given.
+func (v *UpdateVehicleInput) Validate() error {
+	e := &ValidationError{}
+
+	if v.PlateNumber != nil {
+		if plate, ok := NormalizePlate(*v.PlateNumber); ok {
+			v.PlateNumber = &plate
+		} else {
+			e.Add("plate_number", CodeInvalid, "invalid plate_number")
+		}
+	}
+
+	if v.Model != nil && *v.Model == "" {
+		e.Add("model", CodeRequired, "empty model")
+	}
+
+	if v.FuelType != nil && *v.FuelType == "" {
+		e.Add("fuel_type", CodeRequired, "empty fuel_type")
+	}
+
+	if v.TankCapacity != nil && *v.TankCapacity <= 0 {
+		e.Add("tank_capacity", CodeOutOfRange, "tank_capacity can't be less than zero")
+	}
+
+	if v.ConsumptionNorm != nil && *v.ConsumptionNorm <= 0 {
+		e.Add("consumption_norm", CodeOutOfRange, "consumption_norm has to be greater than zero")
+	}
+
+	return e.Err()
+}
+
+func (v *UpdateVehicleInput) ToVehicle(vehicle *Vehicle) {
+	if v.PlateNumber != nil {
+		vehicle.PlateNumber = *v.PlateNumber
+	}
+
+	if v.Model != nil {
+		vehicle.Model = *v.Model
+	}
+
+	if v.FuelType != nil {
+		vehicle.FuelType = *v.FuelType
+	}
+
+	if v.TankCapacity != nil {
+		vehicle.TankCapacity = v.TankCapacity
+	}
+
+	if v.Active != nil {
+		vehicle.Active = *v.Active
+	}
+
+	if v.ConsumptionNorm != nil {
+		vehicle.ConsumptionNorm = v.ConsumptionNorm
+	}
+
+	if v.DepartmentID != nil {
+		vehicle.DepartmentID = v.DepartmentID
+	}
+}
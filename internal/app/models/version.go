@@ -0,0 +1,8 @@
+package models
+
+import "errors"
+
+var (
+	ErrVersionRequired = errors.New("If-Match header is required")
+	ErrVersionMismatch = errors.New("the record has been changed by someone else")
+)
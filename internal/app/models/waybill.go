@@ -0,0 +1,41 @@
+package models
+
+import (
+	"errors"
+)
+
+const (
+	MinWaybill = 1000
+	MaxWaybill = 9999
+)
+
+var ErrWaybillsExhausted = errors.New("there are no free waybill numbers left in this year")
+
+// WaybillSequence keeps the last allocated waybill number of the year,
+// the next document without a number gets the first free one after it.
+type WaybillSequence struct {
+	Year           int `json:"year" db:"year"`
+	LastValue      int `json:"last_value" db:"last_value"`
+	OrganizationID int `json:"-" db:"organization_id"`
+}
+
+type ResetSequenceInput struct {
+	// LastValue is the number to continue from, MinWaybill-1 starts the year over
+	LastValue *int `json:"last_value"`
+}
+
+func (s *ResetSequenceInput) Validate() error {
+	if s.LastValue == nil {
+		return errors.New("empty last_value")
+	}
+
+	if *s.LastValue < MinWaybill-1 || *s.LastValue > MaxWaybill {
+		return errors.New("invalid last_value")
+	}
+
+	return nil
+}
+
+func validWaybill(waybill int) bool {
+	return waybill >= MinWaybill && waybill <= MaxWaybill
+}
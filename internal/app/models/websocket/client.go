@@ -17,6 +17,8 @@ type Message struct {
 	Content  string `json:"content"`
 	RoomID   int    `json:"room_id"`
 	Username string `json:"username"`
+	// ClientID addresses the message to the single client of the room, zero means everyone
+	ClientID int `json:"client_id,omitempty"`
 }
 
 type ClientResponse struct {
@@ -1,9 +1,13 @@
 package ws
 
+import "sync"
+
 type Room struct {
-	ID      int             `json:"id"`
-	Name    string          `json:"name"`
-	Clients map[int]*Client `json:"clients"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// OrganizationID is the organization whose workers can see and join the room
+	OrganizationID int             `json:"organization_id"`
+	Clients        map[int]*Client `json:"clients"`
 }
 
 type Hub struct {
@@ -11,6 +15,9 @@ type Hub struct {
 	Register   chan *Client
 	Unregister chan *Client
 	Broadcast  chan *Message
+
+	// mu guards Rooms and their clients, which are read by the handlers while the hub runs
+	mu sync.Mutex
 }
 
 func NewHub() *Hub {
@@ -32,39 +39,172 @@ type RoomResponse struct {
 	Name string `json:"name"`
 }
 
+// AlertRoomID returns the room the organization gets its alerts in, such rooms have negative ids
+// and are opened once the first client joins them, other rooms are created by the clients.
+func AlertRoomID(orgID int) int {
+	return -orgID
+}
+
+// AddRoom adds the room unless there is a room with the same id already.
+func (h *Hub) AddRoom(room *Room) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Rooms[room.ID]; ok {
+		return false
+	}
+	h.Rooms[room.ID] = room
+
+	return true
+}
+
+// CanJoin tells if workers of the organization can join the room,
+// the role of the worker is checked by the caller for the alert room.
+func (h *Hub) CanJoin(orgID, roomID int) bool {
+	if roomID == AlertRoomID(orgID) {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.Rooms[roomID]
+	return ok && room.OrganizationID == orgID
+}
+
+// OrganizationRooms returns the rooms of the organization.
+func (h *Hub) OrganizationRooms(orgID int) []RoomResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rooms := []RoomResponse{}
+	for _, room := range h.Rooms {
+		if room.OrganizationID == orgID {
+			rooms = append(rooms, RoomResponse{ID: room.ID, Name: room.Name})
+		}
+	}
+
+	return rooms
+}
+
+// Clients returns the clients of the room of the organization, it returns false if there is no such room.
+func (h *Hub) Clients(orgID, roomID int) ([]ClientResponse, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.Rooms[roomID]
+	if !ok || room.OrganizationID != orgID {
+		return nil, false
+	}
+
+	clients := []ClientResponse{}
+	for _, cl := range room.Clients {
+		clients = append(clients, ClientResponse{ID: cl.ID, Username: cl.Username})
+	}
+
+	return clients, true
+}
+
+// Run serves the channels of the hub. The rooms are changed under the lock, while messages are sent
+// after it's released and never wait for a client, so handlers reading the rooms are never held up.
 func (h *Hub) Run() {
 	for {
 		select {
 		case cl := <-h.Register:
-			if _, ok := h.Rooms[cl.RoomID]; ok {
-				r := h.Rooms[cl.RoomID]
-
-				if _, ok := r.Clients[cl.ID]; !ok {
-					r.Clients[cl.ID] = cl
-				}
-			}
+			h.register(cl)
 
 		case cl := <-h.Unregister:
-			if _, ok := h.Rooms[cl.RoomID]; ok {
-				if _, ok := h.Rooms[cl.RoomID].Clients[cl.ID]; ok {
-					if len(h.Rooms[cl.RoomID].Clients) != 0 {
-						h.Broadcast <- &Message{
-							Content:  "user left the chat",
-							RoomID:   cl.RoomID,
-							Username: cl.Username,
-						}
-					}
-					delete(h.Rooms[cl.RoomID].Clients, cl.ID)
-					close(cl.Message)
-				}
+			// the rest of the room is told right away, the hub can't wait for its own Broadcast channel
+			if recipients := h.unregister(cl); len(recipients) != 0 {
+				h.deliver(&Message{
+					Content:  "user left the chat",
+					RoomID:   cl.RoomID,
+					Username: cl.Username,
+				}, recipients)
 			}
 
 		case m := <-h.Broadcast:
-			if _, ok := h.Rooms[m.RoomID]; ok {
-				for _, cl := range h.Rooms[m.RoomID].Clients {
-					cl.Message <- m
-				}
-			}
+			h.deliver(m, h.recipients(m))
 		}
 	}
 }
+
+func (h *Hub) register(cl *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Rooms[cl.RoomID]; !ok && cl.RoomID < 0 {
+		h.Rooms[cl.RoomID] = &Room{
+			ID:             cl.RoomID,
+			Name:           "alerts",
+			OrganizationID: -cl.RoomID,
+			Clients:        make(map[int]*Client),
+		}
+	}
+
+	// clients are identified by the worker, the newer connection of the worker replaces the older one
+	if r, ok := h.Rooms[cl.RoomID]; ok {
+		if old, ok := r.Clients[cl.ID]; ok {
+			close(old.Message)
+		}
+		r.Clients[cl.ID] = cl
+	}
+}
+
+// unregister removes the client unless it has been replaced already, it returns the clients left in the room.
+func (h *Hub) unregister(cl *Client) []*Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.Rooms[cl.RoomID]
+	if !ok || r.Clients[cl.ID] != cl {
+		return nil
+	}
+
+	delete(r.Clients, cl.ID)
+	close(cl.Message)
+
+	recipients := make([]*Client, 0, len(r.Clients))
+	for _, other := range r.Clients {
+		recipients = append(recipients, other)
+	}
+
+	return recipients
+}
+
+// recipients returns the clients of the room the message is addressed to.
+func (h *Hub) recipients(m *Message) []*Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.Rooms[m.RoomID]
+	if !ok {
+		return nil
+	}
+
+	recipients := make([]*Client, 0, len(r.Clients))
+	for _, cl := range r.Clients {
+		if m.ClientID == 0 || m.ClientID == cl.ID {
+			recipients = append(recipients, cl)
+		}
+	}
+
+	return recipients
+}
+
+// deliver sends the message without waiting, clients which don't keep up with their messages are dropped.
+// Channels of the clients are closed only by Run, so they can't be closed while the message is sent.
+func (h *Hub) deliver(m *Message, recipients []*Client) {
+	var stuck []*Client
+	for _, cl := range recipients {
+		select {
+		case cl.Message <- m:
+		default:
+			stuck = append(stuck, cl)
+		}
+	}
+
+	for _, cl := range stuck {
+		h.unregister(cl)
+	}
+}
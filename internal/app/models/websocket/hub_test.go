@@ -0,0 +1,127 @@
+package ws
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func receive(client *Client) *Message {
+	select {
+	case m := <-client.Message:
+		return m
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+func TestHub_alertRooms(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	first := &Client{ID: 1, RoomID: AlertRoomID(1), Message: make(chan *Message, 10)}
+	second := &Client{ID: 2, RoomID: AlertRoomID(2), Message: make(chan *Message, 10)}
+	hub.Register <- first
+	hub.Register <- second
+
+	NewNotifier(hub, AlertRoomID(1), "system").Notify("first alert")
+	NewNotifier(hub, AlertRoomID(2), "system").NotifyWorker(2, "second mention")
+	NewNotifier(hub, AlertRoomID(2), "system").NotifyWorker(1, "mention of another organization")
+	NewNotifier(hub, AlertRoomID(1), "system").Notify("last alert")
+
+	assert.Equal(t, &Message{Content: "first alert", RoomID: -1, Username: "system"}, receive(first))
+	assert.Equal(t, &Message{Content: "last alert", RoomID: -1, Username: "system"}, receive(first))
+	assert.Equal(t, &Message{Content: "second mention", RoomID: -2, Username: "system", ClientID: 2}, receive(second))
+	assert.Empty(t, second.Message)
+}
+
+func TestHub_organizationRooms(t *testing.T) {
+	hub := NewHub()
+
+	assert.True(t, hub.AddRoom(&Room{ID: 1, Name: "drivers", OrganizationID: 1, Clients: make(map[int]*Client)}))
+	assert.True(t, hub.AddRoom(&Room{ID: 2, Name: "garage", OrganizationID: 2, Clients: make(map[int]*Client)}))
+	assert.False(t, hub.AddRoom(&Room{ID: 2, Name: "taken", OrganizationID: 1, Clients: make(map[int]*Client)}))
+
+	assert.True(t, hub.CanJoin(1, 1))
+	assert.True(t, hub.CanJoin(1, AlertRoomID(1)))
+	assert.False(t, hub.CanJoin(1, 2))
+	assert.False(t, hub.CanJoin(1, AlertRoomID(2)))
+	assert.False(t, hub.CanJoin(1, 3))
+
+	assert.Equal(t, []RoomResponse{{ID: 2, Name: "garage"}}, hub.OrganizationRooms(2))
+
+	_, ok := hub.Clients(1, 2)
+	assert.False(t, ok)
+
+	clients, ok := hub.Clients(2, 2)
+	assert.True(t, ok)
+	assert.Equal(t, []ClientResponse{}, clients)
+}
+
+func TestHub_replacedConnection(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	older := &Client{ID: 1, RoomID: AlertRoomID(1), Username: "Ivan", Message: make(chan *Message, 10)}
+	newer := &Client{ID: 1, RoomID: AlertRoomID(1), Username: "Ivan", Message: make(chan *Message, 10)}
+	hub.Register <- older
+	hub.Register <- newer
+	// the older connection goes away after it's replaced and must not take the newer one with it
+	hub.Unregister <- older
+
+	NewNotifier(hub, AlertRoomID(1), "system").NotifyWorker(1, "alert")
+
+	_, open := <-older.Message
+	assert.False(t, open)
+	assert.Equal(t, &Message{Content: "alert", RoomID: -1, Username: "system", ClientID: 1}, receive(newer))
+
+	clients, _ := hub.Clients(1, AlertRoomID(1))
+	assert.Equal(t, []ClientResponse{{ID: 1, Username: "Ivan"}}, clients)
+}
+
+func TestHub_slowClient(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	// the slow client never reads its messages
+	slow := &Client{ID: 1, RoomID: AlertRoomID(1), Username: "Ivan", Message: make(chan *Message, 1)}
+	fast := &Client{ID: 2, RoomID: AlertRoomID(1), Username: "Petr", Message: make(chan *Message, 10)}
+	hub.Register <- slow
+	hub.Register <- fast
+
+	for _, content := range []string{"first", "second", "third"} {
+		hub.Broadcast <- &Message{Content: content, RoomID: AlertRoomID(1), Username: "system"}
+	}
+
+	for _, content := range []string{"first", "second", "third"} {
+		assert.Equal(t, &Message{Content: content, RoomID: -1, Username: "system"}, receive(fast))
+	}
+
+	// the slow client is dropped with the messages it got before
+	assert.Equal(t, &Message{Content: "first", RoomID: -1, Username: "system"}, <-slow.Message)
+	_, open := <-slow.Message
+	assert.False(t, open)
+
+	clients, _ := hub.Clients(1, AlertRoomID(1))
+	assert.Equal(t, []ClientResponse{{ID: 2, Username: "Petr"}}, clients)
+}
+
+func TestHub_leftTheChat(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	leaving := &Client{ID: 1, RoomID: AlertRoomID(1), Username: "Ivan", Message: make(chan *Message, 10)}
+	staying := &Client{ID: 2, RoomID: AlertRoomID(1), Username: "Petr", Message: make(chan *Message, 10)}
+	hub.Register <- leaving
+	hub.Register <- staying
+
+	// the broadcast buffer is full, leaving mustn't wait for it
+	for i := 0; i < cap(hub.Broadcast); i++ {
+		hub.Broadcast <- &Message{Content: "filler", RoomID: AlertRoomID(5)}
+	}
+	hub.Unregister <- leaving
+
+	assert.Equal(t, &Message{Content: "user left the chat", RoomID: -1, Username: "Ivan"}, receive(staying))
+	_, open := <-leaving.Message
+	assert.False(t, open)
+}
@@ -0,0 +1,42 @@
+package ws
+
+// Notifier posts messages to a room of the hub on behalf of the system.
+type Notifier struct {
+	hub      *Hub
+	roomID   int
+	username string
+}
+
+func NewNotifier(hub *Hub, roomID int, username string) *Notifier {
+	return &Notifier{
+		hub:      hub,
+		roomID:   roomID,
+		username: username,
+	}
+}
+
+// Notify never blocks the caller, the message is dropped if the hub can't take it right away.
+func (n *Notifier) Notify(content string) {
+	n.send(&Message{
+		Content:  content,
+		RoomID:   n.roomID,
+		Username: n.username,
+	})
+}
+
+// NotifyWorker delivers the message only to the worker if the worker has joined the room.
+func (n *Notifier) NotifyWorker(workerID int, content string) {
+	n.send(&Message{
+		Content:  content,
+		RoomID:   n.roomID,
+		Username: n.username,
+		ClientID: workerID,
+	})
+}
+
+func (n *Notifier) send(m *Message) {
+	select {
+	case n.hub.Broadcast <- m:
+	default:
+	}
+}
@@ -2,10 +2,19 @@ package models
 
 import (
 	"errors"
-	"regexp"
 )
 
-var checkPhone = regexp.MustCompile(`^[\+]?[0-9]{3}\s[0-9]{2,3}\s[0-9]{3}-[0-9]{2}-[0-9]{2}$`)
+const (
+	RoleAdmin      = "admin"
+	RoleDispatcher = "dispatcher"
+	RoleWorker     = "worker"
+	// RoleAuditor reads every document of the organization but can't change any of them
+	RoleAuditor = "auditor"
+	// RoleSuperAdmin manages organizations, super-admins don't belong to any of them
+	RoleSuperAdmin = "superadmin"
+)
+
+var ErrWorkerNotFound = errors.New("worker doesn't exist")
 
 type Worker struct {
 	ID           int    `json:"id" db:"id"`
@@ -15,6 +24,12 @@ type Worker struct {
 	Phone        string `json:"phone" db:"phone"`
 	Role         string `json:"role" db:"role"`
 	PasswordHash string `json:"password_hash" db:"password_hash"`
+	// Version is sent as the ETag header
+	Version        int  `json:"-" db:"version"`
+	OrganizationID *int `json:"organization_id,omitempty" db:"organization_id"`
+	DepartmentID   *int `json:"department_id,omitempty" db:"department_id"`
+	// DepartmentAccess grants the worker reading documents of the whole department
+	DepartmentAccess bool `json:"department_access,omitempty" db:"department_access"`
 }
 type CreateWorkerInput struct {
 	Name        string `json:"name"`
@@ -23,6 +38,9 @@ type CreateWorkerInput struct {
 	Phone       string `json:"phone"`
 	Role        string `json:"role"`
 	Password    string `json:"password"`
+	// DepartmentID has to belong to the organization the worker is created in
+	DepartmentID     *int `json:"department_id"`
+	DepartmentAccess bool `json:"department_access"`
 }
 
 type LogWorkerInput struct {
@@ -33,36 +51,40 @@ type LogWorkerInput struct {
 }
 
 type UpdateWorkerInput struct {
-	Name        *string `json:"name"`
-	Surname     *string `json:"surname"`
-	FathersName *string `json:"fathers_name"`
-	Phone       *string `json:"phone"`
+	Name             *string `json:"name"`
+	Surname          *string `json:"surname"`
+	FathersName      *string `json:"fathers_name"`
+	Phone            *string `json:"phone"`
+	DepartmentID     *int    `json:"department_id"`
+	DepartmentAccess *bool   `json:"department_access"`
 }
 
+// WorkerAttributes are carried in the token, OrganizationID is the tenant
+// every request of the worker is scoped to.
 type WorkerAttributes struct {
-	ID   int    `json:"id"`
-	Role string `json:"role"`
-	Name string `json:"name"`
+	ID             int    `json:"id"`
+	Role           string `json:"role"`
+	Name           string `json:"name"`
+	OrganizationID int    `json:"organization_id,omitempty"`
 }
 
 func (w *CreateWorkerInput) Validate() error {
-	if w.Name == "" || w.Surname == "" || w.FathersName == "" {
-		return errors.New("name fields can't be empty")
-	}
+	v := &ValidationError{}
 
-	if w.Phone == "" || !checkPhone.MatchString(w.Phone) {
-		return errors.New("empty or invalid phone number")
-	}
+	validateName(v, "name", w.Name)
+	validateName(v, "surname", w.Surname)
+	validateName(v, "fathers_name", w.FathersName)
+	validatePhone(v, &w.Phone)
 
-	if w.Role != "admin" && w.Role != "worker" {
-		return errors.New("invalid role")
+	if w.Role != RoleAdmin && w.Role != RoleDispatcher && w.Role != RoleWorker && w.Role != RoleAuditor {
+		v.Add("role", CodeInvalid, "invalid role")
 	}
 
 	if w.Password == "" {
-		return errors.New("password field can't be empty")
+		v.Add("password", CodeRequired, "password field can't be empty")
 	}
 
-	return nil
+	return v.Err()
 }
 
 func (w *LogWorkerInput) Validate() error {
@@ -70,9 +92,11 @@ func (w *LogWorkerInput) Validate() error {
 		return errors.New("name fields can't be empty")
 	}
 
-	if w.Phone == "" || !checkPhone.MatchString(w.Phone) {
+	phone, ok := NormalizePhone(w.Phone)
+	if !ok {
 		return errors.New("empty or invalid phone number")
 	}
+	w.Phone = phone
 
 	if w.Password == "" {
 		return errors.New("password field can't be empty")
@@ -81,6 +105,28 @@ func (w *LogWorkerInput) Validate() error {
 	return nil
 }
 
+func (w *UpdateWorkerInput) Validate() error {
+	v := &ValidationError{}
+
+	if w.Name != nil {
+		validateName(v, "name", *w.Name)
+	}
+
+	if w.Surname != nil {
+		validateName(v, "surname", *w.Surname)
+	}
+
+	if w.FathersName != nil {
+		validateName(v, "fathers_name", *w.FathersName)
+	}
+
+	if w.Phone != nil {
+		validatePhone(v, w.Phone)
+	}
+
+	return v.Err()
+}
+
 func (w *UpdateWorkerInput) ToWorker(worker *Worker) {
 	if w.Name != nil && worker.Name != *w.Name {
 		worker.Name = *w.Name
@@ -94,7 +140,36 @@ func (w *UpdateWorkerInput) ToWorker(worker *Worker) {
 		worker.FathersName = *w.FathersName
 	}
 
-	if w.Phone != nil && worker.Phone != *w.Phone && checkPhone.MatchString(*w.Phone) {
+	if w.Phone != nil && worker.Phone != *w.Phone {
 		worker.Phone = *w.Phone
 	}
+
+	if w.DepartmentID != nil && *w.DepartmentID > 0 {
+		worker.DepartmentID = w.DepartmentID
+	}
+
+	if w.DepartmentAccess != nil {
+		worker.DepartmentAccess = *w.DepartmentAccess
+	}
+}
+
+func validateName(v *ValidationError, field, value string) {
+	if value == "" {
+		v.Add(field, CodeRequired, field+" can't be empty")
+	}
+}
+
+// validatePhone brings the phone to the canonical form of its format.
+func validatePhone(v *ValidationError, phone *string) {
+	if *phone == "" {
+		v.Add("phone", CodeRequired, "empty phone number")
+		return
+	}
+
+	normalized, ok := NormalizePhone(*phone)
+	if !ok {
+		v.Add("phone", CodeInvalid, "invalid phone number")
+		return
+	}
+	*phone = normalized
 }
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type AttachmentRepository struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentRepository(db *sqlx.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) Create(attachment models.Attachment) (models.Attachment, error) {
+	query := fmt.Sprintf(`insert into %s
+								(document_id, file_name, content_type, size, checksum, storage_key, uploaded_by)
+								values ($1, $2, $3, $4, $5, $6, $7)
+								returning *`, attachmentsTable)
+
+	var created models.Attachment
+	if err := r.db.Get(&created, query,
+		attachment.DocumentID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		attachment.UploadedBy); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return models.Attachment{}, models.ErrDocumentNotFound
+		}
+		return models.Attachment{}, err
+	}
+
+	return created, nil
+}
+
+func (r *AttachmentRepository) GetAll(docID int) ([]models.Attachment, error) {
+	attachments := []models.Attachment{}
+	query := fmt.Sprintf("select * from %s where document_id=$1 order by created_at, id", attachmentsTable)
+
+	if err := r.db.Select(&attachments, query, docID); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetByID(attachmentID int) (models.Attachment, error) {
+	var attachment models.Attachment
+	query := fmt.Sprintf("select * from %s where id=$1", attachmentsTable)
+
+	if err := r.db.Get(&attachment, query, attachmentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Attachment{}, models.ErrAttachmentNotFound
+		}
+		return models.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) Delete(attachmentID int) error {
+	query := fmt.Sprintf("delete from %s where id=$1", attachmentsTable)
+
+	result, err := r.db.Exec(query, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return models.ErrAttachmentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type CommentRepository struct {
+	db *sqlx.DB
+}
+
+func NewCommentRepository(db *sqlx.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Create(comment models.Comment) (models.Comment, error) {
+	query := fmt.Sprintf(`insert into %s (document_id, worker_id, author, body)
+								values ($1, $2, $3, $4)
+								returning *`, commentsTable)
+
+	var created models.Comment
+	if err := r.db.Get(&created, query,
+		comment.DocumentID,
+		comment.WorkerID,
+		comment.Author,
+		comment.Body); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return models.Comment{}, models.ErrDocumentNotFound
+		}
+		return models.Comment{}, err
+	}
+
+	return created, nil
+}
+
+func (r *CommentRepository) GetAll(docID int) ([]models.Comment, error) {
+	comments := []models.Comment{}
+	query := fmt.Sprintf("select * from %s where document_id=$1 order by created_at, id", commentsTable)
+
+	if err := r.db.Select(&comments, query, docID); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *CommentRepository) GetByID(commentID int) (models.Comment, error) {
+	var comment models.Comment
+	query := fmt.Sprintf("select * from %s where id=$1", commentsTable)
+
+	if err := r.db.Get(&comment, query, commentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Comment{}, models.ErrCommentNotFound
+		}
+		return models.Comment{}, err
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) Update(commentID int, body string) error {
+	query := fmt.Sprintf("update %s set body=$1, updated_at=now() where id=$2", commentsTable)
+
+	result, err := r.db.Exec(query, body, commentID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return models.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (r *CommentRepository) Delete(commentID int) error {
+	query := fmt.Sprintf("delete from %s where id=$1", commentsTable)
+
+	result, err := r.db.Exec(query, commentID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return models.ErrCommentNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+const customFieldColumns = "id, code, name, type, options, required"
+
+type CustomFieldRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewCustomFieldRepository(db *sqlx.DB, orgID int) *CustomFieldRepository {
+	return &CustomFieldRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *CustomFieldRepository) Create(field models.CustomField) (int, error) {
+	var id int
+	query := fmt.Sprintf(`insert into %s (code, name, type, options, required, organization_id)
+								values ($1, $2, $3, $4, $5, $6)
+								returning id`, customFieldsTable)
+
+	if err := r.db.QueryRow(query,
+		field.Code,
+		field.Name,
+		field.Type,
+		field.Options,
+		field.Required,
+		r.orgID).
+		Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrCustomFieldExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *CustomFieldRepository) GetAll() ([]models.CustomField, error) {
+	fields := []models.CustomField{}
+	query := fmt.Sprintf("select %s from %s where organization_id=$1 order by code", customFieldColumns,
+		customFieldsTable)
+
+	if err := r.db.Select(&fields, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// Delete removes the field unless any document of the organization, the trash included,
+// has a value of it, then models.ErrCustomFieldReferenced is returned.
+func (r *CustomFieldRepository) Delete(fieldID int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var code string
+	codeQuery := fmt.Sprintf("select code from %s where id=$1 and organization_id=$2 for update", customFieldsTable)
+	if err := tx.Get(&code, codeQuery, fieldID, r.orgID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrCustomFieldNotFound
+		}
+		return err
+	}
+
+	var used bool
+	usedQuery := fmt.Sprintf(`select exists(select 1 from %s
+								where organization_id=$1 and custom_fields->$2::text is not null)`, docsTable)
+	if err := tx.Get(&used, usedQuery, r.orgID, code); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if used {
+		tx.Rollback()
+		return models.ErrCustomFieldReferenced
+	}
+
+	deleteQuery := fmt.Sprintf("delete from %s where id=$1", customFieldsTable)
+	if _, err := tx.Exec(deleteQuery, fieldID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type DepartmentRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewDepartmentRepository(db *sqlx.DB, orgID int) *DepartmentRepository {
+	return &DepartmentRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *DepartmentRepository) Create(department models.Department) (int, error) {
+	var id int
+	query := fmt.Sprintf("insert into %s (name, organization_id) values ($1, $2) returning id", departmentsTable)
+
+	if err := r.db.QueryRow(query, department.Name, r.orgID).Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrDepartmentExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *DepartmentRepository) GetAll() ([]models.Department, error) {
+	departments := []models.Department{}
+	query := fmt.Sprintf("select * from %s where organization_id=$1 order by name", departmentsTable)
+
+	if err := r.db.Select(&departments, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return departments, nil
+}
+
+func (r *DepartmentRepository) GetByID(departmentID int) (models.Department, error) {
+	var department models.Department
+	query := fmt.Sprintf("select * from %s where id=$1 and organization_id=$2", departmentsTable)
+
+	if err := r.db.Get(&department, query, departmentID, r.orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Department{}, models.ErrDepartmentNotFound
+		}
+		return models.Department{}, err
+	}
+
+	return department, nil
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"sort"
+	"strings"
+)
+
+// likePattern escapes the wildcards of a value matched with like, so they match literally.
+var likePattern = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type whereClause struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add appends condition with a single placeholder, format must contain one %d verb
+// which is replaced with the number of the argument.
+func (w *whereClause) add(format string, arg interface{}) {
+	w.args = append(w.args, arg)
+	w.conditions = append(w.conditions, fmt.Sprintf(format, len(w.args)))
+}
+
+// addScope limits documents aliased as d to the ones of the access scope.
+func (w *whereClause) addScope(scope models.DocumentScope) {
+	own := fmt.Sprintf("d.id in (select document_id from %s where worker_id=$%%d)", workersDocsTable)
+	if scope.DepartmentID == nil {
+		w.add(own, scope.WorkerID)
+		return
+	}
+
+	w.args = append(w.args, scope.WorkerID, *scope.DepartmentID)
+	w.conditions = append(w.conditions,
+		fmt.Sprintf("("+own+" or d.department_id=$%d)", len(w.args)-1, len(w.args)))
+}
+
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+
+	return "where " + strings.Join(w.conditions, " and ")
+}
+
+func documentFilterWhere(orgID int, filter models.DocumentFilter) *whereClause {
+	where := &whereClause{}
+	where.add("d.organization_id=$%d", orgID)
+
+	if filter.Deleted {
+		where.conditions = append(where.conditions, "d.deleted_at is not null")
+	} else {
+		where.conditions = append(where.conditions, "d.deleted_at is null")
+	}
+
+	if filter.WorkerID != 0 {
+		where.add(fmt.Sprintf("d.id in (select document_id from %s where worker_id=$%%d)", workersDocsTable),
+			filter.WorkerID)
+	}
+
+	if filter.Scope != nil {
+		where.addScope(*filter.Scope)
+	}
+
+	if filter.DepartmentID != 0 {
+		where.add("d.department_id=$%d", filter.DepartmentID)
+	}
+
+	if filter.VehicleID != 0 {
+		where.add("d.vehicle_id=$%d", filter.VehicleID)
+	}
+
+	if filter.CarID != "" {
+		where.add("d.car_id=$%d", filter.CarID)
+	}
+
+	if filter.DriverName != "" {
+		where.add(`d.driver_name ilike '%%' || $%d || '%%' escape '\'`, likePattern.Replace(filter.DriverName))
+	}
+
+	if filter.GasType != "" {
+		where.add("d.gas_type=$%d", filter.GasType)
+	}
+
+	if filter.NormExceeded {
+		where.conditions = append(where.conditions, "d.norm_exceeded")
+	}
+
+	if filter.LimitExceeded {
+		where.conditions = append(where.conditions, "d.limit_exceeded")
+	}
+
+	if filter.Status != "" {
+		where.add("d.status=$%d", filter.Status)
+	}
+
+	if filter.IssuedFrom != nil {
+		where.add("d.issue_date>=$%d", *filter.IssuedFrom)
+	}
+
+	if filter.IssuedTo != nil {
+		where.add("d.issue_date<=$%d", *filter.IssuedTo)
+	}
+
+	for _, tag := range filter.Tags {
+		where.add("d.tags @> jsonb_build_array($%d::text)", tag)
+	}
+
+	// codes are sorted to keep the query text the same for the same filter
+	codes := make([]string, 0, len(filter.CustomFields))
+	for code := range filter.CustomFields {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	// containment uses the index of the column and compares the values by their json type
+	for _, code := range codes {
+		value, _ := json.Marshal(filter.CustomFields[code])
+		where.args = append(where.args, code, string(value))
+		where.conditions = append(where.conditions, fmt.Sprintf(
+			"d.custom_fields @> jsonb_build_object($%d::text, $%d::jsonb)", len(where.args)-1, len(where.args)))
+	}
+
+	return where
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDocumentFilterWhere_scope(t *testing.T) {
+	department := 4
+	own := "d.id in (select document_id from workers_documents where worker_id=$2)"
+
+	testTable := []struct {
+		name          string
+		filter        models.DocumentFilter
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "no scope",
+			filter:        models.DocumentFilter{},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null",
+			expectedArgs:  []interface{}{7},
+		},
+		{
+			name:          "own documents",
+			filter:        models.DocumentFilter{Scope: &models.DocumentScope{WorkerID: 3}},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null and " + own,
+			expectedArgs:  []interface{}{7, 3},
+		},
+		{
+			name:          "department documents",
+			filter:        models.DocumentFilter{Scope: &models.DocumentScope{WorkerID: 3, DepartmentID: &department}},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null and (" + own + " or d.department_id=$3)",
+			expectedArgs:  []interface{}{7, 3, 4},
+		},
+		{
+			name: "department documents with filters",
+			filter: models.DocumentFilter{
+				Scope:   &models.DocumentScope{WorkerID: 3, DepartmentID: &department},
+				Status:  models.StatusDraft,
+				GasType: "95",
+			},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null and (" + own +
+				" or d.department_id=$3) and d.gas_type=$4 and d.status=$5",
+			expectedArgs: []interface{}{7, 3, 4, "95", models.StatusDraft},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			where := documentFilterWhere(7, tc.filter)
+
+			assert.Equal(t, tc.expectedWhere, where.String())
+			assert.Equal(t, tc.expectedArgs, where.args)
+		})
+	}
+}
+
+func TestDocumentFilterWhere_tagsAndCustomFields(t *testing.T) {
+	testTable := []struct {
+		name          string
+		filter        models.DocumentFilter
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:   "tags",
+			filter: models.DocumentFilter{Tags: []string{"intercity", "repair trip"}},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null and " +
+				"d.tags @> jsonb_build_array($2::text) and d.tags @> jsonb_build_array($3::text)",
+			expectedArgs: []interface{}{7, "intercity", "repair trip"},
+		},
+		{
+			name:   "custom fields by code",
+			filter: models.DocumentFilter{CustomFields: models.CustomValues{"trip_km": 340.0, "card": "12"}},
+			expectedWhere: "where d.organization_id=$1 and d.deleted_at is null and " +
+				"d.custom_fields @> jsonb_build_object($2::text, $3::jsonb) and " +
+				"d.custom_fields @> jsonb_build_object($4::text, $5::jsonb)",
+			expectedArgs: []interface{}{7, "card", `"12"`, "trip_km", "340"},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			where := documentFilterWhere(7, tc.filter)
+
+			assert.Equal(t, tc.expectedWhere, where.String())
+			assert.Equal(t, tc.expectedArgs, where.args)
+		})
+	}
+}
+
+func TestDocumentFilterWhere_driverName(t *testing.T) {
+	testTable := []struct {
+		name         string
+		driverName   string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "plain name",
+			driverName:   "Ivanov",
+			expectedArgs: []interface{}{7, "Ivanov"},
+		},
+		{
+			name:         "wildcards",
+			driverName:   `100%_\`,
+			expectedArgs: []interface{}{7, `100\%\_\\`},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			where := documentFilterWhere(7, models.DocumentFilter{DriverName: tc.driverName})
+
+			assert.Equal(t, `where d.organization_id=$1 and d.deleted_at is null and `+
+				`d.driver_name ilike '%' || $2 || '%' escape '\'`, where.String())
+			assert.Equal(t, tc.expectedArgs, where.args)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// FuelRepository keeps the fuel catalog shared by all organizations and the prices
+// the organization buys the fuels at.
+type FuelRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewFuelRepository(db *sqlx.DB, orgID int) *FuelRepository {
+	return &FuelRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+// currentPrice selects the price of the fuel aliased as f in effect today,
+// the organization is the first argument of the query.
+var currentPrice = fmt.Sprintf(`(select p.price from %s p
+									where p.fuel_code=f.code and p.organization_id=$1 and p.effective_from<=current_date
+									order by p.effective_from desc limit 1)::float8`, fuelPricesTable)
+
+func (r *FuelRepository) Create(fuel models.Fuel) error {
+	query := fmt.Sprintf("insert into %s (code, name, unit, active) values ($1, $2, $3, $4)", fuelsTable)
+
+	if _, err := r.db.Exec(query, fuel.Code, fuel.Name, fuel.Unit, fuel.Active); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return models.ErrFuelExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *FuelRepository) GetAll(onlyActive bool) ([]models.Fuel, error) {
+	fuels := []models.Fuel{}
+
+	query := fmt.Sprintf("select f.*, %s as price from %s f", currentPrice, fuelsTable)
+	if onlyActive {
+		query += " where f.active"
+	}
+	query += " order by f.code"
+
+	if err := r.db.Select(&fuels, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return fuels, nil
+}
+
+func (r *FuelRepository) GetByCode(code string) (models.Fuel, error) {
+	var fuel models.Fuel
+
+	query := fmt.Sprintf("select f.*, %s as price from %s f where f.code=$2", currentPrice, fuelsTable)
+
+	if err := r.db.Get(&fuel, query, r.orgID, code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Fuel{}, models.ErrFuelNotFound
+		}
+		return models.Fuel{}, err
+	}
+
+	return fuel, nil
+}
+
+func (r *FuelRepository) Update(fuel models.Fuel) error {
+	query := fmt.Sprintf("update %s set name=$1, unit=$2, active=$3 where code=$4", fuelsTable)
+
+	_, err := r.db.Exec(query, fuel.Name, fuel.Unit, fuel.Active, fuel.Code)
+
+	return err
+}
+
+func (r *FuelRepository) Delete(code string) error {
+	query := fmt.Sprintf("delete from %s where code=$1", fuelsTable)
+
+	if _, err := r.db.Exec(query, code); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return models.ErrFuelReferenced
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *FuelRepository) GetPrices(code string) ([]models.FuelPrice, error) {
+	prices := []models.FuelPrice{}
+
+	query := fmt.Sprintf(`select id, fuel_code, price::float8 as price, effective_from from %s
+								where fuel_code=$1 and organization_id=$2 order by effective_from desc`, fuelPricesTable)
+
+	if err := r.db.Select(&prices, query, code, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// SetPrice adds the price to the fuel price history, the price with the same
+// effective date is replaced.
+func (r *FuelRepository) SetPrice(price models.FuelPrice) (int, error) {
+	var id int
+	query := fmt.Sprintf(`insert into %s (fuel_code, price, effective_from, organization_id) values ($1, $2, $3, $4)
+								on conflict (organization_id, fuel_code, effective_from) do update set price=excluded.price
+								returning id`, fuelPricesTable)
+
+	if err := r.db.QueryRow(query, price.FuelCode, price.Price, price.EffectiveFrom, r.orgID).
+		Scan(&id); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return 0, models.ErrFuelNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -1,128 +1,479 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/jmoiron/sqlx"
+	"time"
 )
 
+// documentCost computes cost of the document aliased as d from the price its organization
+// has set for the fuel in effect on the issue date, it's null if there is no such price.
+var documentCost = fmt.Sprintf(`(d.gas_amount * (select p.price from %s p
+									where p.fuel_code=d.gas_type and p.organization_id=d.organization_id
+									  and p.effective_from<=d.issue_date
+									order by p.effective_from desc limit 1))::float8`, fuelPricesTable)
+
 type GSMRepository struct {
-	db *sqlx.DB
+	db                  *sqlx.DB
+	orgID               int
+	refuseStockShortage bool
+	refuseLimitExceeded bool
 }
 
-func NewGSMRepository(db *sqlx.DB) *GSMRepository {
-	return &GSMRepository{db: db}
+func NewGSMRepository(db *sqlx.DB, orgID int, refuseStockShortage, refuseLimitExceeded bool) *GSMRepository {
+	return &GSMRepository{
+		db:                  db,
+		orgID:               orgID,
+		refuseStockShortage: refuseStockShortage,
+		refuseLimitExceeded: refuseLimitExceeded,
+	}
 }
 
 func (r *GSMRepository) Create(workerID int, document models.Document) (int, error) {
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	docID, err := insertDocument(tx, r.orgID, workerID, document)
 	if err != nil {
+		tx.Rollback()
 		return 0, err
 	}
 
+	if err := syncStockIssue(tx, workerID, docID, r.refuseStockShortage); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := syncLimitExceeded(tx, r.orgID, docID, r.refuseLimitExceeded); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return docID, tx.Commit()
+}
+
+// CreateBatch inserts all documents in one transaction, if any of them fails
+// nothing is inserted and the error is returned as *models.BatchError.
+func (r *GSMRepository) CreateBatch(workerID int, documents []models.Document) ([]int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	docIDs := make([]int, 0, len(documents))
+	for i, document := range documents {
+		docID, err := insertDocument(tx, r.orgID, workerID, document)
+		if err != nil {
+			tx.Rollback()
+			return nil, &models.BatchError{Index: i, Err: err}
+		}
+
+		if err := syncStockIssue(tx, workerID, docID, r.refuseStockShortage); err != nil {
+			tx.Rollback()
+			return nil, &models.BatchError{Index: i, Err: err}
+		}
+
+		if err := syncLimitExceeded(tx, r.orgID, docID, r.refuseLimitExceeded); err != nil {
+			tx.Rollback()
+			return nil, &models.BatchError{Index: i, Err: err}
+		}
+
+		docIDs = append(docIDs, docID)
+	}
+
+	return docIDs, tx.Commit()
+}
+
+// insertDocument creates the document in the organization, the document belongs
+// to the department the worker is in at the moment.
+func insertDocument(tx *sqlx.Tx, orgID, workerID int, document models.Document) (int, error) {
+	if err := checkPeriod(tx, orgID, document.IssueDate); err != nil {
+		return 0, err
+	}
+
+	if document.Waybill == 0 {
+		waybill, err := nextWaybill(tx, orgID, document.IssueDate)
+		if err != nil {
+			return 0, err
+		}
+		document.Waybill = waybill
+	}
+
 	var docID int
 	createDocQuery := fmt.Sprintf(`insert into %s 
-    									(car, car_id, waybill, driver_name, gas_amount, gas_type, issue_date)
-    									values ($1,$2,$3,$4,$5,$6,$7)
-    									returning id`, docsTable)
+    									(car, car_id, vehicle_id, waybill, driver_name, gas_amount, gas_type, issue_date, status,
+    									 odometer_start, odometer_end, norm_exceeded, limit_exceeded, tags, custom_fields,
+    									 organization_id, department_id)
+    									values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,
+    									        (select department_id from %s where id=$17))
+    									returning id`, docsTable, workersTable)
 
 	if err := tx.QueryRow(createDocQuery,
 		document.Car,
 		document.CarID,
+		document.VehicleID,
 		document.Waybill,
 		document.DriverName,
 		document.GasAmount,
 		document.GasType,
-		document.IssueDate).
+		document.IssueDate,
+		document.Status,
+		document.OdometerStart,
+		document.OdometerEnd,
+		document.NormExceeded,
+		document.LimitExceeded,
+		document.Tags,
+		document.CustomFields,
+		orgID,
+		workerID).
 		Scan(&docID); err != nil {
-		tx.Rollback()
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrWaybillTaken
+		}
 		return 0, err
 	}
 
 	workersDocsQuery := fmt.Sprintf("insert into %s (worker_id, document_id) values ($1, $2)",
 		workersDocsTable)
 	if _, err := tx.Exec(workersDocsQuery, workerID, docID); err != nil {
-		tx.Rollback()
 		return 0, err
 	}
 
-	return docID, tx.Commit()
+	return docID, nil
 }
 
-func (r *GSMRepository) GetAll() ([]models.Document, error) {
-	var documents []models.Document
+func (r *GSMRepository) GetAll(filter models.DocumentFilter) ([]models.Document, int, error) {
+	where := documentFilterWhere(r.orgID, filter)
 
-	query := fmt.Sprintf("select * from %s", docsTable)
+	var total int
+	countQuery := fmt.Sprintf("select count(*) from %s d %s", docsTable, where)
+	if err := r.db.Get(&total, countQuery, where.args...); err != nil {
+		return nil, 0, err
+	}
 
-	if err := r.db.Select(&documents, query); err != nil {
-		return nil, err
+	documents := []models.Document{}
+	column, direction := filter.OrderBy()
+	query := fmt.Sprintf("select d.*, %s as cost from %s d %s order by d.%s %s, d.id limit %d offset %d",
+		documentCost, docsTable, where, column, direction, filter.Limit, filter.Offset)
+
+	if err := r.db.Select(&documents, query, where.args...); err != nil {
+		return nil, 0, err
 	}
 
-	return documents, nil
+	return documents, total, nil
+}
+
+// Export passes every document matching the filter to fn reading them one by one
+// from the database cursor, pagination of the filter is ignored.
+func (r *GSMRepository) Export(filter models.DocumentFilter, fn func(models.Document) error) error {
+	where := documentFilterWhere(r.orgID, filter)
+	column, direction := filter.OrderBy()
+	query := fmt.Sprintf("select d.*, %s as cost from %s d %s order by d.%s %s, d.id",
+		documentCost, docsTable, where, column, direction)
+
+	rows, err := r.db.Queryx(query, where.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var document models.Document
+		if err := rows.StructScan(&document); err != nil {
+			return err
+		}
+
+		if err := fn(document); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
 func (r *GSMRepository) GetByID(docID int) (models.Document, error) {
 	var document models.Document
 
-	query := fmt.Sprintf("select * from %s where id=$1", docsTable)
+	query := fmt.Sprintf(`select d.*, %s as cost from %s d
+								where d.id=$1 and d.organization_id=$2 and d.deleted_at is null`, documentCost, docsTable)
 
-	if err := r.db.Get(&document, query, docID); err != nil {
+	if err := r.db.Get(&document, query, docID, r.orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Document{}, models.ErrDocumentNotFound
+		}
 		return models.Document{}, err
 	}
 
 	return document, nil
 }
 
-func (r *GSMRepository) GetAllWithWorkerID(workerID int) ([]models.Document, error) {
-	var documents []models.Document
+// GetAuthorID returns id of the worker who created the document.
+func (r *GSMRepository) GetAuthorID(docID int) (int, error) {
+	var workerID int
+
+	query := fmt.Sprintf(`select wd.worker_id from %s wd join %s d on d.id=wd.document_id
+								where wd.document_id=$1 and d.organization_id=$2`, workersDocsTable, docsTable)
 
-	query := fmt.Sprintf("select d.* from %s d inner join %s wd on d.id=wd.document_id where wd.worker_id=$1",
-		docsTable, workersDocsTable)
+	if err := r.db.Get(&workerID, query, docID, r.orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, models.ErrDocumentNotFound
+		}
+		return 0, err
+	}
+
+	return workerID, nil
+}
 
-	if err := r.db.Select(&documents, query, workerID); err != nil {
+// PreviousOdometer returns the return odometer reading of the latest waybill of the vehicle
+// issued not later than the date, the document itself is skipped. It's nil if there is no such waybill.
+func (r *GSMRepository) PreviousOdometer(vehicleID, docID int, issueDate models.MyTime) (*int, error) {
+	var odometer *int
+
+	query := fmt.Sprintf(`select odometer_end from %s
+								where vehicle_id=$1 and id<>$2 and issue_date<=$3
+								  and odometer_end is not null and deleted_at is null
+								order by issue_date desc, id desc
+								limit 1`, docsTable)
+
+	if err := r.db.Get(&odometer, query, vehicleID, docID, issueDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
-	return documents, nil
+	return odometer, nil
 }
 
-func (r *GSMRepository) Update(document models.Document) error {
+// Update overwrites the document and records the revision with the values
+// the document had before and after the update on behalf of the worker.
+// It fails with models.ErrVersionMismatch if the document has been changed since it was read.
+func (r *GSMRepository) Update(workerID int, document models.Document) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var old models.Document
+	oldQuery := fmt.Sprintf("select * from %s where id=$1 and organization_id=$2 and deleted_at is null for update",
+		docsTable)
+	if err := tx.Get(&old, oldQuery, document.ID, r.orgID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrDocumentNotFound
+		}
+		return err
+	}
+
+	if old.Version != document.Version {
+		tx.Rollback()
+		return models.ErrVersionMismatch
+	}
+
+	// the document can neither be changed in a closed period nor moved into one
+	if err := checkPeriod(tx, r.orgID, old.IssueDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if document.IssueDate != old.IssueDate {
+		if err := checkPeriod(tx, r.orgID, document.IssueDate); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	query := fmt.Sprintf(`update %s 
-						set car=$1, car_id=$2, waybill=$3, driver_name=$4, gas_amount=$5, gas_type=$6, issue_date=$7
-						where id=$8`, docsTable)
+						set car=$1, car_id=$2, vehicle_id=$3, waybill=$4, driver_name=$5, gas_amount=$6, gas_type=$7,
+						    issue_date=$8, odometer_start=$9, odometer_end=$10, norm_exceeded=$11, limit_exceeded=$12,
+						    tags=$13, custom_fields=$14, version=version+1
+						where id=$15`, docsTable)
 
-	if _, err := r.db.Exec(query,
+	if _, err := tx.Exec(query,
 		document.Car,
 		document.CarID,
+		document.VehicleID,
 		document.Waybill,
 		document.DriverName,
 		document.GasAmount,
 		document.GasType,
 		document.IssueDate,
+		document.OdometerStart,
+		document.OdometerEnd,
+		document.NormExceeded,
+		document.LimitExceeded,
+		document.Tags,
+		document.CustomFields,
 		document.ID); err != nil {
+		tx.Rollback()
+		if isViolation(err, uniqueViolation) {
+			return models.ErrWaybillTaken
+		}
+		return err
+	}
+
+	if err := syncStockIssue(tx, workerID, document.ID, r.refuseStockShortage); err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	return nil
+	// the limits are checked again only if the document counts differently, like the service does
+	if document.VehicleID != old.VehicleID || document.GasAmount != old.GasAmount ||
+		document.GasType != old.GasType || document.IssueDate != old.IssueDate {
+		if err := syncLimitExceeded(tx, r.orgID, document.ID, r.refuseLimitExceeded); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := insertRevision(tx, workerID, old, document); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *GSMRepository) Delete(docID int) error {
-	tx, err := r.db.Begin()
+// ChangeStatus moves the document to the new status and records the transition,
+// it fails with models.ErrTransitionNotAllowed if the document status has been changed meanwhile.
+func (r *GSMRepository) ChangeStatus(workerID int, transition models.Transition) error {
+	tx, err := r.db.Beginx()
 	if err != nil {
 		return err
 	}
 
-	deleteFromDocsQuery := fmt.Sprintf("delete from %s where id=$1", docsTable)
-	if _, err := tx.Exec(deleteFromDocsQuery, docID); err != nil {
+	var issueDate models.MyTime
+	query := fmt.Sprintf(`update %s set status=$1, version=version+1
+								where id=$2 and status=$3 and organization_id=$4 and deleted_at is null
+								returning issue_date`, docsTable)
+
+	if err := tx.Get(&issueDate, query, transition.ToStatus, transition.DocumentID, transition.FromStatus,
+		r.orgID); err != nil {
 		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrTransitionNotAllowed
+		}
 		return err
 	}
 
-	deleteFromWorkersDocs := fmt.Sprintf("delete from %s where document_id=$1", workersDocsTable)
-	if _, err := tx.Exec(deleteFromWorkersDocs, docID); err != nil {
+	if err := checkPeriod(tx, r.orgID, issueDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := syncStockIssue(tx, workerID, transition.DocumentID, r.refuseStockShortage); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertTransition(tx, workerID, transition); err != nil {
 		tx.Rollback()
 		return err
 	}
 
 	return tx.Commit()
 }
+
+// Delete moves the document to the trash marking it as deleted by the worker,
+// the document is kept with its history until it's purged.
+func (r *GSMRepository) Delete(docID, workerID int) error {
+	return r.setDeleted(docID, workerID, true)
+}
+
+// Restore brings the document back from the trash.
+func (r *GSMRepository) Restore(docID, workerID int) error {
+	return r.setDeleted(docID, workerID, false)
+}
+
+func (r *GSMRepository) setDeleted(docID, workerID int, deleted bool) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	state := "is null"
+	query := fmt.Sprintf("update %s set deleted_at=now(), deleted_by=$1, version=version+1 where id=$2 returning *", docsTable)
+	args := []interface{}{workerID, docID}
+	if !deleted {
+		state = "is not null"
+		query = fmt.Sprintf("update %s set deleted_at=null, deleted_by=null, version=version+1 where id=$1 returning *", docsTable)
+		args = []interface{}{docID}
+	}
+
+	var old models.Document
+	oldQuery := fmt.Sprintf("select * from %s where id=$1 and organization_id=$2 and deleted_at %s for update",
+		docsTable, state)
+	if err := tx.Get(&old, oldQuery, docID, r.orgID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrDocumentNotFound
+		}
+		return err
+	}
+
+	if err := checkPeriod(tx, r.orgID, old.IssueDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var document models.Document
+	if err := tx.Get(&document, query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := syncStockIssue(tx, workerID, docID, r.refuseStockShortage); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := insertRevision(tx, workerID, old, document); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Purge removes documents which were moved to the trash before the given time for good
+// together with their revisions and attachment rows, returns ids of the removed documents.
+// Documents issued in a closed period are kept until the period is reopened.
+func (r *GSMRepository) Purge(deletedBefore time.Time) ([]int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []time.Time
+	periodsQuery := fmt.Sprintf(`select distinct date_trunc('month', issue_date)::date from %s
+								where organization_id=$1 and deleted_at is not null and deleted_at<$2`, docsTable)
+	if err := tx.Select(&periods, periodsQuery, r.orgID, deletedBefore); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, period := range periods {
+		if err := lockPeriod(tx, r.orgID, period, false); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	query := fmt.Sprintf(`delete from %s d where d.organization_id=$1 and d.deleted_at is not null and d.deleted_at<$2
+								and not exists(select 1 from %s c where c.organization_id=d.organization_id
+								and c.period=date_trunc('month', d.issue_date)::date and c.reopened_at is null)
+								returning d.id`, docsTable, periodClosingsTable)
+
+	docIDs := []int{}
+	if err := tx.Select(&docIDs, query, r.orgID, deletedBefore); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return docIDs, tx.Commit()
+}
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// limitColumns casts numeric columns, so they can be scanned into floats
+const limitColumns = "id, vehicle_id, department_id, period, unit, amount::float8 as amount, alert_percent::float8 as alert_percent"
+
+type LimitRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewLimitRepository(db *sqlx.DB, orgID int) *LimitRepository {
+	return &LimitRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *LimitRepository) Create(limit models.Limit) (int, error) {
+	var id int
+	query := fmt.Sprintf(`insert into %s (vehicle_id, department_id, period, unit, amount, alert_percent,
+								organization_id)
+								values ($1, $2, $3, $4, $5, $6, $7)
+								returning id`, limitsTable)
+
+	if err := r.db.QueryRow(query,
+		limit.VehicleID,
+		limit.DepartmentID,
+		limit.Period,
+		limit.Unit,
+		limit.Amount,
+		limit.AlertPercent,
+		r.orgID).
+		Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrLimitExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *LimitRepository) GetAll() ([]models.Limit, error) {
+	limits := []models.Limit{}
+	query := fmt.Sprintf("select %s from %s where organization_id=$1 order by id", limitColumns, limitsTable)
+
+	if err := r.db.Select(&limits, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+// vehicleLimitsQuery selects limits of the vehicle $1 and of its department in the organization $2
+var vehicleLimitsQuery = fmt.Sprintf(`select %s from %s
+								where organization_id=$2
+								  and (vehicle_id=$1 or department_id=(select department_id from %s where id=$1))
+								order by id`, limitColumns, limitsTable, vehiclesTable)
+
+// GetForVehicle returns limits of the vehicle and of the department it belongs to.
+func (r *LimitRepository) GetForVehicle(vehicleID int) ([]models.Limit, error) {
+	limits := []models.Limit{}
+
+	if err := r.db.Select(&limits, vehicleLimitsQuery, vehicleID, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func (r *LimitRepository) Delete(limitID int) error {
+	query := fmt.Sprintf("delete from %s where id=$1 and organization_id=$2", limitsTable)
+
+	result, err := r.db.Exec(query, limitID, r.orgID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrLimitNotFound
+	}
+
+	return nil
+}
+
+// Usage sums liters or cost of documents counted against the limit which were issued
+// in [from, to), the document excludedDocID is left out. Rejected documents aren't counted.
+func (r *LimitRepository) Usage(limit models.Limit, from, to time.Time, excludedDocID int) (float64, error) {
+	return limitUsage(r.db, r.orgID, limit, from, to, excludedDocID)
+}
+
+func limitUsage(db getter, orgID int, limit models.Limit, from, to time.Time, excludedDocID int) (float64, error) {
+	amount := "d.gas_amount"
+	if limit.Unit == models.LimitMoney {
+		amount = documentCost
+	}
+
+	owner, ownerID := "d.vehicle_id=$6", limit.VehicleID
+	if limit.DepartmentID != nil {
+		owner, ownerID = "v.department_id=$6", limit.DepartmentID
+	}
+
+	var used float64
+	query := fmt.Sprintf(`select coalesce(sum(%s), 0)::float8 from %s d
+								join %s v on v.id=d.vehicle_id
+								where d.deleted_at is null and d.status<>$1 and d.organization_id=$2
+								  and d.issue_date>=$3 and d.issue_date<$4 and d.id<>$5 and %s`,
+		amount, docsTable, vehiclesTable, owner)
+
+	if err := db.Get(&used, query, models.StatusRejected, orgID, from, to, excludedDocID, *ownerID); err != nil {
+		return 0, err
+	}
+
+	return used, nil
+}
+
+// syncLimitExceeded sets the limit exceeded flag of the document from the usage of its limits
+// counting the document itself, with refuseExceeded a document taking a limit over the amount
+// fails with models.ErrLimitExceeded instead. Like the stock balance, the usage of every limit is
+// summed under a lock held until the transaction ends, so concurrent documents counted against
+// the same limit are checked one after another and each one sees the documents committed before it.
+func syncLimitExceeded(tx *sqlx.Tx, orgID, docID int, refuseExceeded bool) error {
+	var document models.Document
+	documentQuery := fmt.Sprintf("select vehicle_id, issue_date, status from %s where id=$1", docsTable)
+	if err := tx.Get(&document, documentQuery, docID); err != nil {
+		return err
+	}
+
+	exceeded := false
+	if document.Status != models.StatusRejected {
+		limits := []models.Limit{}
+		if err := tx.Select(&limits, vehicleLimitsQuery, document.VehicleID, orgID); err != nil {
+			return err
+		}
+
+		// limits are locked in the order of their ids, so documents sharing limits don't deadlock
+		for _, limit := range limits {
+			from, to := limit.Bounds(document.IssueDate)
+
+			// the lock is taken after the write, the next statement sees everything committed while waiting for it
+			lockQuery := "select pg_advisory_xact_lock($1, hashtext($2))"
+			key := fmt.Sprintf("limit %d %s", limit.ID, from.Format(models.PeriodLayout))
+			if _, err := tx.Exec(lockQuery, orgID, key); err != nil {
+				return err
+			}
+
+			used, err := limitUsage(tx, orgID, limit, from, to, 0)
+			if err != nil {
+				return err
+			}
+
+			if used > limit.Amount {
+				if refuseExceeded {
+					alert := models.LimitAlert{Limit: limit, From: from, Used: used, Exceeded: true}
+					return fmt.Errorf("%w: %s", models.ErrLimitExceeded, alert)
+				}
+				exceeded = true
+			}
+		}
+	}
+
+	flagQuery := fmt.Sprintf("update %s set limit_exceeded=$1 where id=$2", docsTable)
+	_, err := tx.Exec(flagQuery, exceeded, docID)
+
+	return err
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGSMRepository_concurrentLimit(t *testing.T) {
+	db := testDB(t)
+
+	var orgID, workerID, vehicleID int
+	if err := db.Get(&orgID, "insert into organizations (name) values ('test') returning id"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Get(&workerID, `insert into workers (name, surname, fathers_name, phone, role, password_hash,
+									organization_id)
+									values ('test', 'test', 'test', '+375 29 111-11-11', 'worker', 'hash', $1)
+									returning id`, orgID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("insert into fuels (code, name) values ('95', 'AI-95')"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Get(&vehicleID, `insert into vehicles (plate_number, model, fuel_type, organization_id)
+									values ('1111 AA-1', 'MAZ', '95', $1) returning id`, orgID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`insert into fuel_limits (vehicle_id, period, unit, amount, organization_id)
+							values ($1, 'month', 'liters', 100, $2)`, vehicleID, orgID); err != nil {
+		t.Fatal(err)
+	}
+
+	issueDate, _ := time.Parse("2006-01-02", "2023-01-10")
+	document := models.Document{
+		Car:        "MAZ",
+		CarID:      "1111 AA-1",
+		VehicleID:  vehicleID,
+		DriverName: "test_name",
+		GasAmount:  60,
+		GasType:    "95",
+		IssueDate:  models.MyTime(issueDate),
+		Status:     models.StatusDraft,
+	}
+
+	// each of the documents fits the limit, but not both of them
+	repository := NewGSMRepository(db, orgID, false, true)
+	errs := make([]error, 2)
+
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = repository.Create(workerID, document)
+		}(i)
+	}
+	wg.Wait()
+
+	refused := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.True(t, errors.Is(err, models.ErrLimitExceeded), err.Error())
+			refused++
+		}
+	}
+	assert.Equal(t, 1, refused)
+
+	var used int
+	if err := db.Get(&used, "select sum(gas_amount) from documents where organization_id=$1", orgID); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 60, used)
+}
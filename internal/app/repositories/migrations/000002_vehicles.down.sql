@@ -0,0 +1,4 @@
+alter table documents
+    drop column vehicle_id;
+
+drop table vehicles;
@@ -0,0 +1,26 @@
+create table vehicles
+(
+    id            serial primary key,
+    plate_number  varchar(50) not null unique,
+    model         varchar(50) not null,
+    fuel_type     varchar(50) not null,
+    tank_capacity int check ( tank_capacity > 0 ),
+    active        boolean     not null default true
+);
+
+-- every plate number met in documents becomes a vehicle, tank capacity is unknown for them
+insert into vehicles (plate_number, model, fuel_type)
+select car_id, min(car), mode() within group ( order by gas_type )
+from documents
+group by car_id;
+
+alter table documents
+    add column vehicle_id int references vehicles (id);
+
+update documents d
+set vehicle_id = v.id
+from vehicles v
+where v.plate_number = d.car_id;
+
+alter table documents
+    alter column vehicle_id set not null;
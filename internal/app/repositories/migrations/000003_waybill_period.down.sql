@@ -0,0 +1,4 @@
+drop index documents_waybill_period_key;
+
+alter table documents
+    add constraint documents_car_id_key unique (car_id);
@@ -0,0 +1,5 @@
+alter table documents
+    drop constraint documents_car_id_key;
+
+-- waybill numbers start over every year, so they have to be unique within the year of issue only
+create unique index documents_waybill_period_key on documents (waybill, date_trunc('year', issue_date::timestamp));
@@ -0,0 +1 @@
+drop table document_revisions;
@@ -0,0 +1,11 @@
+create table document_revisions
+(
+    id          serial primary key,
+    document_id int references documents (id) on delete cascade not null,
+    worker_id   int references workers (id) on delete set null,
+    old_values  jsonb                                            not null,
+    new_values  jsonb                                            not null,
+    created_at  timestamptz                                      not null default now()
+);
+
+create index document_revisions_document_id_idx on document_revisions (document_id);
@@ -0,0 +1,6 @@
+delete from workers_documents where document_id in (select id from documents where deleted_at is not null);
+delete from documents where deleted_at is not null;
+
+alter table documents
+    drop column deleted_at,
+    drop column deleted_by;
@@ -0,0 +1,5 @@
+alter table documents
+    add column deleted_at timestamptz,
+    add column deleted_by int references workers (id) on delete set null;
+
+create index documents_deleted_at_idx on documents (deleted_at) where deleted_at is not null;
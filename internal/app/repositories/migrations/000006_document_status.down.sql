@@ -0,0 +1,4 @@
+drop table document_transitions;
+
+alter table documents
+    drop column status;
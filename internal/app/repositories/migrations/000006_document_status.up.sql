@@ -0,0 +1,19 @@
+-- documents created before the workflow were final, so they are treated as approved
+alter table documents
+    add column status varchar(20) not null default 'approved';
+
+alter table documents
+    alter column status set default 'draft';
+
+create table document_transitions
+(
+    id          serial primary key,
+    document_id int references documents (id) on delete cascade not null,
+    worker_id   int references workers (id) on delete set null,
+    from_status varchar(20)                                      not null,
+    to_status   varchar(20)                                      not null,
+    reason      text                                             not null default '',
+    created_at  timestamptz                                      not null default now()
+);
+
+create index document_transitions_document_id_idx on document_transitions (document_id);
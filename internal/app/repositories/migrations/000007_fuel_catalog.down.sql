@@ -0,0 +1,6 @@
+alter table documents
+    drop constraint documents_gas_type_fkey;
+
+drop table fuel_prices;
+
+drop table fuels;
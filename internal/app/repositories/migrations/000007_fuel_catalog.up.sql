@@ -0,0 +1,24 @@
+create table fuels
+(
+    code   varchar(50) primary key,
+    name   varchar(100) not null,
+    unit   varchar(20)  not null default 'l',
+    active boolean      not null default true
+);
+
+create table fuel_prices
+(
+    id             serial primary key,
+    fuel_code      varchar(50) references fuels (code) on delete cascade not null,
+    price          numeric(12, 2) check ( price >= 0 )                   not null,
+    effective_from date                                                  not null,
+    unique (fuel_code, effective_from)
+);
+
+-- every gas type met in documents becomes a catalog entry, prices have to be entered by admins
+insert into fuels (code, name)
+select distinct gas_type, gas_type
+from documents;
+
+alter table documents
+    add constraint documents_gas_type_fkey foreign key (gas_type) references fuels (code);
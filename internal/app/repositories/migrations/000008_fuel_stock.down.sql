@@ -0,0 +1,4 @@
+alter table documents
+    drop column stock_shortage;
+
+drop table stock_movements;
@@ -0,0 +1,24 @@
+create table stock_movements
+(
+    id          serial primary key,
+    fuel_code   varchar(50) references fuels (code)                not null,
+    kind        varchar(20)                                        not null,
+    amount      int check ( amount <> 0 )                          not null,
+    moved_at    date                                               not null,
+    document_id int unique references documents (id) on delete cascade,
+    worker_id   int references workers (id) on delete set null,
+    note        text                                               not null default '',
+    created_at  timestamptz                                        not null default now()
+);
+
+create index stock_movements_fuel_code_moved_at_idx on stock_movements (fuel_code, moved_at);
+
+alter table documents
+    add column stock_shortage boolean not null default false;
+
+-- every approved document draws the stock down, the opening balance has to be entered as a receipt
+insert into stock_movements (fuel_code, kind, amount, moved_at, document_id)
+select gas_type, 'issue', -gas_amount, issue_date, id
+from documents
+where status = 'approved'
+  and deleted_at is null;
@@ -0,0 +1,11 @@
+drop index documents_vehicle_id_issue_date_idx;
+
+alter table documents
+    drop column norm_exceeded,
+    drop column consumption,
+    drop column mileage,
+    drop column odometer_end,
+    drop column odometer_start;
+
+alter table vehicles
+    drop column consumption_norm;
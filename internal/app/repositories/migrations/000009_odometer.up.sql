@@ -0,0 +1,13 @@
+alter table vehicles
+    add column consumption_norm numeric(6, 2) check ( consumption_norm > 0 );
+
+alter table documents
+    add column odometer_start int check ( odometer_start >= 0 ),
+    add column odometer_end   int,
+    add column mileage        int generated always as ( odometer_end - odometer_start ) stored,
+    add column consumption    float8 generated always as
+        ( (gas_amount * 100.0 / nullif(odometer_end - odometer_start, 0))::float8 ) stored,
+    add column norm_exceeded  boolean not null default false,
+    add constraint documents_odometer_check check ( odometer_end >= odometer_start );
+
+create index documents_vehicle_id_issue_date_idx on documents (vehicle_id, issue_date);
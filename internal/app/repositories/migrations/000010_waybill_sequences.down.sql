@@ -0,0 +1 @@
+drop table waybill_sequences;
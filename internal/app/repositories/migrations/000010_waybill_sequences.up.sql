@@ -0,0 +1,11 @@
+create table waybill_sequences
+(
+    year       int primary key,
+    last_value int not null check ( last_value between 999 and 9999 )
+);
+
+-- continue from the largest number used in every year
+insert into waybill_sequences (year, last_value)
+select extract(year from issue_date)::int, max(waybill)
+from documents
+group by 1;
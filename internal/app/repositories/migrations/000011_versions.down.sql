@@ -0,0 +1,5 @@
+alter table workers
+    drop column version;
+
+alter table documents
+    drop column version;
@@ -0,0 +1,6 @@
+-- version is increased by every update and used as an etag for optimistic locking
+alter table documents
+    add column version int not null default 1;
+
+alter table workers
+    add column version int not null default 1;
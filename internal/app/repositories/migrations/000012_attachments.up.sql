@@ -0,0 +1,15 @@
+-- files are kept in the storage, rows of purged documents are removed with them
+create table document_attachments
+(
+    id           serial primary key,
+    document_id  int references documents (id) on delete cascade not null,
+    file_name    varchar(255)                                    not null,
+    content_type varchar(100)                                    not null,
+    size         bigint                                          not null check ( size > 0 ),
+    checksum     char(64)                                        not null,
+    storage_key  varchar(255)                                    not null unique,
+    uploaded_by  int                                             references workers (id) on delete set null,
+    created_at   timestamptz                                     not null default now()
+);
+
+create index document_attachments_document_id_idx on document_attachments (document_id);
@@ -0,0 +1 @@
+drop table period_closings;
@@ -0,0 +1,14 @@
+create table period_closings
+(
+    id            serial primary key,
+    period        date                                    not null check ( extract(day from period) = 1 ),
+    reason        text                                    not null,
+    closed_by     int references workers (id) on delete set null,
+    closed_at     timestamptz                             not null default now(),
+    reopen_reason text,
+    reopened_by   int references workers (id) on delete set null,
+    reopened_at   timestamptz
+);
+
+-- a period can be closed again after it was reopened, but only once at a time
+create unique index period_closings_period_idx on period_closings (period) where reopened_at is null;
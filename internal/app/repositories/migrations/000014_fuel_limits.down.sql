@@ -0,0 +1,9 @@
+alter table documents
+    drop column limit_exceeded;
+
+drop table fuel_limits;
+
+alter table vehicles
+    drop column department_id;
+
+drop table departments;
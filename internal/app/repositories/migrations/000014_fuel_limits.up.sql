@@ -0,0 +1,26 @@
+create table departments
+(
+    id   serial primary key,
+    name varchar(255) not null unique
+);
+
+alter table vehicles
+    add column department_id int references departments (id) on delete set null;
+
+create table fuel_limits
+(
+    id            serial primary key,
+    vehicle_id    int references vehicles (id) on delete cascade,
+    department_id int references departments (id) on delete cascade,
+    period        varchar(10)                                      not null check ( period in ('month', 'quarter') ),
+    unit          varchar(10)                                      not null check ( unit in ('liters', 'money') ),
+    amount        numeric(12, 2) check ( amount > 0 )              not null,
+    alert_percent numeric(5, 2) check ( alert_percent > 0 and alert_percent <= 100 ) default 80 not null,
+    check ( (vehicle_id is null) <> (department_id is null) )
+);
+
+create unique index fuel_limits_vehicle_idx on fuel_limits (vehicle_id, period, unit) where vehicle_id is not null;
+create unique index fuel_limits_department_idx on fuel_limits (department_id, period, unit) where department_id is not null;
+
+alter table documents
+    add column limit_exceeded boolean not null default false;
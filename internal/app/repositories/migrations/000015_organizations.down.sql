@@ -0,0 +1,43 @@
+drop index period_closings_period_idx;
+create unique index period_closings_period_idx on period_closings (period) where reopened_at is null;
+alter table period_closings
+    drop column organization_id;
+
+drop index fuel_limits_vehicle_idx;
+create unique index fuel_limits_vehicle_idx on fuel_limits (vehicle_id, period, unit) where vehicle_id is not null;
+alter table fuel_limits
+    drop column organization_id;
+
+alter table stock_movements
+    drop column organization_id;
+
+alter table waybill_sequences
+    drop constraint waybill_sequences_pkey;
+delete
+from waybill_sequences s
+where exists(select 1 from waybill_sequences o where o.year = s.year and o.organization_id < s.organization_id);
+alter table waybill_sequences
+    drop column organization_id,
+    add primary key (year);
+
+drop index documents_waybill_period_key;
+create unique index documents_waybill_period_key on documents (waybill, date_trunc('year', issue_date::timestamp));
+
+drop index documents_organization_id_idx;
+alter table documents
+    drop column department_id,
+    drop column organization_id;
+
+delete
+from workers
+where role = 'superadmin';
+alter table workers
+    drop column department_id,
+    drop column organization_id;
+
+alter table departments
+    drop constraint departments_organization_id_name_key,
+    drop column organization_id,
+    add unique (name);
+
+drop table organizations;
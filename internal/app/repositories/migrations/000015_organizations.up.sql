@@ -0,0 +1,79 @@
+create table organizations
+(
+    id         serial primary key,
+    name       varchar(255) not null unique,
+    created_at timestamptz  not null default now()
+);
+
+-- everything created before organizations were introduced belongs to the first one
+insert into organizations (name)
+values ('default');
+
+alter table departments
+    add column organization_id int references organizations (id) on delete cascade;
+update departments
+set organization_id = (select min(id) from organizations);
+alter table departments
+    alter column organization_id set not null,
+    drop constraint departments_name_key,
+    add unique (organization_id, name);
+
+-- super-admins are appointed by hand and don't belong to any organization:
+-- update workers set role='superadmin', organization_id=null, department_id=null where id=...
+alter table workers
+    add column organization_id int references organizations (id) on delete cascade,
+    add column department_id   int references departments (id) on delete set null;
+update workers
+set organization_id = (select min(id) from organizations);
+alter table workers
+    add constraint workers_organization_id_check check ( (role = 'superadmin') = (organization_id is null) );
+
+alter table documents
+    add column organization_id int references organizations (id) on delete cascade,
+    add column department_id   int references departments (id) on delete set null;
+update documents
+set organization_id = (select min(id) from organizations);
+alter table documents
+    alter column organization_id set not null;
+create index documents_organization_id_idx on documents (organization_id);
+
+-- waybill numbers are allocated by every organization on its own
+drop index documents_waybill_period_key;
+create unique index documents_waybill_period_key
+    on documents (organization_id, waybill, date_trunc('year', issue_date::timestamp));
+
+alter table waybill_sequences
+    add column organization_id int references organizations (id) on delete cascade;
+update waybill_sequences
+set organization_id = (select min(id) from organizations);
+alter table waybill_sequences
+    alter column organization_id set not null,
+    drop constraint waybill_sequences_pkey,
+    add primary key (organization_id, year);
+
+alter table stock_movements
+    add column organization_id int references organizations (id) on delete cascade;
+update stock_movements
+set organization_id = (select min(id) from organizations);
+alter table stock_movements
+    alter column organization_id set not null;
+
+alter table fuel_limits
+    add column organization_id int references organizations (id) on delete cascade;
+update fuel_limits
+set organization_id = (select min(id) from organizations);
+alter table fuel_limits
+    alter column organization_id set not null;
+drop index fuel_limits_vehicle_idx;
+create unique index fuel_limits_vehicle_idx on fuel_limits (organization_id, vehicle_id, period, unit)
+    where vehicle_id is not null;
+
+alter table period_closings
+    add column organization_id int references organizations (id) on delete cascade;
+update period_closings
+set organization_id = (select min(id) from organizations);
+alter table period_closings
+    alter column organization_id set not null;
+drop index period_closings_period_idx;
+create unique index period_closings_period_idx on period_closings (organization_id, period)
+    where reopened_at is null;
@@ -0,0 +1,2 @@
+alter table workers
+    drop column department_access;
@@ -0,0 +1,2 @@
+alter table workers
+    add column department_access boolean not null default false;
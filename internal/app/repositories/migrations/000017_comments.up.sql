@@ -0,0 +1,13 @@
+-- the author name is kept as it was when the comment was written
+create table document_comments
+(
+    id          serial primary key,
+    document_id int references documents (id) on delete cascade not null,
+    worker_id   int                                             references workers (id) on delete set null,
+    author      varchar(255)                                    not null,
+    body        text                                            not null check ( body <> '' ),
+    created_at  timestamptz                                     not null default now(),
+    updated_at  timestamptz
+);
+
+create index document_comments_document_id_idx on document_comments (document_id);
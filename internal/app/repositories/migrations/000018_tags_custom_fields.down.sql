@@ -0,0 +1,9 @@
+drop index documents_custom_fields_idx;
+drop index documents_tags_idx;
+
+alter table documents
+    drop column custom_fields,
+    drop column tags;
+
+drop table custom_fields;
+drop table tags;
@@ -0,0 +1,28 @@
+create table tags
+(
+    id              serial primary key,
+    organization_id int references organizations (id) on delete cascade not null,
+    name            varchar(50)                                          not null,
+    unique (organization_id, name)
+);
+
+create table custom_fields
+(
+    id              serial primary key,
+    organization_id int references organizations (id) on delete cascade not null,
+    code            varchar(50)                                          not null,
+    name            varchar(255)                                         not null,
+    type            varchar(10)                                          not null
+        check ( type in ('string', 'number', 'date', 'enum') ),
+    options         jsonb                                                not null default '[]',
+    required        boolean                                              not null default false,
+    unique (organization_id, code)
+);
+
+-- documents refer to tags by name and keep custom values by field code
+alter table documents
+    add column tags          jsonb not null default '[]',
+    add column custom_fields jsonb not null default '{}';
+
+create index documents_tags_idx on documents using gin (tags);
+create index documents_custom_fields_idx on documents using gin (custom_fields);
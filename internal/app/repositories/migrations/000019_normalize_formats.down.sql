@@ -0,0 +1 @@
+-- the spelling phones and plates had before they were normalized isn't kept, there is nothing to revert
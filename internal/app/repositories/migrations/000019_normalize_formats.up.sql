@@ -0,0 +1,61 @@
+-- Phones and plates are stored in the canonical form of their format since the inputs are normalized,
+-- this brings the rows stored before to the same form, so workers can sign in and plates match filters.
+-- The formats of internal/pkg/format are tried in the order they are declared there, values matching
+-- none of them and values whose canonical form is already taken by another row are left as they are.
+
+create function pg_temp.normalize_phone(value text) returns text as
+$$
+select case
+           when c ~ '^375[0-9]{9}$'
+               then regexp_replace(c, '^(375)([0-9]{2})([0-9]{3})([0-9]{2})([0-9]{2})$', '+\1 \2 \3-\4-\5')
+           when c ~ '^7[0-9]{10}$'
+               then regexp_replace(c, '^(7)([0-9]{3})([0-9]{3})([0-9]{2})([0-9]{2})$', '+\1 \2 \3-\4-\5')
+           when c ~ '^[1-9][0-9]{7,14}$'
+               then '+' || c
+           else value
+           end
+from (select regexp_replace(regexp_replace(btrim(value), '[ ().-]', '', 'g'), '^\+', '') as c) compact
+$$ language sql immutable;
+
+create function pg_temp.normalize_plate(value text) returns text as
+$$
+select case
+           when c ~ '^[0-9]{4}[A-Z]{2}[1-7]$'
+               then regexp_replace(c, '^([0-9]{4})([A-Z]{2})([1-7])$', '\1 \2-\3')
+           when c ~ '^[ABEKMHOPCTYX][0-9]{3}[ABEKMHOPCTYX]{2}[0-9]{2,3}$'
+               then regexp_replace(c, '^([ABEKMHOPCTYX][0-9]{3}[ABEKMHOPCTYX]{2})([0-9]{2,3})$', '\1 \2')
+           when c ~ '^[A-Z]{2}[0-9]{4}[A-Z]{2}$'
+               then regexp_replace(c, '^([A-Z]{2})([0-9]{4})([A-Z]{2})$', '\1 \2 \3')
+           when c ~ '^[A-Z]{2,3}[0-9][0-9A-Z]{3,4}$'
+               then regexp_replace(c, '^([A-Z]{2,3})([0-9][0-9A-Z]{3,4})$', '\1 \2')
+           when c ~ '^[A-Z]{3}[0-9]{3}$'
+               then regexp_replace(c, '^([A-Z]{3})([0-9]{3})$', '\1 \2')
+           else value
+           end
+from (select translate(upper(regexp_replace(btrim(value), '[ ().-]', '', 'g')),
+                       'АВЕІКМНОРСТУХ', 'ABEIKMHOPCTYX') as c) compact
+$$ language sql immutable;
+
+-- of the rows with the same canonical form only the first one is changed
+update workers w
+set phone = n.phone
+from (select distinct on (phone) id, phone
+      from (select id, pg_temp.normalize_phone(phone) as phone from workers) p
+      order by phone, id) n
+where w.id = n.id
+  and w.phone <> n.phone
+  and not exists(select 1 from workers o where o.phone = n.phone);
+
+update vehicles v
+set plate_number = n.plate_number
+from (select distinct on (plate_number) id, plate_number
+      from (select id, pg_temp.normalize_plate(plate_number) as plate_number from vehicles) p
+      order by plate_number, id) n
+where v.id = n.id
+  and v.plate_number <> n.plate_number
+  and not exists(select 1 from vehicles o where o.plate_number = n.plate_number);
+
+-- documents keep a copy of the plate, they aren't unique
+update documents
+set car_id = pg_temp.normalize_plate(car_id)
+where car_id <> pg_temp.normalize_plate(car_id);
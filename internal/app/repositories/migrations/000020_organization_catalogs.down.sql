@@ -0,0 +1,10 @@
+delete
+from fuel_prices
+where organization_id <> (select min(id) from organizations);
+alter table fuel_prices
+    drop column organization_id,
+    add unique (fuel_code, effective_from);
+
+alter table vehicles
+    drop column organization_id,
+    add unique (plate_number);
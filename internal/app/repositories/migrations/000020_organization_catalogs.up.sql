@@ -0,0 +1,31 @@
+-- a vehicle belongs to the organization of its department, vehicles without a department go
+-- to the organization which used them first, the ones never used go to the first organization
+alter table vehicles
+    add column organization_id int references organizations (id) on delete cascade;
+update vehicles v
+set organization_id = coalesce(
+        (select dp.organization_id from departments dp where dp.id = v.department_id),
+        (select d.organization_id from documents d where d.vehicle_id = v.id order by d.id limit 1),
+        (select min(id) from organizations));
+alter table vehicles
+    alter column organization_id set not null,
+    drop constraint vehicles_plate_number_key,
+    add unique (organization_id, plate_number);
+
+-- the fuel catalog stays shared by all organizations and is kept by super-admins,
+-- every organization buys fuel at its own prices, so the prices entered so far are copied to each of them
+alter table fuel_prices
+    add column organization_id int references organizations (id) on delete cascade;
+insert into fuel_prices (fuel_code, price, effective_from, organization_id)
+select p.fuel_code, p.price, p.effective_from, o.id
+from fuel_prices p,
+     organizations o
+where p.organization_id is null
+  and o.id <> (select min(id) from organizations);
+update fuel_prices
+set organization_id = (select min(id) from organizations)
+where organization_id is null;
+alter table fuel_prices
+    alter column organization_id set not null,
+    drop constraint fuel_prices_fuel_code_effective_from_key,
+    add unique (organization_id, fuel_code, effective_from);
@@ -0,0 +1,1418 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/HeadHardener/tp_lab/internal/app/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkerInterface is a mock of WorkerInterface interface.
+type MockWorkerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerInterfaceMockRecorder
+}
+
+// MockWorkerInterfaceMockRecorder is the mock recorder for MockWorkerInterface.
+type MockWorkerInterfaceMockRecorder struct {
+	mock *MockWorkerInterface
+}
+
+// NewMockWorkerInterface creates a new mock instance.
+func NewMockWorkerInterface(ctrl *gomock.Controller) *MockWorkerInterface {
+	mock := &MockWorkerInterface{ctrl: ctrl}
+	mock.recorder = &MockWorkerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkerInterface) EXPECT() *MockWorkerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateWorker mocks base method.
+func (m *MockWorkerInterface) CreateWorker(worker models.Worker) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorker", worker)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorker indicates an expected call of CreateWorker.
+func (mr *MockWorkerInterfaceMockRecorder) CreateWorker(worker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorker", reflect.TypeOf((*MockWorkerInterface)(nil).CreateWorker), worker)
+}
+
+// Export mocks base method.
+func (m *MockWorkerInterface) Export(fn func(models.Worker) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockWorkerInterfaceMockRecorder) Export(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockWorkerInterface)(nil).Export), fn)
+}
+
+// GetAll mocks base method.
+func (m *MockWorkerInterface) GetAll() ([]models.Worker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Worker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWorkerInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWorkerInterface)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockWorkerInterface) GetByID(workerID int) (models.Worker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", workerID)
+	ret0, _ := ret[0].(models.Worker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWorkerInterfaceMockRecorder) GetByID(workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWorkerInterface)(nil).GetByID), workerID)
+}
+
+// GetWorker mocks base method.
+func (m *MockWorkerInterface) GetWorker(worker *models.Worker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorker", worker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWorker indicates an expected call of GetWorker.
+func (mr *MockWorkerInterfaceMockRecorder) GetWorker(worker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorker", reflect.TypeOf((*MockWorkerInterface)(nil).GetWorker), worker)
+}
+
+// Update mocks base method.
+func (m *MockWorkerInterface) Update(worker models.Worker) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", worker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkerInterfaceMockRecorder) Update(worker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkerInterface)(nil).Update), worker)
+}
+
+// MockGSMInterface is a mock of GSMInterface interface.
+type MockGSMInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGSMInterfaceMockRecorder
+}
+
+// MockGSMInterfaceMockRecorder is the mock recorder for MockGSMInterface.
+type MockGSMInterfaceMockRecorder struct {
+	mock *MockGSMInterface
+}
+
+// NewMockGSMInterface creates a new mock instance.
+func NewMockGSMInterface(ctrl *gomock.Controller) *MockGSMInterface {
+	mock := &MockGSMInterface{ctrl: ctrl}
+	mock.recorder = &MockGSMInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGSMInterface) EXPECT() *MockGSMInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MockGSMInterface) ChangeStatus(workerID int, transition models.Transition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", workerID, transition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockGSMInterfaceMockRecorder) ChangeStatus(workerID, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockGSMInterface)(nil).ChangeStatus), workerID, transition)
+}
+
+// Create mocks base method.
+func (m *MockGSMInterface) Create(workerID int, document models.Document) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", workerID, document)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGSMInterfaceMockRecorder) Create(workerID, document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGSMInterface)(nil).Create), workerID, document)
+}
+
+// CreateBatch mocks base method.
+func (m *MockGSMInterface) CreateBatch(workerID int, documents []models.Document) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", workerID, documents)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockGSMInterfaceMockRecorder) CreateBatch(workerID, documents interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockGSMInterface)(nil).CreateBatch), workerID, documents)
+}
+
+// Delete mocks base method.
+func (m *MockGSMInterface) Delete(docID, workerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", docID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGSMInterfaceMockRecorder) Delete(docID, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGSMInterface)(nil).Delete), docID, workerID)
+}
+
+// Export mocks base method.
+func (m *MockGSMInterface) Export(filter models.DocumentFilter, fn func(models.Document) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockGSMInterfaceMockRecorder) Export(filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockGSMInterface)(nil).Export), filter, fn)
+}
+
+// GetAll mocks base method.
+func (m *MockGSMInterface) GetAll(filter models.DocumentFilter) ([]models.Document, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", filter)
+	ret0, _ := ret[0].([]models.Document)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockGSMInterfaceMockRecorder) GetAll(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGSMInterface)(nil).GetAll), filter)
+}
+
+// GetAuthorID mocks base method.
+func (m *MockGSMInterface) GetAuthorID(docID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorID", docID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorID indicates an expected call of GetAuthorID.
+func (mr *MockGSMInterfaceMockRecorder) GetAuthorID(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorID", reflect.TypeOf((*MockGSMInterface)(nil).GetAuthorID), docID)
+}
+
+// GetByID mocks base method.
+func (m *MockGSMInterface) GetByID(docID int) (models.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", docID)
+	ret0, _ := ret[0].(models.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGSMInterfaceMockRecorder) GetByID(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGSMInterface)(nil).GetByID), docID)
+}
+
+// PreviousOdometer mocks base method.
+func (m *MockGSMInterface) PreviousOdometer(vehicleID, docID int, issueDate models.MyTime) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviousOdometer", vehicleID, docID, issueDate)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviousOdometer indicates an expected call of PreviousOdometer.
+func (mr *MockGSMInterfaceMockRecorder) PreviousOdometer(vehicleID, docID, issueDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviousOdometer", reflect.TypeOf((*MockGSMInterface)(nil).PreviousOdometer), vehicleID, docID, issueDate)
+}
+
+// Purge mocks base method.
+func (m *MockGSMInterface) Purge(deletedBefore time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", deletedBefore)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockGSMInterfaceMockRecorder) Purge(deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockGSMInterface)(nil).Purge), deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockGSMInterface) Restore(docID, workerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", docID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockGSMInterfaceMockRecorder) Restore(docID, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockGSMInterface)(nil).Restore), docID, workerID)
+}
+
+// Update mocks base method.
+func (m *MockGSMInterface) Update(workerID int, document models.Document) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", workerID, document)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGSMInterfaceMockRecorder) Update(workerID, document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGSMInterface)(nil).Update), workerID, document)
+}
+
+// MockRevisionInterface is a mock of RevisionInterface interface.
+type MockRevisionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionInterfaceMockRecorder
+}
+
+// MockRevisionInterfaceMockRecorder is the mock recorder for MockRevisionInterface.
+type MockRevisionInterfaceMockRecorder struct {
+	mock *MockRevisionInterface
+}
+
+// NewMockRevisionInterface creates a new mock instance.
+func NewMockRevisionInterface(ctrl *gomock.Controller) *MockRevisionInterface {
+	mock := &MockRevisionInterface{ctrl: ctrl}
+	mock.recorder = &MockRevisionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionInterface) EXPECT() *MockRevisionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockRevisionInterface) GetAll(docID int) ([]models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", docID)
+	ret0, _ := ret[0].([]models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRevisionInterfaceMockRecorder) GetAll(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRevisionInterface)(nil).GetAll), docID)
+}
+
+// GetByID mocks base method.
+func (m *MockRevisionInterface) GetByID(revisionID int) (models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", revisionID)
+	ret0, _ := ret[0].(models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRevisionInterfaceMockRecorder) GetByID(revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRevisionInterface)(nil).GetByID), revisionID)
+}
+
+// MockTransitionInterface is a mock of TransitionInterface interface.
+type MockTransitionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransitionInterfaceMockRecorder
+}
+
+// MockTransitionInterfaceMockRecorder is the mock recorder for MockTransitionInterface.
+type MockTransitionInterfaceMockRecorder struct {
+	mock *MockTransitionInterface
+}
+
+// NewMockTransitionInterface creates a new mock instance.
+func NewMockTransitionInterface(ctrl *gomock.Controller) *MockTransitionInterface {
+	mock := &MockTransitionInterface{ctrl: ctrl}
+	mock.recorder = &MockTransitionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransitionInterface) EXPECT() *MockTransitionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockTransitionInterface) GetAll(docID int) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", docID)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTransitionInterfaceMockRecorder) GetAll(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTransitionInterface)(nil).GetAll), docID)
+}
+
+// MockCommentInterface is a mock of CommentInterface interface.
+type MockCommentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentInterfaceMockRecorder
+}
+
+// MockCommentInterfaceMockRecorder is the mock recorder for MockCommentInterface.
+type MockCommentInterfaceMockRecorder struct {
+	mock *MockCommentInterface
+}
+
+// NewMockCommentInterface creates a new mock instance.
+func NewMockCommentInterface(ctrl *gomock.Controller) *MockCommentInterface {
+	mock := &MockCommentInterface{ctrl: ctrl}
+	mock.recorder = &MockCommentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentInterface) EXPECT() *MockCommentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentInterface) Create(comment models.Comment) (models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", comment)
+	ret0, _ := ret[0].(models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentInterfaceMockRecorder) Create(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentInterface)(nil).Create), comment)
+}
+
+// Delete mocks base method.
+func (m *MockCommentInterface) Delete(commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentInterfaceMockRecorder) Delete(commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentInterface)(nil).Delete), commentID)
+}
+
+// GetAll mocks base method.
+func (m *MockCommentInterface) GetAll(docID int) ([]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", docID)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCommentInterfaceMockRecorder) GetAll(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCommentInterface)(nil).GetAll), docID)
+}
+
+// GetByID mocks base method.
+func (m *MockCommentInterface) GetByID(commentID int) (models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", commentID)
+	ret0, _ := ret[0].(models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommentInterfaceMockRecorder) GetByID(commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommentInterface)(nil).GetByID), commentID)
+}
+
+// Update mocks base method.
+func (m *MockCommentInterface) Update(commentID int, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", commentID, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentInterfaceMockRecorder) Update(commentID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentInterface)(nil).Update), commentID, body)
+}
+
+// MockAttachmentInterface is a mock of AttachmentInterface interface.
+type MockAttachmentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentInterfaceMockRecorder
+}
+
+// MockAttachmentInterfaceMockRecorder is the mock recorder for MockAttachmentInterface.
+type MockAttachmentInterfaceMockRecorder struct {
+	mock *MockAttachmentInterface
+}
+
+// NewMockAttachmentInterface creates a new mock instance.
+func NewMockAttachmentInterface(ctrl *gomock.Controller) *MockAttachmentInterface {
+	mock := &MockAttachmentInterface{ctrl: ctrl}
+	mock.recorder = &MockAttachmentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentInterface) EXPECT() *MockAttachmentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentInterface) Create(attachment models.Attachment) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentInterfaceMockRecorder) Create(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentInterface)(nil).Create), attachment)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentInterface) Delete(attachmentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentInterfaceMockRecorder) Delete(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentInterface)(nil).Delete), attachmentID)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachmentInterface) GetAll(docID int) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", docID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentInterfaceMockRecorder) GetAll(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachmentInterface)(nil).GetAll), docID)
+}
+
+// GetByID mocks base method.
+func (m *MockAttachmentInterface) GetByID(attachmentID int) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", attachmentID)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAttachmentInterfaceMockRecorder) GetByID(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAttachmentInterface)(nil).GetByID), attachmentID)
+}
+
+// MockVehicleInterface is a mock of VehicleInterface interface.
+type MockVehicleInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleInterfaceMockRecorder
+}
+
+// MockVehicleInterfaceMockRecorder is the mock recorder for MockVehicleInterface.
+type MockVehicleInterfaceMockRecorder struct {
+	mock *MockVehicleInterface
+}
+
+// NewMockVehicleInterface creates a new mock instance.
+func NewMockVehicleInterface(ctrl *gomock.Controller) *MockVehicleInterface {
+	mock := &MockVehicleInterface{ctrl: ctrl}
+	mock.recorder = &MockVehicleInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleInterface) EXPECT() *MockVehicleInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVehicleInterface) Create(vehicle models.Vehicle) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleInterfaceMockRecorder) Create(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicleInterface)(nil).Create), vehicle)
+}
+
+// Delete mocks base method.
+func (m *MockVehicleInterface) Delete(vehicleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", vehicleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleInterfaceMockRecorder) Delete(vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleInterface)(nil).Delete), vehicleID)
+}
+
+// GetAll mocks base method.
+func (m *MockVehicleInterface) GetAll(onlyActive bool) ([]models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", onlyActive)
+	ret0, _ := ret[0].([]models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockVehicleInterfaceMockRecorder) GetAll(onlyActive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockVehicleInterface)(nil).GetAll), onlyActive)
+}
+
+// GetByID mocks base method.
+func (m *MockVehicleInterface) GetByID(vehicleID int) (models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", vehicleID)
+	ret0, _ := ret[0].(models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVehicleInterfaceMockRecorder) GetByID(vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicleInterface)(nil).GetByID), vehicleID)
+}
+
+// Update mocks base method.
+func (m *MockVehicleInterface) Update(vehicle models.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleInterfaceMockRecorder) Update(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleInterface)(nil).Update), vehicle)
+}
+
+// MockDepartmentInterface is a mock of DepartmentInterface interface.
+type MockDepartmentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentInterfaceMockRecorder
+}
+
+// MockDepartmentInterfaceMockRecorder is the mock recorder for MockDepartmentInterface.
+type MockDepartmentInterfaceMockRecorder struct {
+	mock *MockDepartmentInterface
+}
+
+// NewMockDepartmentInterface creates a new mock instance.
+func NewMockDepartmentInterface(ctrl *gomock.Controller) *MockDepartmentInterface {
+	mock := &MockDepartmentInterface{ctrl: ctrl}
+	mock.recorder = &MockDepartmentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartmentInterface) EXPECT() *MockDepartmentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDepartmentInterface) Create(department models.Department) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", department)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDepartmentInterfaceMockRecorder) Create(department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDepartmentInterface)(nil).Create), department)
+}
+
+// GetAll mocks base method.
+func (m *MockDepartmentInterface) GetAll() ([]models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDepartmentInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDepartmentInterface)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockDepartmentInterface) GetByID(departmentID int) (models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", departmentID)
+	ret0, _ := ret[0].(models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDepartmentInterfaceMockRecorder) GetByID(departmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDepartmentInterface)(nil).GetByID), departmentID)
+}
+
+// MockLimitInterface is a mock of LimitInterface interface.
+type MockLimitInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitInterfaceMockRecorder
+}
+
+// MockLimitInterfaceMockRecorder is the mock recorder for MockLimitInterface.
+type MockLimitInterfaceMockRecorder struct {
+	mock *MockLimitInterface
+}
+
+// NewMockLimitInterface creates a new mock instance.
+func NewMockLimitInterface(ctrl *gomock.Controller) *MockLimitInterface {
+	mock := &MockLimitInterface{ctrl: ctrl}
+	mock.recorder = &MockLimitInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitInterface) EXPECT() *MockLimitInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLimitInterface) Create(limit models.Limit) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLimitInterfaceMockRecorder) Create(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLimitInterface)(nil).Create), limit)
+}
+
+// Delete mocks base method.
+func (m *MockLimitInterface) Delete(limitID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", limitID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLimitInterfaceMockRecorder) Delete(limitID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLimitInterface)(nil).Delete), limitID)
+}
+
+// GetAll mocks base method.
+func (m *MockLimitInterface) GetAll() ([]models.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLimitInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLimitInterface)(nil).GetAll))
+}
+
+// GetForVehicle mocks base method.
+func (m *MockLimitInterface) GetForVehicle(vehicleID int) ([]models.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForVehicle", vehicleID)
+	ret0, _ := ret[0].([]models.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForVehicle indicates an expected call of GetForVehicle.
+func (mr *MockLimitInterfaceMockRecorder) GetForVehicle(vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForVehicle", reflect.TypeOf((*MockLimitInterface)(nil).GetForVehicle), vehicleID)
+}
+
+// Usage mocks base method.
+func (m *MockLimitInterface) Usage(limit models.Limit, from, to time.Time, excludedDocID int) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", limit, from, to, excludedDocID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockLimitInterfaceMockRecorder) Usage(limit, from, to, excludedDocID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockLimitInterface)(nil).Usage), limit, from, to, excludedDocID)
+}
+
+// MockFuelInterface is a mock of FuelInterface interface.
+type MockFuelInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFuelInterfaceMockRecorder
+}
+
+// MockFuelInterfaceMockRecorder is the mock recorder for MockFuelInterface.
+type MockFuelInterfaceMockRecorder struct {
+	mock *MockFuelInterface
+}
+
+// NewMockFuelInterface creates a new mock instance.
+func NewMockFuelInterface(ctrl *gomock.Controller) *MockFuelInterface {
+	mock := &MockFuelInterface{ctrl: ctrl}
+	mock.recorder = &MockFuelInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFuelInterface) EXPECT() *MockFuelInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFuelInterface) Create(fuel models.Fuel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", fuel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFuelInterfaceMockRecorder) Create(fuel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFuelInterface)(nil).Create), fuel)
+}
+
+// Delete mocks base method.
+func (m *MockFuelInterface) Delete(code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFuelInterfaceMockRecorder) Delete(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFuelInterface)(nil).Delete), code)
+}
+
+// GetAll mocks base method.
+func (m *MockFuelInterface) GetAll(onlyActive bool) ([]models.Fuel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", onlyActive)
+	ret0, _ := ret[0].([]models.Fuel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFuelInterfaceMockRecorder) GetAll(onlyActive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFuelInterface)(nil).GetAll), onlyActive)
+}
+
+// GetByCode mocks base method.
+func (m *MockFuelInterface) GetByCode(code string) (models.Fuel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", code)
+	ret0, _ := ret[0].(models.Fuel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockFuelInterfaceMockRecorder) GetByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockFuelInterface)(nil).GetByCode), code)
+}
+
+// GetPrices mocks base method.
+func (m *MockFuelInterface) GetPrices(code string) ([]models.FuelPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", code)
+	ret0, _ := ret[0].([]models.FuelPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockFuelInterfaceMockRecorder) GetPrices(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockFuelInterface)(nil).GetPrices), code)
+}
+
+// SetPrice mocks base method.
+func (m *MockFuelInterface) SetPrice(price models.FuelPrice) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", price)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockFuelInterfaceMockRecorder) SetPrice(price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockFuelInterface)(nil).SetPrice), price)
+}
+
+// Update mocks base method.
+func (m *MockFuelInterface) Update(fuel models.Fuel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", fuel)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFuelInterfaceMockRecorder) Update(fuel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFuelInterface)(nil).Update), fuel)
+}
+
+// MockStockInterface is a mock of StockInterface interface.
+type MockStockInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockInterfaceMockRecorder
+}
+
+// MockStockInterfaceMockRecorder is the mock recorder for MockStockInterface.
+type MockStockInterfaceMockRecorder struct {
+	mock *MockStockInterface
+}
+
+// NewMockStockInterface creates a new mock instance.
+func NewMockStockInterface(ctrl *gomock.Controller) *MockStockInterface {
+	mock := &MockStockInterface{ctrl: ctrl}
+	mock.recorder = &MockStockInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockInterface) EXPECT() *MockStockInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddReceipt mocks base method.
+func (m *MockStockInterface) AddReceipt(workerID int, movement models.StockMovement) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReceipt", workerID, movement)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReceipt indicates an expected call of AddReceipt.
+func (mr *MockStockInterfaceMockRecorder) AddReceipt(workerID, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReceipt", reflect.TypeOf((*MockStockInterface)(nil).AddReceipt), workerID, movement)
+}
+
+// GetBalances mocks base method.
+func (m *MockStockInterface) GetBalances(asOf models.MyTime) ([]models.StockBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", asOf)
+	ret0, _ := ret[0].([]models.StockBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockStockInterfaceMockRecorder) GetBalances(asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockStockInterface)(nil).GetBalances), asOf)
+}
+
+// GetMovements mocks base method.
+func (m *MockStockInterface) GetMovements(filter models.StockFilter) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", filter)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockStockInterfaceMockRecorder) GetMovements(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockStockInterface)(nil).GetMovements), filter)
+}
+
+// MockWaybillInterface is a mock of WaybillInterface interface.
+type MockWaybillInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaybillInterfaceMockRecorder
+}
+
+// MockWaybillInterfaceMockRecorder is the mock recorder for MockWaybillInterface.
+type MockWaybillInterfaceMockRecorder struct {
+	mock *MockWaybillInterface
+}
+
+// NewMockWaybillInterface creates a new mock instance.
+func NewMockWaybillInterface(ctrl *gomock.Controller) *MockWaybillInterface {
+	mock := &MockWaybillInterface{ctrl: ctrl}
+	mock.recorder = &MockWaybillInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaybillInterface) EXPECT() *MockWaybillInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetSequences mocks base method.
+func (m *MockWaybillInterface) GetSequences() ([]models.WaybillSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequences")
+	ret0, _ := ret[0].([]models.WaybillSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequences indicates an expected call of GetSequences.
+func (mr *MockWaybillInterfaceMockRecorder) GetSequences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequences", reflect.TypeOf((*MockWaybillInterface)(nil).GetSequences))
+}
+
+// ResetSequence mocks base method.
+func (m *MockWaybillInterface) ResetSequence(sequence models.WaybillSequence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSequence", sequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSequence indicates an expected call of ResetSequence.
+func (mr *MockWaybillInterfaceMockRecorder) ResetSequence(sequence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSequence", reflect.TypeOf((*MockWaybillInterface)(nil).ResetSequence), sequence)
+}
+
+// MockPeriodInterface is a mock of PeriodInterface interface.
+type MockPeriodInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeriodInterfaceMockRecorder
+}
+
+// MockPeriodInterfaceMockRecorder is the mock recorder for MockPeriodInterface.
+type MockPeriodInterfaceMockRecorder struct {
+	mock *MockPeriodInterface
+}
+
+// NewMockPeriodInterface creates a new mock instance.
+func NewMockPeriodInterface(ctrl *gomock.Controller) *MockPeriodInterface {
+	mock := &MockPeriodInterface{ctrl: ctrl}
+	mock.recorder = &MockPeriodInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeriodInterface) EXPECT() *MockPeriodInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPeriodInterface) Close(workerID int, period time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", workerID, period, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPeriodInterfaceMockRecorder) Close(workerID, period, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPeriodInterface)(nil).Close), workerID, period, reason)
+}
+
+// GetAll mocks base method.
+func (m *MockPeriodInterface) GetAll() ([]models.PeriodClosing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.PeriodClosing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPeriodInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPeriodInterface)(nil).GetAll))
+}
+
+// IsClosed mocks base method.
+func (m *MockPeriodInterface) IsClosed(period time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClosed", period)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsClosed indicates an expected call of IsClosed.
+func (mr *MockPeriodInterfaceMockRecorder) IsClosed(period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClosed", reflect.TypeOf((*MockPeriodInterface)(nil).IsClosed), period)
+}
+
+// Reopen mocks base method.
+func (m *MockPeriodInterface) Reopen(workerID int, period time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", workerID, period, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockPeriodInterfaceMockRecorder) Reopen(workerID, period, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockPeriodInterface)(nil).Reopen), workerID, period, reason)
+}
+
+// MockReportInterface is a mock of ReportInterface interface.
+type MockReportInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportInterfaceMockRecorder
+}
+
+// MockReportInterfaceMockRecorder is the mock recorder for MockReportInterface.
+type MockReportInterfaceMockRecorder struct {
+	mock *MockReportInterface
+}
+
+// NewMockReportInterface creates a new mock instance.
+func NewMockReportInterface(ctrl *gomock.Controller) *MockReportInterface {
+	mock := &MockReportInterface{ctrl: ctrl}
+	mock.recorder = &MockReportInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportInterface) EXPECT() *MockReportInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FuelConsumption mocks base method.
+func (m *MockReportInterface) FuelConsumption(filter models.FuelReportFilter) ([]models.FuelReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuelConsumption", filter)
+	ret0, _ := ret[0].([]models.FuelReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FuelConsumption indicates an expected call of FuelConsumption.
+func (mr *MockReportInterfaceMockRecorder) FuelConsumption(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuelConsumption", reflect.TypeOf((*MockReportInterface)(nil).FuelConsumption), filter)
+}
+
+// MockTagInterface is a mock of TagInterface interface.
+type MockTagInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagInterfaceMockRecorder
+}
+
+// MockTagInterfaceMockRecorder is the mock recorder for MockTagInterface.
+type MockTagInterfaceMockRecorder struct {
+	mock *MockTagInterface
+}
+
+// NewMockTagInterface creates a new mock instance.
+func NewMockTagInterface(ctrl *gomock.Controller) *MockTagInterface {
+	mock := &MockTagInterface{ctrl: ctrl}
+	mock.recorder = &MockTagInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagInterface) EXPECT() *MockTagInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTagInterface) Create(tag models.Tag) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagInterfaceMockRecorder) Create(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTagInterface)(nil).Create), tag)
+}
+
+// Delete mocks base method.
+func (m *MockTagInterface) Delete(tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagInterfaceMockRecorder) Delete(tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagInterface)(nil).Delete), tagID)
+}
+
+// GetAll mocks base method.
+func (m *MockTagInterface) GetAll() ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTagInterface)(nil).GetAll))
+}
+
+// MockCustomFieldInterface is a mock of CustomFieldInterface interface.
+type MockCustomFieldInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldInterfaceMockRecorder
+}
+
+// MockCustomFieldInterfaceMockRecorder is the mock recorder for MockCustomFieldInterface.
+type MockCustomFieldInterfaceMockRecorder struct {
+	mock *MockCustomFieldInterface
+}
+
+// NewMockCustomFieldInterface creates a new mock instance.
+func NewMockCustomFieldInterface(ctrl *gomock.Controller) *MockCustomFieldInterface {
+	mock := &MockCustomFieldInterface{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFieldInterface) EXPECT() *MockCustomFieldInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomFieldInterface) Create(field models.CustomField) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", field)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldInterfaceMockRecorder) Create(field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomFieldInterface)(nil).Create), field)
+}
+
+// Delete mocks base method.
+func (m *MockCustomFieldInterface) Delete(fieldID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldInterfaceMockRecorder) Delete(fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomFieldInterface)(nil).Delete), fieldID)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomFieldInterface) GetAll() ([]models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomFieldInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomFieldInterface)(nil).GetAll))
+}
+
+// MockOrganizationInterface is a mock of OrganizationInterface interface.
+type MockOrganizationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationInterfaceMockRecorder
+}
+
+// MockOrganizationInterfaceMockRecorder is the mock recorder for MockOrganizationInterface.
+type MockOrganizationInterfaceMockRecorder struct {
+	mock *MockOrganizationInterface
+}
+
+// NewMockOrganizationInterface creates a new mock instance.
+func NewMockOrganizationInterface(ctrl *gomock.Controller) *MockOrganizationInterface {
+	mock := &MockOrganizationInterface{ctrl: ctrl}
+	mock.recorder = &MockOrganizationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationInterface) EXPECT() *MockOrganizationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizationInterface) Create(organization models.Organization) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", organization)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationInterfaceMockRecorder) Create(organization interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationInterface)(nil).Create), organization)
+}
+
+// GetAll mocks base method.
+func (m *MockOrganizationInterface) GetAll() ([]models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockOrganizationInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrganizationInterface)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockOrganizationInterface) GetByID(orgID int) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", orgID)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrganizationInterfaceMockRecorder) GetByID(orgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrganizationInterface)(nil).GetByID), orgID)
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type OrganizationRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+func (r *OrganizationRepository) Create(organization models.Organization) (int, error) {
+	var id int
+	query := fmt.Sprintf("insert into %s (name) values ($1) returning id", organizationsTable)
+
+	if err := r.db.QueryRow(query, organization.Name).Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrOrganizationExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *OrganizationRepository) GetAll() ([]models.Organization, error) {
+	organizations := []models.Organization{}
+	query := fmt.Sprintf("select * from %s order by name", organizationsTable)
+
+	if err := r.db.Select(&organizations, query); err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (r *OrganizationRepository) GetByID(orgID int) (models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("select * from %s where id=$1", organizationsTable)
+
+	if err := r.db.Get(&organization, query, orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Organization{}, models.ErrOrganizationNotFound
+		}
+		return models.Organization{}, err
+	}
+
+	return organization, nil
+}
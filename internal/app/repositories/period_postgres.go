@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type PeriodRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewPeriodRepository(db *sqlx.DB, orgID int) *PeriodRepository {
+	return &PeriodRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+// GetAll returns every closing including the reopened ones, the latest periods go first.
+func (r *PeriodRepository) GetAll() ([]models.PeriodClosing, error) {
+	closings := []models.PeriodClosing{}
+	query := fmt.Sprintf(`select id, to_char(period, 'YYYY-MM') as period, reason, closed_by, closed_at,
+								reopen_reason, reopened_by, reopened_at
+								from %s where organization_id=$1
+								order by period desc, id desc`, periodClosingsTable)
+
+	if err := r.db.Select(&closings, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return closings, nil
+}
+
+func (r *PeriodRepository) IsClosed(period time.Time) (bool, error) {
+	return isPeriodClosed(r.db, r.orgID, period)
+}
+
+// Close waits for the transactions changing documents of the period, see checkPeriod.
+func (r *PeriodRepository) Close(workerID int, period time.Time, reason string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := lockPeriod(tx, r.orgID, period, true); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("insert into %s (period, reason, closed_by, organization_id) values ($1, $2, $3, $4)",
+		periodClosingsTable)
+
+	if _, err := tx.Exec(query, period, reason, workerID, r.orgID); err != nil {
+		tx.Rollback()
+		if isViolation(err, uniqueViolation) {
+			return models.ErrPeriodAlreadyClosed
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PeriodRepository) Reopen(workerID int, period time.Time, reason string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := lockPeriod(tx, r.orgID, period, true); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf(`update %s set reopen_reason=$1, reopened_by=$2, reopened_at=now()
+								where period=$3 and organization_id=$4 and reopened_at is null`, periodClosingsTable)
+
+	result, err := tx.Exec(query, reason, workerID, period, r.orgID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if affected == 0 {
+		tx.Rollback()
+		return models.ErrPeriodNotClosed
+	}
+
+	return tx.Commit()
+}
+
+// checkPeriod refuses changes of documents issued in a closed period, the period stays
+// as it is until the transaction ends, so it can't be closed while the changes are being made.
+func checkPeriod(tx *sqlx.Tx, orgID int, issueDate models.MyTime) error {
+	period := models.PeriodOf(issueDate)
+	if err := lockPeriod(tx, orgID, period, false); err != nil {
+		return err
+	}
+
+	closed, err := isPeriodClosed(tx, orgID, period)
+	if err != nil {
+		return err
+	}
+
+	if closed {
+		return models.ErrPeriodClosed
+	}
+
+	return nil
+}
+
+// lockPeriod takes the lock of the period until the transaction ends, documents are changed
+// under the shared lock and the period is closed or reopened under the exclusive one.
+func lockPeriod(tx *sqlx.Tx, orgID int, period time.Time, exclusive bool) error {
+	lockQuery := "select pg_advisory_xact_lock_shared($1, hashtext($2))"
+	if exclusive {
+		lockQuery = "select pg_advisory_xact_lock($1, hashtext($2))"
+	}
+
+	_, err := tx.Exec(lockQuery, orgID, "period "+period.Format("2006-01"))
+	return err
+}
+
+func isPeriodClosed(db getter, orgID int, period time.Time) (bool, error) {
+	var closed bool
+	query := fmt.Sprintf(`select exists(select 1 from %s
+								where period=$1 and organization_id=$2 and reopened_at is null)`, periodClosingsTable)
+
+	if err := db.Get(&closed, query, period, orgID); err != nil {
+		return false, err
+	}
+
+	return closed, nil
+}
@@ -1,16 +1,42 @@
 package repositories
 
 import (
+	"errors"
 	"fmt"
 	"github.com/HeadHardener/tp_lab/configs"
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 )
 
 const (
-	workersTable     = "workers"
-	docsTable        = "documents"
-	workersDocsTable = "workers_documents"
+	workersTable          = "workers"
+	docsTable             = "documents"
+	workersDocsTable      = "workers_documents"
+	vehiclesTable         = "vehicles"
+	revisionsTable        = "document_revisions"
+	transitionsTable      = "document_transitions"
+	fuelsTable            = "fuels"
+	fuelPricesTable       = "fuel_prices"
+	stockTable            = "stock_movements"
+	waybillSequencesTable = "waybill_sequences"
+	attachmentsTable      = "document_attachments"
+	periodClosingsTable   = "period_closings"
+	departmentsTable      = "departments"
+	limitsTable           = "fuel_limits"
+	organizationsTable    = "organizations"
+	commentsTable         = "document_comments"
+	tagsTable             = "tags"
+	customFieldsTable     = "custom_fields"
+)
+
+// noOrganization scopes repositories to an organization which doesn't exist
+const noOrganization = 0
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
 )
 
 func NewDB(conf configs.DBConfig) (*sqlx.DB, error) {
@@ -27,3 +53,8 @@ func NewDB(conf configs.DBConfig) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+func isViolation(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// testDB returns connection to a new schema of the database given by TEST_DB_DSN with
+// the migrations applied, the schema is dropped once the test is over.
+func testDB(t *testing.T) *sqlx.DB {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN isn't set")
+	}
+
+	admin, err := sqlx.Connect("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("create schema " + schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Exec("drop schema " + schema + " cascade") })
+
+	db, err := sqlx.Connect("pgx", dsn+" search_path="+schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrations, _ := filepath.Glob("migrations/*.up.sql")
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		script, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.Exec(string(script)); err != nil {
+			t.Fatalf("%s: %v", migration, err)
+		}
+	}
+
+	return db
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type ReportRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewReportRepository(db *sqlx.DB, orgID int) *ReportRepository {
+	return &ReportRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+// FuelConsumption totals gas amount of approved documents issued in the filter range
+// grouped by the filter groups in the order they were requested, like the stock ledger
+// the report doesn't count documents which may still be changed or were rejected.
+func (r *ReportRepository) FuelConsumption(filter models.FuelReportFilter) ([]models.FuelReportRow, error) {
+	var columns, groups []string
+
+	for _, group := range filter.GroupBy {
+		switch group {
+		case models.GroupByVehicle:
+			columns = append(columns, "d.vehicle_id", "v.plate_number as car_id")
+			groups = append(groups, "d.vehicle_id", "v.plate_number")
+		case models.GroupByDriver:
+			columns = append(columns, "d.driver_name")
+			groups = append(groups, "d.driver_name")
+		case models.GroupByGasType:
+			columns = append(columns, "d.gas_type")
+			groups = append(groups, "d.gas_type")
+		case models.GroupByPeriod:
+			period := fmt.Sprintf("date_trunc('%s', d.issue_date)::date", filter.Period)
+			columns = append(columns, period+" as period")
+			groups = append(groups, period)
+		}
+	}
+
+	query := fmt.Sprintf(`select %s, count(*) as documents, sum(d.gas_amount) as gas_amount,
+       							coalesce(sum(%s), 0) as cost
+							from %s d inner join %s v on v.id=d.vehicle_id
+							where d.issue_date between $1 and $2 and d.deleted_at is null and d.organization_id=$3
+							  and d.status=$4
+							group by %s
+							order by %s`,
+		strings.Join(columns, ", "), documentCost, docsTable, vehiclesTable,
+		strings.Join(groups, ", "), strings.Join(groups, ", "))
+
+	rows := []models.FuelReportRow{}
+	if err := r.db.Select(&rows, query, filter.From, filter.To, r.orgID, models.StatusApproved); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
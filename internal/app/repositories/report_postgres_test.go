@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReportRepository_FuelConsumption(t *testing.T) {
+	db := testDB(t)
+
+	var orgID, vehicleID int
+	if err := db.Get(&orgID, "insert into organizations (name) values ('test') returning id"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("insert into fuels (code, name) values ('95', 'AI-95')"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Get(&vehicleID, `insert into vehicles (plate_number, model, fuel_type, organization_id)
+									values ('1111 AA-1', 'MAZ', '95', $1) returning id`, orgID); err != nil {
+		t.Fatal(err)
+	}
+
+	documents := []struct {
+		waybill   int
+		gasAmount int
+		status    string
+		deleted   bool
+	}{
+		{waybill: 1001, gasAmount: 10, status: models.StatusApproved},
+		{waybill: 1002, gasAmount: 20, status: models.StatusApproved},
+		{waybill: 1003, gasAmount: 40, status: models.StatusRejected},
+		{waybill: 1004, gasAmount: 80, status: models.StatusDraft},
+		{waybill: 1005, gasAmount: 160, status: models.StatusSubmitted},
+		{waybill: 1006, gasAmount: 320, status: models.StatusApproved, deleted: true},
+	}
+	for _, d := range documents {
+		_, err := db.Exec(`insert into documents (car, car_id, waybill, driver_name, gas_amount, gas_type, issue_date,
+							vehicle_id, organization_id, status, deleted_at)
+							values ('MAZ', '1111 AA-1', $1, 'test_name', $2, '95', '2023-01-10', $3, $4, $5,
+							        case when $6 then now() end)`,
+			d.waybill, d.gasAmount, vehicleID, orgID, d.status, d.deleted)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from, _ := time.Parse("2006-01-02", "2023-01-01")
+	to, _ := time.Parse("2006-01-02", "2023-01-31")
+
+	rows, err := NewReportRepository(db, orgID).FuelConsumption(models.FuelReportFilter{
+		From:    models.MyTime(from),
+		To:      models.MyTime(to),
+		GroupBy: []string{models.GroupByGasType},
+	})
+
+	gasType := "95"
+	assert.NoError(t, err)
+	assert.Equal(t, []models.FuelReportRow{{GasType: &gasType, Documents: 2, GasAmount: 30}}, rows)
+}
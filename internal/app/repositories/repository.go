@@ -1,35 +1,192 @@
 package repositories
 
 import (
+	"github.com/HeadHardener/tp_lab/configs"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/jmoiron/sqlx"
+	"time"
 )
 
+//go:generate mockgen -source=repository.go -destination=mocks/mock.go
+
 type WorkerInterface interface {
 	CreateWorker(worker models.Worker) (int, error)
 	GetWorker(worker *models.Worker) error
 	GetAll() ([]models.Worker, error)
+	Export(fn func(models.Worker) error) error
 	GetByID(workerID int) (models.Worker, error)
 	Update(worker models.Worker) error
 }
 
 type GSMInterface interface {
 	Create(workerID int, document models.Document) (int, error)
-	GetAll() ([]models.Document, error)
+	CreateBatch(workerID int, documents []models.Document) ([]int, error)
+	GetAll(filter models.DocumentFilter) ([]models.Document, int, error)
+	Export(filter models.DocumentFilter, fn func(models.Document) error) error
 	GetByID(docID int) (models.Document, error)
-	GetAllWithWorkerID(workerID int) ([]models.Document, error)
-	Update(document models.Document) error
-	Delete(docID int) error
+	GetAuthorID(docID int) (int, error)
+	PreviousOdometer(vehicleID, docID int, issueDate models.MyTime) (*int, error)
+	Update(workerID int, document models.Document) error
+	ChangeStatus(workerID int, transition models.Transition) error
+	Delete(docID, workerID int) error
+	Restore(docID, workerID int) error
+	Purge(deletedBefore time.Time) ([]int, error)
+}
+
+type RevisionInterface interface {
+	GetAll(docID int) ([]models.Revision, error)
+	GetByID(revisionID int) (models.Revision, error)
+}
+
+type TransitionInterface interface {
+	GetAll(docID int) ([]models.Transition, error)
+}
+
+type CommentInterface interface {
+	Create(comment models.Comment) (models.Comment, error)
+	GetAll(docID int) ([]models.Comment, error)
+	GetByID(commentID int) (models.Comment, error)
+	Update(commentID int, body string) error
+	Delete(commentID int) error
+}
+
+type AttachmentInterface interface {
+	Create(attachment models.Attachment) (models.Attachment, error)
+	GetAll(docID int) ([]models.Attachment, error)
+	GetByID(attachmentID int) (models.Attachment, error)
+	Delete(attachmentID int) error
+}
+
+type VehicleInterface interface {
+	Create(vehicle models.Vehicle) (int, error)
+	GetAll(onlyActive bool) ([]models.Vehicle, error)
+	GetByID(vehicleID int) (models.Vehicle, error)
+	Update(vehicle models.Vehicle) error
+	Delete(vehicleID int) error
+}
+
+type DepartmentInterface interface {
+	Create(department models.Department) (int, error)
+	GetAll() ([]models.Department, error)
+	GetByID(departmentID int) (models.Department, error)
+}
+
+type LimitInterface interface {
+	Create(limit models.Limit) (int, error)
+	GetAll() ([]models.Limit, error)
+	GetForVehicle(vehicleID int) ([]models.Limit, error)
+	Delete(limitID int) error
+	Usage(limit models.Limit, from, to time.Time, excludedDocID int) (float64, error)
+}
+
+type FuelInterface interface {
+	Create(fuel models.Fuel) error
+	GetAll(onlyActive bool) ([]models.Fuel, error)
+	GetByCode(code string) (models.Fuel, error)
+	Update(fuel models.Fuel) error
+	Delete(code string) error
+	GetPrices(code string) ([]models.FuelPrice, error)
+	SetPrice(price models.FuelPrice) (int, error)
+}
+
+type StockInterface interface {
+	AddReceipt(workerID int, movement models.StockMovement) (int, error)
+	GetMovements(filter models.StockFilter) ([]models.StockMovement, error)
+	GetBalances(asOf models.MyTime) ([]models.StockBalance, error)
 }
 
+type WaybillInterface interface {
+	GetSequences() ([]models.WaybillSequence, error)
+	ResetSequence(sequence models.WaybillSequence) error
+}
+
+type PeriodInterface interface {
+	GetAll() ([]models.PeriodClosing, error)
+	IsClosed(period time.Time) (bool, error)
+	Close(workerID int, period time.Time, reason string) error
+	Reopen(workerID int, period time.Time, reason string) error
+}
+
+type ReportInterface interface {
+	FuelConsumption(filter models.FuelReportFilter) ([]models.FuelReportRow, error)
+}
+
+type TagInterface interface {
+	Create(tag models.Tag) (int, error)
+	GetAll() ([]models.Tag, error)
+	Delete(tagID int) error
+}
+
+type CustomFieldInterface interface {
+	Create(field models.CustomField) (int, error)
+	GetAll() ([]models.CustomField, error)
+	Delete(fieldID int) error
+}
+
+type OrganizationInterface interface {
+	Create(organization models.Organization) (int, error)
+	GetAll() ([]models.Organization, error)
+	GetByID(orgID int) (models.Organization, error)
+}
+
+// Repository is scoped to a single organization, every query of the tenant repositories filters
+// by it, so data of other organizations can't be read or changed. The repository returned by
+// NewRepository belongs to no organization and sees no tenant data at all, Tenant has to be used.
 type Repository struct {
+	OrganizationInterface
 	WorkerInterface
 	GSMInterface
+	VehicleInterface
+	DepartmentInterface
+	LimitInterface
+	FuelInterface
+	StockInterface
+	WaybillInterface
+	PeriodInterface
+	ReportInterface
+	RevisionInterface
+	TransitionInterface
+	AttachmentInterface
+	CommentInterface
+	TagInterface
+	CustomFieldInterface
+
+	db   *sqlx.DB
+	conf *configs.RepositoryConfig
 }
 
-func NewRepository(db *sqlx.DB) *Repository {
+func NewRepository(db *sqlx.DB, conf *configs.RepositoryConfig) *Repository {
+	return newRepository(db, conf, noOrganization)
+}
+
+// Tenant returns repositories which read and write only the data of the organization.
+func (r *Repository) Tenant(orgID int) *Repository {
+	return newRepository(r.db, r.conf, orgID)
+}
+
+func newRepository(db *sqlx.DB, conf *configs.RepositoryConfig, orgID int) *Repository {
+	refuseStockShortage := conf.StockShortagePolicy == configs.StockShortageRefuse
+	refuseLimitExceeded := conf.LimitPolicy == configs.LimitRefuse
+
 	return &Repository{
-		WorkerInterface: NewWorkerRepository(db),
-		GSMInterface:    NewGSMRepository(db),
+		OrganizationInterface: NewOrganizationRepository(db),
+		WorkerInterface:       NewWorkerRepository(db, orgID),
+		GSMInterface:          NewGSMRepository(db, orgID, refuseStockShortage, refuseLimitExceeded),
+		VehicleInterface:      NewVehicleRepository(db, orgID),
+		DepartmentInterface:   NewDepartmentRepository(db, orgID),
+		LimitInterface:        NewLimitRepository(db, orgID),
+		FuelInterface:         NewFuelRepository(db, orgID),
+		StockInterface:        NewStockRepository(db, orgID),
+		WaybillInterface:      NewWaybillRepository(db, orgID),
+		PeriodInterface:       NewPeriodRepository(db, orgID),
+		ReportInterface:       NewReportRepository(db, orgID),
+		RevisionInterface:     NewRevisionRepository(db),
+		TransitionInterface:   NewTransitionRepository(db),
+		AttachmentInterface:   NewAttachmentRepository(db),
+		CommentInterface:      NewCommentRepository(db),
+		TagInterface:          NewTagRepository(db, orgID),
+		CustomFieldInterface:  NewCustomFieldRepository(db, orgID),
+		db:                    db,
+		conf:                  conf,
 	}
 }
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type RevisionRepository struct {
+	db *sqlx.DB
+}
+
+func NewRevisionRepository(db *sqlx.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+func (r *RevisionRepository) GetAll(docID int) ([]models.Revision, error) {
+	revisions := []models.Revision{}
+
+	query := fmt.Sprintf("select * from %s where document_id=$1 order by created_at, id", revisionsTable)
+
+	if err := r.db.Select(&revisions, query, docID); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionRepository) GetByID(revisionID int) (models.Revision, error) {
+	var revision models.Revision
+
+	query := fmt.Sprintf("select * from %s where id=$1", revisionsTable)
+
+	if err := r.db.Get(&revision, query, revisionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Revision{}, models.ErrRevisionNotFound
+		}
+		return models.Revision{}, err
+	}
+
+	return revision, nil
+}
+
+func insertRevision(tx *sqlx.Tx, workerID int, before, after models.Document) error {
+	query := fmt.Sprintf(`insert into %s (document_id, worker_id, old_values, new_values)
+								values ($1, $2, $3, $4)`, revisionsTable)
+
+	_, err := tx.Exec(query, after.ID, workerID, models.Snapshot(before), models.Snapshot(after))
+
+	return err
+}
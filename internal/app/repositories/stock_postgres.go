@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type StockRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewStockRepository(db *sqlx.DB, orgID int) *StockRepository {
+	return &StockRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *StockRepository) AddReceipt(workerID int, movement models.StockMovement) (int, error) {
+	var id int
+	query := fmt.Sprintf(`insert into %s (fuel_code, kind, amount, moved_at, worker_id, note, organization_id)
+								values ($1, $2, $3, $4, $5, $6, $7)
+								returning id`, stockTable)
+
+	if err := r.db.QueryRow(query,
+		movement.FuelCode,
+		models.MovementReceipt,
+		movement.Amount,
+		movement.MovedAt,
+		workerID,
+		movement.Note,
+		r.orgID).
+		Scan(&id); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return 0, models.ErrFuelNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *StockRepository) GetMovements(filter models.StockFilter) ([]models.StockMovement, error) {
+	where := &whereClause{}
+	where.add("organization_id=$%d", r.orgID)
+
+	if filter.FuelCode != "" {
+		where.add("fuel_code=$%d", filter.FuelCode)
+	}
+
+	if filter.From != nil {
+		where.add("moved_at>=$%d", *filter.From)
+	}
+
+	if filter.To != nil {
+		where.add("moved_at<=$%d", *filter.To)
+	}
+
+	movements := []models.StockMovement{}
+	query := fmt.Sprintf("select * from %s %s order by moved_at, id", stockTable, where)
+
+	if err := r.db.Select(&movements, query, where.args...); err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// GetBalances returns stock of every fuel of the catalog counting movements made up to the date.
+func (r *StockRepository) GetBalances(asOf models.MyTime) ([]models.StockBalance, error) {
+	balances := []models.StockBalance{}
+
+	query := fmt.Sprintf(`select f.code as fuel_code,
+       							coalesce(sum(m.amount) filter ( where m.amount > 0 ), 0) as received,
+       							coalesce(-sum(m.amount) filter ( where m.amount < 0 ), 0) as issued,
+       							coalesce(sum(m.amount), 0) as balance
+							from %s f left join %s m on m.fuel_code=f.code and m.moved_at<=$1 and m.organization_id=$2
+							group by f.code
+							order by f.code`, fuelsTable, stockTable)
+
+	if err := r.db.Select(&balances, query, asOf, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// syncStockIssue brings the ledger in line with the document: approved documents which aren't
+// deleted have exactly one issue, other documents have none. If the issue leaves the stock
+// below zero at any date since the document was issued the document is either refused with
+// models.ErrStockShortage or marked with the stock shortage flag.
+// The balance of the fuel is checked under a lock held until the transaction ends, so concurrent
+// issues of the same fuel are checked one after another and each one sees the issues committed before it.
+func syncStockIssue(tx *sqlx.Tx, workerID, docID int, refuseShortage bool) error {
+	deleteQuery := fmt.Sprintf("delete from %s where document_id=$1", stockTable)
+	if _, err := tx.Exec(deleteQuery, docID); err != nil {
+		return err
+	}
+
+	var issue models.StockMovement
+	issueQuery := fmt.Sprintf(`insert into %s (fuel_code, kind, amount, moved_at, document_id, worker_id,
+									organization_id)
+								select gas_type, $1, -gas_amount, issue_date, id, $2, organization_id from %s
+								where id=$3 and status=$4 and deleted_at is null
+								returning fuel_code, moved_at, organization_id`, stockTable, docsTable)
+
+	err := tx.Get(&issue, issueQuery, models.MovementIssue, workerID, docID, models.StatusApproved)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	shortage := false
+	if err == nil {
+		// the lock is taken after the insert, the next statement sees everything committed while waiting for it
+		lockQuery := "select pg_advisory_xact_lock($1, hashtext($2))"
+		if _, err := tx.Exec(lockQuery, issue.OrganizationID, issue.FuelCode); err != nil {
+			return err
+		}
+
+		var minBalance int
+		balanceQuery := fmt.Sprintf(`select coalesce(min(balance), 0) from (
+    									select moved_at, sum(sum(amount)) over (order by moved_at) as balance
+    									from %s where fuel_code=$1 and organization_id=$2 group by moved_at) b
+									where moved_at>=$3`, stockTable)
+
+		if err := tx.Get(&minBalance, balanceQuery, issue.FuelCode, issue.OrganizationID, issue.MovedAt); err != nil {
+			return err
+		}
+
+		shortage = minBalance < 0
+	}
+
+	if shortage && refuseShortage {
+		return models.ErrStockShortage
+	}
+
+	flagQuery := fmt.Sprintf("update %s set stock_shortage=$1 where id=$2", docsTable)
+	_, err = tx.Exec(flagQuery, shortage, docID)
+
+	return err
+}
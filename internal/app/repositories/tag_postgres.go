@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type TagRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewTagRepository(db *sqlx.DB, orgID int) *TagRepository {
+	return &TagRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *TagRepository) Create(tag models.Tag) (int, error) {
+	var id int
+	query := fmt.Sprintf("insert into %s (name, organization_id) values ($1, $2) returning id", tagsTable)
+
+	if err := r.db.QueryRow(query, tag.Name, r.orgID).Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrTagExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *TagRepository) GetAll() ([]models.Tag, error) {
+	tags := []models.Tag{}
+	query := fmt.Sprintf("select id, name from %s where organization_id=$1 order by name", tagsTable)
+
+	if err := r.db.Select(&tags, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// Delete removes the tag unless it's set on any document of the organization,
+// the trash included, then models.ErrTagReferenced is returned.
+func (r *TagRepository) Delete(tagID int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	nameQuery := fmt.Sprintf("select name from %s where id=$1 and organization_id=$2 for update", tagsTable)
+	if err := tx.Get(&name, nameQuery, tagID, r.orgID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ErrTagNotFound
+		}
+		return err
+	}
+
+	var used bool
+	usedQuery := fmt.Sprintf(`select exists(select 1 from %s
+								where organization_id=$1 and tags @> jsonb_build_array($2::text))`, docsTable)
+	if err := tx.Get(&used, usedQuery, r.orgID, name); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if used {
+		tx.Rollback()
+		return models.ErrTagReferenced
+	}
+
+	deleteQuery := fmt.Sprintf("delete from %s where id=$1", tagsTable)
+	if _, err := tx.Exec(deleteQuery, tagID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type TransitionRepository struct {
+	db *sqlx.DB
+}
+
+func NewTransitionRepository(db *sqlx.DB) *TransitionRepository {
+	return &TransitionRepository{db: db}
+}
+
+func (r *TransitionRepository) GetAll(docID int) ([]models.Transition, error) {
+	transitions := []models.Transition{}
+
+	query := fmt.Sprintf("select * from %s where document_id=$1 order by created_at, id", transitionsTable)
+
+	if err := r.db.Select(&transitions, query, docID); err != nil {
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+func insertTransition(tx *sqlx.Tx, workerID int, transition models.Transition) error {
+	query := fmt.Sprintf(`insert into %s (document_id, worker_id, from_status, to_status, reason)
+								values ($1, $2, $3, $4, $5)`, transitionsTable)
+
+	_, err := tx.Exec(query,
+		transition.DocumentID,
+		workerID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.Reason)
+
+	return err
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type VehicleRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewVehicleRepository(db *sqlx.DB, orgID int) *VehicleRepository {
+	return &VehicleRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *VehicleRepository) Create(vehicle models.Vehicle) (int, error) {
+	var id int
+	query := fmt.Sprintf(`insert into %s (plate_number, model, fuel_type, tank_capacity, active, consumption_norm,
+								department_id, organization_id)
+								values ($1, $2, $3, $4, $5, $6, $7, $8)
+								returning id`, vehiclesTable)
+
+	if err := r.db.QueryRow(query,
+		vehicle.PlateNumber,
+		vehicle.Model,
+		vehicle.FuelType,
+		vehicle.TankCapacity,
+		vehicle.Active,
+		vehicle.ConsumptionNorm,
+		vehicle.DepartmentID,
+		r.orgID).
+		Scan(&id); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return 0, models.ErrVehicleExists
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *VehicleRepository) GetAll(onlyActive bool) ([]models.Vehicle, error) {
+	vehicles := []models.Vehicle{}
+
+	query := fmt.Sprintf("select * from %s where organization_id=$1", vehiclesTable)
+	if onlyActive {
+		query += " and active"
+	}
+	query += " order by plate_number"
+
+	if err := r.db.Select(&vehicles, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return vehicles, nil
+}
+
+func (r *VehicleRepository) GetByID(vehicleID int) (models.Vehicle, error) {
+	var vehicle models.Vehicle
+
+	query := fmt.Sprintf("select * from %s where id=$1 and organization_id=$2", vehiclesTable)
+
+	if err := r.db.Get(&vehicle, query, vehicleID, r.orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Vehicle{}, models.ErrVehicleNotFound
+		}
+		return models.Vehicle{}, err
+	}
+
+	return vehicle, nil
+}
+
+func (r *VehicleRepository) Update(vehicle models.Vehicle) error {
+	query := fmt.Sprintf(`update %s 
+						set plate_number=$1, model=$2, fuel_type=$3, tank_capacity=$4, active=$5, consumption_norm=$6,
+						    department_id=$7
+						where id=$8 and organization_id=$9`, vehiclesTable)
+
+	if _, err := r.db.Exec(query,
+		vehicle.PlateNumber,
+		vehicle.Model,
+		vehicle.FuelType,
+		vehicle.TankCapacity,
+		vehicle.Active,
+		vehicle.ConsumptionNorm,
+		vehicle.DepartmentID,
+		vehicle.ID,
+		r.orgID); err != nil {
+		if isViolation(err, uniqueViolation) {
+			return models.ErrVehicleExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *VehicleRepository) Delete(vehicleID int) error {
+	query := fmt.Sprintf("delete from %s where id=$1 and organization_id=$2", vehiclesTable)
+
+	if _, err := r.db.Exec(query, vehicleID, r.orgID); err != nil {
+		if isViolation(err, foreignKeyViolation) {
+			return models.ErrVehicleReferenced
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type WaybillRepository struct {
+	db    *sqlx.DB
+	orgID int
+}
+
+func NewWaybillRepository(db *sqlx.DB, orgID int) *WaybillRepository {
+	return &WaybillRepository{
+		db:    db,
+		orgID: orgID,
+	}
+}
+
+func (r *WaybillRepository) GetSequences() ([]models.WaybillSequence, error) {
+	sequences := []models.WaybillSequence{}
+	query := fmt.Sprintf("select * from %s where organization_id=$1 order by year", waybillSequencesTable)
+
+	if err := r.db.Select(&sequences, query, r.orgID); err != nil {
+		return nil, err
+	}
+
+	return sequences, nil
+}
+
+func (r *WaybillRepository) ResetSequence(sequence models.WaybillSequence) error {
+	query := fmt.Sprintf(`insert into %s (organization_id, year, last_value) values ($1, $2, $3)
+								on conflict (organization_id, year) do update set last_value=excluded.last_value`,
+		waybillSequencesTable)
+
+	_, err := r.db.Exec(query, r.orgID, sequence.Year, sequence.LastValue)
+	return err
+}
+
+// getter is the part of sqlx.Tx the waybill allocation needs.
+type getter interface {
+	Get(dest interface{}, query string, args ...interface{}) error
+}
+
+// nextWaybill allocates the first free waybill number of the year in the organization. The sequence row stays locked
+// until the transaction ends, so concurrent documents wait for each other and a rolled back
+// transaction gives the number back. Numbers already taken by hand are skipped.
+func nextWaybill(tx getter, orgID int, issueDate models.MyTime) (int, error) {
+	year := time.Time(issueDate).Year()
+
+	// the sequence isn't moved past the last number, no row is returned then
+	allocateQuery := fmt.Sprintf(`insert into %s as s (organization_id, year, last_value) values ($1, $2, $3)
+									on conflict (organization_id, year) do update set last_value=s.last_value+1
+									where s.last_value<$4
+									returning last_value`, waybillSequencesTable)
+	takenQuery := fmt.Sprintf(`select exists(select 1 from %s
+									where organization_id=$1 and waybill=$2 and extract(year from issue_date)=$3)`,
+		docsTable)
+
+	for {
+		var waybill int
+		if err := tx.Get(&waybill, allocateQuery, orgID, year, models.MinWaybill, models.MaxWaybill); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return 0, models.ErrWaybillsExhausted
+			}
+			return 0, err
+		}
+
+		var taken bool
+		if err := tx.Get(&taken, takenQuery, orgID, waybill, year); err != nil {
+			return 0, err
+		}
+
+		if !taken {
+			return waybill, nil
+		}
+	}
+}
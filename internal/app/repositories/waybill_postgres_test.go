@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"database/sql"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSequence answers the queries of nextWaybill the way the database does,
+// last is the last value of the sequence row, zero if the year has no row yet.
+type fakeSequence struct {
+	last  int
+	taken map[int]bool
+}
+
+func (f *fakeSequence) Get(dest interface{}, query string, args ...interface{}) error {
+	if !strings.HasPrefix(strings.TrimSpace(query), "insert") {
+		*dest.(*bool) = f.taken[args[1].(int)]
+		return nil
+	}
+
+	first, last := args[2].(int), args[3].(int)
+	switch {
+	case f.last == 0:
+		f.last = first
+	case f.last < last:
+		f.last++
+	default:
+		return sql.ErrNoRows
+	}
+	*dest.(*int) = f.last
+
+	return nil
+}
+
+func TestNextWaybill(t *testing.T) {
+	testTable := []struct {
+		name            string
+		sequence        fakeSequence
+		expectedWaybill int
+		expectedLast    int
+		expectedErr     error
+	}{
+		{
+			name:            "new year",
+			expectedWaybill: models.MinWaybill,
+			expectedLast:    models.MinWaybill,
+		},
+		{
+			name:            "next number",
+			sequence:        fakeSequence{last: 1234},
+			expectedWaybill: 1235,
+			expectedLast:    1235,
+		},
+		{
+			name:            "taken numbers are skipped",
+			sequence:        fakeSequence{last: 1234, taken: map[int]bool{1235: true, 1236: true}},
+			expectedWaybill: 1237,
+			expectedLast:    1237,
+		},
+		{
+			name:            "last number",
+			sequence:        fakeSequence{last: models.MaxWaybill - 1},
+			expectedWaybill: models.MaxWaybill,
+			expectedLast:    models.MaxWaybill,
+		},
+		{
+			name:         "exhausted",
+			sequence:     fakeSequence{last: models.MaxWaybill},
+			expectedLast: models.MaxWaybill,
+			expectedErr:  models.ErrWaybillsExhausted,
+		},
+		{
+			name:         "exhausted by taken numbers",
+			sequence:     fakeSequence{last: 9997, taken: map[int]bool{9998: true, 9999: true}},
+			expectedLast: models.MaxWaybill,
+			expectedErr:  models.ErrWaybillsExhausted,
+		},
+	}
+
+	issueDate := models.MyTime(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			waybill, err := nextWaybill(&tc.sequence, 1, issueDate)
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedWaybill, waybill)
+			assert.Equal(t, tc.expectedLast, tc.sequence.last)
+		})
+	}
+}
@@ -1,23 +1,30 @@
 package repositories
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/jmoiron/sqlx"
 )
 
 type WorkerRepository struct {
-	db *sqlx.DB
+	db    *sqlx.DB
+	orgID int
 }
 
-func NewWorkerRepository(db *sqlx.DB) *WorkerRepository {
-	return &WorkerRepository{db: db}
+func NewWorkerRepository(db *sqlx.DB, orgID int) *WorkerRepository {
+	return &WorkerRepository{
+		db:    db,
+		orgID: orgID,
+	}
 }
 
 func (r *WorkerRepository) CreateWorker(worker models.Worker) (int, error) {
 	var id int
-	query := fmt.Sprintf(`insert into %s (name, surname, fathers_name, phone, role, password_hash)
-								values ($1, $2, $3, $4, $5, $6)
+	query := fmt.Sprintf(`insert into %s (name, surname, fathers_name, phone, role, password_hash,
+								organization_id, department_id, department_access)
+								values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 								returning id`, workersTable)
 
 	if err := r.db.QueryRow(query,
@@ -26,7 +33,10 @@ func (r *WorkerRepository) CreateWorker(worker models.Worker) (int, error) {
 		worker.FathersName,
 		worker.Phone,
 		worker.Role,
-		worker.PasswordHash).
+		worker.PasswordHash,
+		r.orgID,
+		worker.DepartmentID,
+		worker.DepartmentAccess).
 		Scan(&id); err != nil {
 		return 0, err
 	}
@@ -34,6 +44,8 @@ func (r *WorkerRepository) CreateWorker(worker models.Worker) (int, error) {
 	return id, nil
 }
 
+// GetWorker looks the worker up by the credentials in every organization,
+// it's used to sign in before the organization is known.
 func (r *WorkerRepository) GetWorker(worker *models.Worker) error {
 	query := fmt.Sprintf(`select * from %s where name=$1 and surname=$2 and phone=$3 and password_hash=$4`,
 		workersTable)
@@ -52,35 +64,77 @@ func (r *WorkerRepository) GetWorker(worker *models.Worker) error {
 func (r *WorkerRepository) GetAll() ([]models.Worker, error) {
 	var workers []models.Worker
 
-	query := fmt.Sprintf("select * from %s where role='worker'", workersTable)
+	query := fmt.Sprintf("select * from %s where role='worker' and organization_id=$1", workersTable)
 
-	if err := r.db.Select(&workers, query); err != nil {
+	if err := r.db.Select(&workers, query, r.orgID); err != nil {
 		return nil, err
 	}
 
 	return workers, nil
 }
 
+func (r *WorkerRepository) Export(fn func(models.Worker) error) error {
+	query := fmt.Sprintf("select * from %s where role='worker' and organization_id=$1 order by id", workersTable)
+
+	rows, err := r.db.Queryx(query, r.orgID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var worker models.Worker
+		if err := rows.StructScan(&worker); err != nil {
+			return err
+		}
+
+		if err := fn(worker); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetByID finds the worker of the organization, repositories of no organization
+// find super-admins only.
 func (r *WorkerRepository) GetByID(workerID int) (models.Worker, error) {
 	var worker models.Worker
 
-	query := fmt.Sprintf("select * from %s where id=$1", workersTable)
+	query := fmt.Sprintf("select * from %s where id=$1 and organization_id is not distinct from nullif($2, 0)",
+		workersTable)
 
-	if err := r.db.Get(&worker, query, workerID); err != nil {
+	if err := r.db.Get(&worker, query, workerID, r.orgID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Worker{}, models.ErrWorkerNotFound
+		}
 		return models.Worker{}, err
 	}
 
 	return worker, nil
 }
 
+// Update writes the worker only if it still has the version it was read with,
+// otherwise models.ErrVersionMismatch is returned.
 func (r *WorkerRepository) Update(worker models.Worker) error {
-	query := fmt.Sprintf(`UPDATE %s SET name=$1, surname=$2, fathers_name=$3, phone=$4 WHERE id=$5`,
-		workersTable)
+	query := fmt.Sprintf(`UPDATE %s SET name=$1, surname=$2, fathers_name=$3, phone=$4, department_id=$5,
+								department_access=$6, version=version+1
+								WHERE id=$7 AND version=$8 AND organization_id=$9`, workersTable)
 
-	_, err := r.db.Exec(query, worker.Name, worker.Surname, worker.FathersName, worker.Phone, worker.ID)
+	result, err := r.db.Exec(query, worker.Name, worker.Surname, worker.FathersName, worker.Phone,
+		worker.DepartmentID, worker.DepartmentAccess, worker.ID, worker.Version, r.orgID)
 	if err != nil {
 		return err
 	}
 
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return models.ErrVersionMismatch
+	}
+
 	return nil
 }
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"io"
+)
+
+// AccessService is the access policy in front of the document services, documents
+// out of the worker's scope are reported as not found so their existence doesn't leak.
+// The scope is taken from the worker as stored, so a change of the department or of the access
+// is in effect right away rather than once the token expires.
+type AccessService struct {
+	repos       *repositories.Repository
+	documents   GSMInterface
+	attachments Attachments
+	printing    Printing
+}
+
+func NewAccessService(repos *repositories.Repository, documents GSMInterface, attachments Attachments,
+	printing Printing) *AccessService {
+	return &AccessService{
+		repos:       repos,
+		documents:   documents,
+		attachments: attachments,
+		printing:    printing,
+	}
+}
+
+func (s *AccessService) GetAll(worker models.WorkerAttributes, filter models.DocumentFilter) (models.DocumentList, error) {
+	scope, err := s.scope(worker)
+	if err != nil {
+		return models.DocumentList{}, err
+	}
+	filter.Scope = scope
+
+	return s.documents.GetAll(filter)
+}
+
+func (s *AccessService) Export(worker models.WorkerAttributes, filter models.DocumentFilter,
+	fn func(models.Document) error) error {
+	scope, err := s.scope(worker)
+	if err != nil {
+		return err
+	}
+	filter.Scope = scope
+
+	return s.documents.Export(filter, fn)
+}
+
+func (s *AccessService) GetByID(worker models.WorkerAttributes, docID int) (models.Document, error) {
+	document, err := s.documents.GetByID(docID)
+	if err != nil {
+		return models.Document{}, err
+	}
+
+	if err := s.check(worker, document); err != nil {
+		return models.Document{}, err
+	}
+
+	return document, nil
+}
+
+func (s *AccessService) Transitions(worker models.WorkerAttributes, docID int) ([]models.Transition, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return nil, err
+	}
+
+	return s.documents.Transitions(docID)
+}
+
+func (s *AccessService) History(worker models.WorkerAttributes, docID int) ([]models.TimelineEntry, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return nil, err
+	}
+
+	return s.documents.History(docID)
+}
+
+func (s *AccessService) Attachments(worker models.WorkerAttributes, docID int) ([]models.Attachment, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return nil, err
+	}
+
+	return s.attachments.GetAll(docID)
+}
+
+func (s *AccessService) Download(worker models.WorkerAttributes, docID,
+	attachmentID int) (models.Attachment, io.ReadCloser, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return s.attachments.Download(docID, attachmentID)
+}
+
+func (s *AccessService) WaybillPDF(worker models.WorkerAttributes, docID int) ([]byte, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return nil, err
+	}
+
+	return s.printing.WaybillPDF(docID)
+}
+
+// UpdateOwn reports documents out of the scope as not found, the ones in the scope are refused
+// by the document service if the worker isn't allowed to change them, so are the changes below.
+func (s *AccessService) UpdateOwn(worker models.WorkerAttributes, docID, version int,
+	docInput models.UpdateDocInput) error {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return err
+	}
+
+	return s.documents.UpdateOwn(docID, version, worker, docInput)
+}
+
+func (s *AccessService) Transition(worker models.WorkerAttributes, docID int, input models.TransitionInput) error {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return err
+	}
+
+	return s.documents.Transition(docID, worker, input)
+}
+
+func (s *AccessService) Upload(worker models.WorkerAttributes, docID int, fileName string,
+	content io.Reader) (models.Attachment, error) {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return models.Attachment{}, err
+	}
+
+	return s.attachments.Upload(docID, worker, fileName, content)
+}
+
+func (s *AccessService) DeleteAttachment(worker models.WorkerAttributes, docID, attachmentID int) error {
+	if _, err := s.GetByID(worker, docID); err != nil {
+		return err
+	}
+
+	return s.attachments.Delete(docID, attachmentID, worker)
+}
+
+// scope returns the scope of the worker as stored, see models.DocumentScopeOf.
+func (s *AccessService) scope(worker models.WorkerAttributes) (*models.DocumentScope, error) {
+	stored, err := s.repos.WorkerInterface.GetByID(worker.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DocumentScopeOf(stored), nil
+}
+
+func (s *AccessService) check(worker models.WorkerAttributes, document models.Document) error {
+	scope, err := s.scope(worker)
+	if err != nil || scope == nil {
+		return err
+	}
+
+	authorID, err := s.repos.GSMInterface.GetAuthorID(document.ID)
+	if err != nil {
+		return err
+	}
+
+	if !scope.Permits(document, authorID) {
+		return models.ErrDocumentNotFound
+	}
+
+	return nil
+}
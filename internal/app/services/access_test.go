@@ -0,0 +1,251 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccessService_GetByID(t *testing.T) {
+	department, otherDepartment := 4, 5
+	ofDepartment := models.Document{ID: 1, DepartmentID: &department}
+	ofOtherDepartment := models.Document{ID: 1, DepartmentID: &otherDepartment}
+
+	stored := func(role string, departmentAccess bool) models.Worker {
+		return models.Worker{ID: 3, Role: role, DepartmentID: &department, DepartmentAccess: departmentAccess}
+	}
+
+	testTable := []struct {
+		name        string
+		role        string
+		stored      models.Worker
+		document    models.Document
+		authorID    int
+		expectedErr error
+	}{
+		{
+			name:     "admin",
+			role:     models.RoleAdmin,
+			stored:   stored(models.RoleAdmin, false),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "admin with department access",
+			role:     models.RoleAdmin,
+			stored:   stored(models.RoleAdmin, true),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "dispatcher",
+			role:     models.RoleDispatcher,
+			stored:   stored(models.RoleDispatcher, false),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "dispatcher with department access",
+			role:     models.RoleDispatcher,
+			stored:   stored(models.RoleDispatcher, true),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "auditor",
+			role:     models.RoleAuditor,
+			stored:   stored(models.RoleAuditor, false),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "auditor with department access",
+			role:     models.RoleAuditor,
+			stored:   stored(models.RoleAuditor, true),
+			document: ofOtherDepartment,
+		},
+		{
+			name:     "worker's own document",
+			role:     models.RoleWorker,
+			stored:   stored(models.RoleWorker, false),
+			document: ofOtherDepartment,
+			authorID: 3,
+		},
+		{
+			name:        "worker and document of the department",
+			role:        models.RoleWorker,
+			stored:      stored(models.RoleWorker, false),
+			document:    ofDepartment,
+			authorID:    2,
+			expectedErr: models.ErrDocumentNotFound,
+		},
+		{
+			name:     "worker with department access and document of the department",
+			role:     models.RoleWorker,
+			stored:   stored(models.RoleWorker, true),
+			document: ofDepartment,
+			authorID: 2,
+		},
+		{
+			name:        "worker with department access and document of another department",
+			role:        models.RoleWorker,
+			stored:      stored(models.RoleWorker, true),
+			document:    ofOtherDepartment,
+			authorID:    2,
+			expectedErr: models.ErrDocumentNotFound,
+		},
+		{
+			name:        "admin demoted after sign in",
+			role:        models.RoleAdmin,
+			stored:      stored(models.RoleWorker, false),
+			document:    ofOtherDepartment,
+			authorID:    2,
+			expectedErr: models.ErrDocumentNotFound,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			workers := mock_repositories.NewMockWorkerInterface(c)
+			documentRepo := mock_repositories.NewMockGSMInterface(c)
+			documents := mock_services.NewMockGSMInterface(c)
+
+			documents.EXPECT().GetByID(1).Return(tc.document, nil)
+			workers.EXPECT().GetByID(3).Return(tc.stored, nil)
+			if tc.authorID != 0 {
+				documentRepo.EXPECT().GetAuthorID(1).Return(tc.authorID, nil)
+			}
+
+			repos := &repositories.Repository{WorkerInterface: workers, GSMInterface: documentRepo}
+			service := NewAccessService(repos, documents, nil, nil)
+
+			document, err := service.GetByID(models.WorkerAttributes{ID: 3, Role: tc.role}, 1)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.document, document)
+		})
+	}
+}
+
+func TestAccessService_GetAll(t *testing.T) {
+	department := 4
+	worker := models.WorkerAttributes{ID: 3, Role: models.RoleWorker}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	workers := mock_repositories.NewMockWorkerInterface(c)
+	documents := mock_services.NewMockGSMInterface(c)
+
+	workers.EXPECT().GetByID(3).Return(models.Worker{ID: 3, Role: models.RoleWorker, DepartmentID: &department,
+		DepartmentAccess: true}, nil)
+	documents.EXPECT().GetAll(models.DocumentFilter{
+		Status: models.StatusDraft,
+		Scope:  &models.DocumentScope{WorkerID: 3, DepartmentID: &department},
+	}).Return(models.DocumentList{Total: 1}, nil)
+
+	service := NewAccessService(&repositories.Repository{WorkerInterface: workers}, documents, nil, nil)
+	list, err := service.GetAll(worker, models.DocumentFilter{Status: models.StatusDraft})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DocumentList{Total: 1}, list)
+}
+
+func TestAccessService_changes(t *testing.T) {
+	worker := models.WorkerAttributes{ID: 3, Role: models.RoleWorker}
+	transition := models.TransitionInput{Status: models.StatusSubmitted}
+
+	testTable := []struct {
+		name         string
+		authorID     int
+		mockBehavior func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments)
+		change       func(s *AccessService) error
+		expectedErr  error
+	}{
+		{
+			name:     "transition",
+			authorID: 3,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {
+				documents.EXPECT().Transition(1, worker, transition).Return(nil)
+			},
+			change: func(s *AccessService) error {
+				return s.Transition(worker, 1, transition)
+			},
+		},
+		{
+			name:         "transition out of scope",
+			authorID:     2,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {},
+			change: func(s *AccessService) error {
+				return s.Transition(worker, 1, transition)
+			},
+			expectedErr: models.ErrDocumentNotFound,
+		},
+		{
+			name:         "update out of scope",
+			authorID:     2,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {},
+			change: func(s *AccessService) error {
+				return s.UpdateOwn(worker, 1, 3, models.UpdateDocInput{})
+			},
+			expectedErr: models.ErrDocumentNotFound,
+		},
+		{
+			name:         "upload out of scope",
+			authorID:     2,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {},
+			change: func(s *AccessService) error {
+				_, err := s.Upload(worker, 1, "receipt.pdf", nil)
+				return err
+			},
+			expectedErr: models.ErrDocumentNotFound,
+		},
+		{
+			name:     "attachment deleted",
+			authorID: 3,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {
+				attachments.EXPECT().Delete(1, 7, worker).Return(nil)
+			},
+			change: func(s *AccessService) error {
+				return s.DeleteAttachment(worker, 1, 7)
+			},
+		},
+		{
+			name:         "attachment out of scope",
+			authorID:     2,
+			mockBehavior: func(documents *mock_services.MockGSMInterface, attachments *mock_services.MockAttachments) {},
+			change: func(s *AccessService) error {
+				return s.DeleteAttachment(worker, 1, 7)
+			},
+			expectedErr: models.ErrDocumentNotFound,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			workers := mock_repositories.NewMockWorkerInterface(c)
+			documentRepo := mock_repositories.NewMockGSMInterface(c)
+			documents := mock_services.NewMockGSMInterface(c)
+			attachments := mock_services.NewMockAttachments(c)
+
+			workers.EXPECT().GetByID(3).Return(models.Worker{ID: 3, Role: models.RoleWorker}, nil)
+			documents.EXPECT().GetByID(1).Return(models.Document{ID: 1}, nil)
+			documentRepo.EXPECT().GetAuthorID(1).Return(tc.authorID, nil)
+			tc.mockBehavior(documents, attachments)
+
+			repos := &repositories.Repository{WorkerInterface: workers, GSMInterface: documentRepo}
+			service := NewAccessService(repos, documents, attachments, nil)
+
+			assert.Equal(t, tc.expectedErr, tc.change(service))
+		})
+	}
+}
@@ -14,13 +14,19 @@ func NewAdminService(repos *repositories.Repository) *AdminService {
 }
 
 func (s *AdminService) CreateWorker(workerInput models.CreateWorkerInput) (int, error) {
+	if err := checkDepartment(s.repos, workerInput.DepartmentID); err != nil {
+		return 0, err
+	}
+
 	worker := models.Worker{
-		Name:         workerInput.Name,
-		Surname:      workerInput.Surname,
-		FathersName:  workerInput.FathersName,
-		Phone:        workerInput.Phone,
-		Role:         workerInput.Role,
-		PasswordHash: getPasswordHash(workerInput.Password),
+		Name:             workerInput.Name,
+		Surname:          workerInput.Surname,
+		FathersName:      workerInput.FathersName,
+		Phone:            workerInput.Phone,
+		Role:             workerInput.Role,
+		PasswordHash:     getPasswordHash(workerInput.Password),
+		DepartmentID:     workerInput.DepartmentID,
+		DepartmentAccess: workerInput.DepartmentAccess,
 	}
 
 	return s.repos.WorkerInterface.CreateWorker(worker)
@@ -30,16 +36,28 @@ func (s *AdminService) GetAll() ([]models.Worker, error) {
 	return s.repos.WorkerInterface.GetAll()
 }
 
+func (s *AdminService) ExportWorkers(fn func(models.Worker) error) error {
+	return s.repos.WorkerInterface.Export(fn)
+}
+
 func (s *AdminService) GetByID(workerID int) (models.Worker, error) {
 	return s.repos.WorkerInterface.GetByID(workerID)
 }
 
-func (s *AdminService) UpdateWorker(workerID int, workerInput models.UpdateWorkerInput) error {
+func (s *AdminService) UpdateWorker(workerID, version int, workerInput models.UpdateWorkerInput) error {
 	worker, err := s.repos.WorkerInterface.GetByID(workerID)
 	if err != nil {
 		return err
 	}
 
+	if worker.Version != version {
+		return models.ErrVersionMismatch
+	}
+
+	if err := checkDepartment(s.repos, workerInput.DepartmentID); err != nil {
+		return err
+	}
+
 	workerInput.ToWorker(&worker)
 
 	return s.repos.WorkerInterface.Update(worker)
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/configs"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffLen is how many bytes are needed to detect the content type
+const sniffLen = 512
+
+type AttachmentService struct {
+	repos   *repositories.Repository
+	storage storage.Storage
+	maxSize int64
+	types   map[string]bool
+}
+
+func NewAttachmentService(repos *repositories.Repository, storage storage.Storage,
+	conf *configs.ServiceConfig) *AttachmentService {
+	types := make(map[string]bool, len(conf.AttachmentTypes))
+	for _, contentType := range conf.AttachmentTypes {
+		types[contentType] = true
+	}
+
+	return &AttachmentService{
+		repos:   repos,
+		storage: storage,
+		maxSize: conf.AttachmentMaxSize,
+		types:   types,
+	}
+}
+
+// Upload stores the file of the document. The content type is detected from the content
+// instead of trusting the client, the size and the checksum are counted while the file is stored.
+func (s *AttachmentService) Upload(docID int, worker models.WorkerAttributes, fileName string,
+	content io.Reader) (models.Attachment, error) {
+	if err := s.checkAccess(docID, worker); err != nil {
+		return models.Attachment{}, err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return models.Attachment{}, err
+	}
+	head = head[:n]
+
+	if n == 0 {
+		return models.Attachment{}, models.ErrAttachmentEmpty
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !s.types[contentType] {
+		return models.Attachment{}, fmt.Errorf("%w: %s", models.ErrAttachmentType, contentType)
+	}
+
+	key, err := attachmentKey(docID)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	// one byte over the limit is read to tell a file of exactly the limit from a larger one
+	hash := sha256.New()
+	counter := &countingWriter{}
+	limited := io.LimitReader(io.MultiReader(bytes.NewReader(head), content), s.maxSize+1)
+
+	if err := s.storage.Put(key, io.TeeReader(limited, io.MultiWriter(hash, counter))); err != nil {
+		return models.Attachment{}, err
+	}
+
+	if counter.n > s.maxSize {
+		s.storage.Delete(key)
+		return models.Attachment{}, models.ErrAttachmentTooLarge
+	}
+
+	attachment, err := s.repos.AttachmentInterface.Create(models.Attachment{
+		DocumentID:  docID,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        counter.n,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		UploadedBy:  &worker.ID,
+	})
+	if err != nil {
+		s.storage.Delete(key)
+		return models.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+// GetAll returns attachments of the document, attachments of documents
+// in the trash are kept but aren't available until the document is restored.
+func (s *AttachmentService) GetAll(docID int) ([]models.Attachment, error) {
+	if _, err := s.repos.GSMInterface.GetByID(docID); err != nil {
+		return nil, err
+	}
+
+	return s.repos.AttachmentInterface.GetAll(docID)
+}
+
+// Download returns the attachment and its content, the caller has to close the content.
+func (s *AttachmentService) Download(docID, attachmentID int) (models.Attachment, io.ReadCloser, error) {
+	if _, err := s.repos.GSMInterface.GetByID(docID); err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	attachment, err := s.get(docID, attachmentID)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	content, err := s.storage.Get(attachment.StorageKey)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// Delete removes the file before the attachment row, so if the storage fails the attachment is kept
+// and can be deleted again, deleting a file which is already gone succeeds.
+func (s *AttachmentService) Delete(docID, attachmentID int, worker models.WorkerAttributes) error {
+	if err := s.checkAccess(docID, worker); err != nil {
+		return err
+	}
+
+	attachment, err := s.get(docID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.Delete(attachment.StorageKey); err != nil {
+		return err
+	}
+
+	return s.repos.AttachmentInterface.Delete(attachment.ID)
+}
+
+// get returns the attachment if it belongs to the document.
+func (s *AttachmentService) get(docID, attachmentID int) (models.Attachment, error) {
+	attachment, err := s.repos.AttachmentInterface.GetByID(attachmentID)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	if attachment.DocumentID != docID {
+		return models.Attachment{}, models.ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+// checkAccess lets workers change attachments of their own documents only,
+// dispatchers and admins can change attachments of any document.
+func (s *AttachmentService) checkAccess(docID int, worker models.WorkerAttributes) error {
+	if _, err := s.repos.GSMInterface.GetByID(docID); err != nil {
+		return err
+	}
+
+	if worker.Role == models.RoleWorker {
+		return checkAuthor(s.repos, docID, worker.ID)
+	}
+
+	return nil
+}
+
+// attachmentsPrefix groups the files of the document, so they can be removed together with it.
+func attachmentsPrefix(docID int) string {
+	return fmt.Sprintf("documents/%d", docID)
+}
+
+func attachmentKey(docID int) (string, error) {
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+
+	return attachmentsPrefix(docID) + "/" + hex.EncodeToString(name), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
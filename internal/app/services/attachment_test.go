@@ -0,0 +1,225 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"github.com/HeadHardener/tp_lab/configs"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+// memoryStorage keeps files in memory, failing operations return err.
+type memoryStorage struct {
+	files map[string][]byte
+	err   error
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) Put(key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	m.files[key] = data
+	return nil
+}
+
+func (m *memoryStorage) Get(key string) (io.ReadCloser, error) {
+	data, ok := m.files[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryStorage) Delete(key string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	delete(m.files, key)
+	return nil
+}
+
+func (m *memoryStorage) DeletePrefix(prefix string) error {
+	for key := range m.files {
+		if strings.HasPrefix(key, prefix+"/") {
+			delete(m.files, key)
+		}
+	}
+
+	return nil
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	admin := models.WorkerAttributes{ID: 2, Role: models.RoleAdmin}
+
+	testTable := []struct {
+		name          string
+		content       string
+		mockBehavior  func(attachments *mock_repositories.MockAttachmentInterface)
+		expectedSize  int64
+		expectedType  string
+		expectedErr   string
+		expectedFiles int
+	}{
+		{
+			name:    "ok",
+			content: "%PDF-1.4 receipt",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().Create(gomock.Any()).DoAndReturn(func(a models.Attachment) (models.Attachment, error) {
+					a.ID = 7
+					return a, nil
+				})
+			},
+			expectedSize:  16,
+			expectedType:  "application/pdf",
+			expectedFiles: 1,
+		},
+		{
+			name:         "exactly the limit",
+			content:      "%PDF-1.4 receipt" + strings.Repeat(" ", 4),
+			expectedSize: 20,
+			expectedType: "application/pdf",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().Create(gomock.Any()).DoAndReturn(func(a models.Attachment) (models.Attachment, error) {
+					return a, nil
+				})
+			},
+			expectedFiles: 1,
+		},
+		{
+			name:         "too large",
+			content:      "%PDF-1.4 receipt" + strings.Repeat(" ", 5),
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {},
+			expectedErr:  "attachment is too large",
+		},
+		{
+			name:         "type not allowed",
+			content:      "plain text",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {},
+			expectedErr:  "attachment type isn't allowed: text/plain",
+		},
+		{
+			name:         "empty",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {},
+			expectedErr:  "attachment is empty",
+		},
+		{
+			name:    "row isn't stored",
+			content: "%PDF-1.4 receipt",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().Create(gomock.Any()).Return(models.Attachment{}, errors.New("db is down"))
+			},
+			expectedErr: "db is down",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			documents := mock_repositories.NewMockGSMInterface(c)
+			attachments := mock_repositories.NewMockAttachmentInterface(c)
+			documents.EXPECT().GetByID(1).Return(models.Document{ID: 1}, nil)
+			tc.mockBehavior(attachments)
+
+			files := newMemoryStorage()
+			service := NewAttachmentService(
+				&repositories.Repository{GSMInterface: documents, AttachmentInterface: attachments},
+				files,
+				&configs.ServiceConfig{AttachmentMaxSize: 20, AttachmentTypes: []string{"application/pdf"}})
+
+			attachment, err := service.Upload(1, admin, "../receipt.pdf", strings.NewReader(tc.content))
+
+			assert.Len(t, files.files, tc.expectedFiles)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, "receipt.pdf", attachment.FileName)
+				assert.Equal(t, tc.expectedType, attachment.ContentType)
+				assert.Equal(t, tc.expectedSize, attachment.Size)
+				assert.Len(t, attachment.Checksum, 64)
+				assert.Equal(t, tc.content, string(files.files[attachment.StorageKey]))
+			}
+		})
+	}
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	admin := models.WorkerAttributes{ID: 2, Role: models.RoleAdmin}
+	stored := models.Attachment{ID: 7, DocumentID: 1, StorageKey: "documents/1/a"}
+
+	testTable := []struct {
+		name          string
+		storageErr    error
+		mockBehavior  func(attachments *mock_repositories.MockAttachmentInterface)
+		expectedErr   error
+		expectedFiles int
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().GetByID(7).Return(stored, nil)
+				attachments.EXPECT().Delete(7).Return(nil)
+			},
+		},
+		{
+			name:       "storage fails",
+			storageErr: errors.New("disk is read-only"),
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().GetByID(7).Return(stored, nil)
+			},
+			expectedErr:   errors.New("disk is read-only"),
+			expectedFiles: 1,
+		},
+		{
+			name: "attachment of another document",
+			mockBehavior: func(attachments *mock_repositories.MockAttachmentInterface) {
+				attachments.EXPECT().GetByID(7).Return(models.Attachment{ID: 7, DocumentID: 3}, nil)
+			},
+			expectedErr:   models.ErrAttachmentNotFound,
+			expectedFiles: 1,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			documents := mock_repositories.NewMockGSMInterface(c)
+			attachments := mock_repositories.NewMockAttachmentInterface(c)
+			documents.EXPECT().GetByID(1).Return(models.Document{ID: 1}, nil)
+			tc.mockBehavior(attachments)
+
+			files := newMemoryStorage()
+			files.files[stored.StorageKey] = []byte("content")
+			files.err = tc.storageErr
+
+			service := &AttachmentService{
+				repos:   &repositories.Repository{GSMInterface: documents, AttachmentInterface: attachments},
+				storage: files,
+			}
+
+			assert.Equal(t, tc.expectedErr, service.Delete(1, 7, admin))
+			assert.Len(t, files.files, tc.expectedFiles)
+		})
+	}
+}
@@ -26,9 +26,10 @@ func NewAuthService(repos *repositories.Repository) *AuthService {
 
 type tokenClaims struct {
 	jwt.StandardClaims
-	WorkerID int    `json:"worker_id"`
-	Role     string `json:"role"`
-	Name     string `json:"name"`
+	WorkerID       int    `json:"worker_id"`
+	Role           string `json:"role"`
+	Name           string `json:"name"`
+	OrganizationID int    `json:"organization_id,omitempty"`
 }
 
 func (s *AuthService) GenerateToken(workerInput models.LogWorkerInput) (string, error) {
@@ -44,6 +45,12 @@ func (s *AuthService) GenerateToken(workerInput models.LogWorkerInput) (string,
 		return "", err
 	}
 
+	// super-admins don't belong to any organization and get zero OrganizationID
+	var orgID int
+	if worker.OrganizationID != nil {
+		orgID = *worker.OrganizationID
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
 		jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(tokenTTL).Unix(),
@@ -52,6 +59,7 @@ func (s *AuthService) GenerateToken(workerInput models.LogWorkerInput) (string,
 		worker.ID,
 		worker.Role,
 		fmt.Sprintf("%s %s", worker.Name, worker.Surname),
+		orgID,
 	})
 
 	return token.SignedString([]byte(secretKey))
@@ -76,9 +84,10 @@ func (s *AuthService) ParseToken(accessToken string) (models.WorkerAttributes, e
 	}
 
 	return models.WorkerAttributes{
-		ID:   claims.WorkerID,
-		Role: claims.Role,
-		Name: claims.Name,
+		ID:             claims.WorkerID,
+		Role:           claims.Role,
+		Name:           claims.Name,
+		OrganizationID: claims.OrganizationID,
 	}, nil
 }
 
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type CommentService struct {
+	repos    *repositories.Repository
+	access   DocumentAccess
+	notifier Notifier
+}
+
+func NewCommentService(repos *repositories.Repository, access DocumentAccess, notifier Notifier) *CommentService {
+	return &CommentService{
+		repos:    repos,
+		access:   access,
+		notifier: notifier,
+	}
+}
+
+func (s *CommentService) GetAll(worker models.WorkerAttributes, docID int) ([]models.Comment, error) {
+	if _, err := s.access.GetByID(worker, docID); err != nil {
+		return nil, err
+	}
+
+	return s.repos.CommentInterface.GetAll(docID)
+}
+
+func (s *CommentService) Create(worker models.WorkerAttributes, docID int,
+	commentInput models.CommentInput) (models.Comment, error) {
+	if _, err := s.access.GetByID(worker, docID); err != nil {
+		return models.Comment{}, err
+	}
+
+	comment, err := s.repos.CommentInterface.Create(models.Comment{
+		DocumentID: docID,
+		WorkerID:   &worker.ID,
+		Author:     worker.Name,
+		Body:       commentInput.Body,
+	})
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	s.notifyMentions(worker, comment, "")
+
+	return comment, nil
+}
+
+// Update changes the comment, only its author can do it.
+// Workers mentioned for the first time are notified.
+func (s *CommentService) Update(worker models.WorkerAttributes, docID, commentID int,
+	commentInput models.CommentInput) error {
+	comment, err := s.get(worker, docID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.WorkerID == nil || *comment.WorkerID != worker.ID {
+		return models.ErrNotEnoughRights
+	}
+
+	if err := s.repos.CommentInterface.Update(commentID, commentInput.Body); err != nil {
+		return err
+	}
+
+	previous := comment.Body
+	comment.Body = commentInput.Body
+	s.notifyMentions(worker, comment, previous)
+
+	return nil
+}
+
+// Delete removes the comment, admins can remove comments of other workers.
+func (s *CommentService) Delete(worker models.WorkerAttributes, docID, commentID int) error {
+	comment, err := s.get(worker, docID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if worker.Role != models.RoleAdmin && (comment.WorkerID == nil || *comment.WorkerID != worker.ID) {
+		return models.ErrNotEnoughRights
+	}
+
+	return s.repos.CommentInterface.Delete(commentID)
+}
+
+func (s *CommentService) get(worker models.WorkerAttributes, docID, commentID int) (models.Comment, error) {
+	if _, err := s.access.GetByID(worker, docID); err != nil {
+		return models.Comment{}, err
+	}
+
+	comment, err := s.repos.CommentInterface.GetByID(commentID)
+	if err != nil {
+		return models.Comment{}, err
+	}
+
+	if comment.DocumentID != docID {
+		return models.Comment{}, models.ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+// notifyMentions notifies the workers mentioned in the comment but not in the previous text of it.
+// Mentions of the author and of workers who can't read the document, like the ones
+// of other organizations or out of the document's department, are skipped.
+func (s *CommentService) notifyMentions(worker models.WorkerAttributes, comment models.Comment, previous string) {
+	notified := make(map[int]bool)
+	for _, workerID := range models.Mentions(previous) {
+		notified[workerID] = true
+	}
+
+	for _, workerID := range models.Mentions(comment.Body) {
+		if notified[workerID] || workerID == worker.ID {
+			continue
+		}
+
+		// the scope is taken from the stored worker, so the id is all the access needs
+		if _, err := s.access.GetByID(models.WorkerAttributes{ID: workerID}, comment.DocumentID); err != nil {
+			continue
+		}
+
+		s.notifier.NotifyWorker(workerID, fmt.Sprintf("%s mentioned you in a comment to document %d: %s",
+			worker.Name, comment.DocumentID, comment.Body))
+	}
+}
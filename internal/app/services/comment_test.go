@@ -0,0 +1,73 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	mock_services "github.com/HeadHardener/tp_lab/internal/app/services/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCommentService_mentions(t *testing.T) {
+	author := models.WorkerAttributes{ID: 3, Role: models.RoleWorker, Name: "Ivan Ivanov"}
+
+	type mockBehavior func(access *mock_services.MockDocumentAccess, comments *mock_repositories.MockCommentInterface,
+		notifier *mock_services.MockNotifier)
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		comment      func(s *CommentService) error
+	}{
+		{
+			name: "readers are notified",
+			mockBehavior: func(access *mock_services.MockDocumentAccess, comments *mock_repositories.MockCommentInterface,
+				notifier *mock_services.MockNotifier) {
+				comments.EXPECT().Create(gomock.Any()).Return(models.Comment{ID: 9, DocumentID: 1,
+					Body: "@5 @6 @3 check the receipt @5"}, nil)
+				access.EXPECT().GetByID(models.WorkerAttributes{ID: 5}, 1).Return(models.Document{ID: 1}, nil)
+				access.EXPECT().GetByID(models.WorkerAttributes{ID: 6}, 1).Return(models.Document{}, models.ErrDocumentNotFound)
+				notifier.EXPECT().NotifyWorker(5,
+					"Ivan Ivanov mentioned you in a comment to document 1: @5 @6 @3 check the receipt @5")
+			},
+			comment: func(s *CommentService) error {
+				_, err := s.Create(author, 1, models.CommentInput{Body: "@5 @6 @3 check the receipt @5"})
+				return err
+			},
+		},
+		{
+			name: "workers mentioned before aren't notified again",
+			mockBehavior: func(access *mock_services.MockDocumentAccess, comments *mock_repositories.MockCommentInterface,
+				notifier *mock_services.MockNotifier) {
+				comments.EXPECT().GetByID(9).Return(models.Comment{ID: 9, DocumentID: 1, WorkerID: &author.ID,
+					Body: "@5 check the receipt"}, nil)
+				comments.EXPECT().Update(9, "@5 @7 check the receipt").Return(nil)
+				access.EXPECT().GetByID(models.WorkerAttributes{ID: 7}, 1).Return(models.Document{ID: 1}, nil)
+				notifier.EXPECT().NotifyWorker(7, "Ivan Ivanov mentioned you in a comment to document 1: @5 @7 check the receipt")
+			},
+			comment: func(s *CommentService) error {
+				return s.Update(author, 1, 9, models.CommentInput{Body: "@5 @7 check the receipt"})
+			},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			access := mock_services.NewMockDocumentAccess(c)
+			comments := mock_repositories.NewMockCommentInterface(c)
+			notifier := mock_services.NewMockNotifier(c)
+
+			access.EXPECT().GetByID(author, 1).Return(models.Document{ID: 1}, nil)
+			tc.mockBehavior(access, comments, notifier)
+
+			service := NewCommentService(&repositories.Repository{CommentInterface: comments}, access, notifier)
+
+			assert.NoError(t, tc.comment(service))
+		})
+	}
+}
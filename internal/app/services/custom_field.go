@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type CustomFieldService struct {
+	repos *repositories.Repository
+}
+
+func NewCustomFieldService(repos *repositories.Repository) *CustomFieldService {
+	return &CustomFieldService{repos: repos}
+}
+
+func (s *CustomFieldService) Create(fieldInput models.CreateCustomFieldInput) (int, error) {
+	return s.repos.CustomFieldInterface.Create(models.CustomField{
+		Code:     fieldInput.Code,
+		Name:     fieldInput.Name,
+		Type:     fieldInput.Type,
+		Options:  fieldInput.Options,
+		Required: fieldInput.Required,
+	})
+}
+
+func (s *CustomFieldService) GetAll() ([]models.CustomField, error) {
+	return s.repos.CustomFieldInterface.GetAll()
+}
+
+func (s *CustomFieldService) Delete(fieldID int) error {
+	return s.repos.CustomFieldInterface.Delete(fieldID)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type DepartmentService struct {
+	repos *repositories.Repository
+}
+
+func NewDepartmentService(repos *repositories.Repository) *DepartmentService {
+	return &DepartmentService{repos: repos}
+}
+
+func (s *DepartmentService) Create(departmentInput models.CreateDepartmentInput) (int, error) {
+	return s.repos.DepartmentInterface.Create(models.Department{Name: departmentInput.Name})
+}
+
+func (s *DepartmentService) GetAll() ([]models.Department, error) {
+	return s.repos.DepartmentInterface.GetAll()
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type FuelService struct {
+	repos *repositories.Repository
+}
+
+func NewFuelService(repos *repositories.Repository) *FuelService {
+	return &FuelService{repos: repos}
+}
+
+func (s *FuelService) Create(fuelInput models.CreateFuelInput) error {
+	fuel := models.Fuel{
+		Code:   fuelInput.Code,
+		Name:   fuelInput.Name,
+		Unit:   fuelInput.Unit,
+		Active: true,
+	}
+
+	return s.repos.FuelInterface.Create(fuel)
+}
+
+func (s *FuelService) GetAll(onlyActive bool) ([]models.Fuel, error) {
+	return s.repos.FuelInterface.GetAll(onlyActive)
+}
+
+func (s *FuelService) GetByCode(code string) (models.Fuel, error) {
+	return s.repos.FuelInterface.GetByCode(code)
+}
+
+func (s *FuelService) Update(code string, fuelInput models.UpdateFuelInput) error {
+	fuel, err := s.repos.FuelInterface.GetByCode(code)
+	if err != nil {
+		return err
+	}
+
+	fuelInput.ToFuel(&fuel)
+
+	return s.repos.FuelInterface.Update(fuel)
+}
+
+func (s *FuelService) Delete(code string) error {
+	if _, err := s.repos.FuelInterface.GetByCode(code); err != nil {
+		return err
+	}
+
+	return s.repos.FuelInterface.Delete(code)
+}
+
+func (s *FuelService) GetPrices(code string) ([]models.FuelPrice, error) {
+	if _, err := s.repos.FuelInterface.GetByCode(code); err != nil {
+		return nil, err
+	}
+
+	return s.repos.FuelInterface.GetPrices(code)
+}
+
+func (s *FuelService) SetPrice(code string, priceInput models.FuelPriceInput) (int, error) {
+	return s.repos.FuelInterface.SetPrice(models.FuelPrice{
+		FuelCode:      code,
+		Price:         priceInput.Price,
+		EffectiveFrom: priceInput.EffectiveFrom,
+	})
+}
@@ -2,60 +2,638 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"github.com/HeadHardener/tp_lab/configs"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
+	"time"
 )
 
 type GSMService struct {
-	repos *repositories.Repository
+	repos                *repositories.Repository
+	storage              storage.Storage
+	notifier             Notifier
+	trashRetentionDays   int
+	normTolerancePercent float64
+	refuseLimitExceeded  bool
 }
 
-func NewGSMService(repos *repositories.Repository) *GSMService {
-	return &GSMService{repos: repos}
+func NewGSMService(repos *repositories.Repository, storage storage.Storage, notifier Notifier,
+	conf *configs.ServiceConfig) *GSMService {
+	return &GSMService{
+		repos:                repos,
+		storage:              storage,
+		notifier:             notifier,
+		trashRetentionDays:   conf.TrashRetentionDays,
+		normTolerancePercent: conf.NormTolerancePercent,
+		refuseLimitExceeded:  conf.LimitPolicy == configs.LimitRefuse,
+	}
 }
 
 func (s *GSMService) Create(workerID int, docInput models.CreateDocInput) (int, error) {
-	document := models.Document{
-		Car:        docInput.Car,
-		CarID:      docInput.CarID,
-		Waybill:    docInput.Waybill,
-		DriverName: docInput.DriverName,
-		GasAmount:  docInput.GasAmount,
-		GasType:    docInput.GasType,
-		IssueDate:  docInput.IssueDate,
+	defs, err := s.loadDefinitions(len(docInput.Tags) != 0)
+	if err != nil {
+		return 0, err
+	}
+
+	document, err := s.newDocument(docInput, defs)
+	if err != nil {
+		return 0, err
+	}
+
+	alerts, err := s.checkLimits(&document, nil)
+	if err != nil {
+		return 0, err
 	}
 
-	return s.repos.GSMInterface.Create(workerID, document)
+	docID, err := s.repos.GSMInterface.Create(workerID, document)
+	if err != nil {
+		return 0, err
+	}
+	s.notify(alerts)
+
+	return docID, nil
 }
 
-func (s *GSMService) GetAll() ([]models.Document, error) {
-	return s.repos.GSMInterface.GetAll()
+// Import creates documents from already validated rows in one transaction,
+// rows which can't be stored are reported instead of failing the whole import.
+func (s *GSMService) Import(workerID int, rows []models.ImportRow, dryRun bool) (models.ImportReport, error) {
+	report := models.ImportReport{
+		DryRun:      dryRun,
+		Total:       len(rows),
+		DocumentIDs: []int{},
+		Errors:      []models.ImportRowError{},
+	}
+
+	var (
+		documents []models.Document
+		lines     []int
+		alerts    []models.LimitAlert
+	)
+	waybills := make(map[string]int)
+	pending := make(pendingUsage)
+
+	withTags := false
+	for _, row := range rows {
+		withTags = withTags || len(row.Input.Tags) != 0
+	}
+
+	defs, err := s.loadDefinitions(withTags)
+	if err != nil {
+		return models.ImportReport{}, err
+	}
+
+	for _, row := range rows {
+		// values of the custom field columns are text until the field definitions are known
+		if len(row.Input.CustomFields) != 0 {
+			row.Input.CustomFields = row.Input.CustomFields.Parse(defs.fields)
+		}
+
+		document, err := s.newDocument(row.Input, defs)
+		if err != nil {
+			report.Errors = append(report.Errors, models.ImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+
+		// the repository refuses them as well, they're checked here to be reported line by line
+		if err := checkPeriod(s.repos, document.IssueDate); err != nil {
+			if !errors.Is(err, models.ErrPeriodClosed) {
+				return models.ImportReport{}, err
+			}
+			report.Errors = append(report.Errors, models.ImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%d", document.Waybill, time.Time(document.IssueDate).Year())
+		if line, ok := waybills[key]; ok && document.Waybill != 0 {
+			report.Errors = append(report.Errors, models.ImportRowError{
+				Line:    row.Line,
+				Message: fmt.Sprintf("waybill number is already used in line %d", line),
+			})
+			continue
+		}
+		waybills[key] = row.Line
+
+		documentAlerts, err := s.checkLimits(&document, pending)
+		if err != nil {
+			if !errors.Is(err, models.ErrLimitExceeded) {
+				return models.ImportReport{}, err
+			}
+			report.Errors = append(report.Errors, models.ImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+		alerts = append(alerts, documentAlerts...)
+
+		documents = append(documents, document)
+		lines = append(lines, row.Line)
+	}
+
+	if dryRun || len(documents) == 0 {
+		report.Valid = len(documents)
+		return report, nil
+	}
+
+	docIDs, err := s.repos.GSMInterface.CreateBatch(workerID, documents)
+	if err != nil {
+		var batchErr *models.BatchError
+		if !errors.As(err, &batchErr) {
+			return models.ImportReport{}, err
+		}
+
+		report.Errors = append(report.Errors, models.ImportRowError{
+			Line:    lines[batchErr.Index],
+			Message: batchErr.Err.Error(),
+		})
+		return report, nil
+	}
+
+	// rows are valid only once they are stored, the failed batch is rolled back as a whole
+	report.Valid = len(docIDs)
+	report.Imported = len(docIDs)
+	report.DocumentIDs = docIDs
+	s.notify(alerts)
+
+	return report, nil
+}
+
+func (s *GSMService) GetAll(filter models.DocumentFilter) (models.DocumentList, error) {
+	if err := s.parseCustomFilter(&filter); err != nil {
+		return models.DocumentList{}, err
+	}
+
+	documents, total, err := s.repos.GSMInterface.GetAll(filter)
+	if err != nil {
+		return models.DocumentList{}, err
+	}
+
+	return models.DocumentList{
+		Documents:     documents,
+		Total:         total,
+		NextPageToken: filter.NextPageToken(total),
+	}, nil
+}
+
+func (s *GSMService) Export(filter models.DocumentFilter, fn func(models.Document) error) error {
+	if err := s.parseCustomFilter(&filter); err != nil {
+		return err
+	}
+
+	return s.repos.GSMInterface.Export(filter, fn)
+}
+
+// parseCustomFilter types the custom field values of the filter as they are compared with
+// the stored json, a value which can't be of the field type just matches no documents.
+func (s *GSMService) parseCustomFilter(filter *models.DocumentFilter) error {
+	if len(filter.CustomFields) == 0 {
+		return nil
+	}
+
+	fields, err := s.repos.CustomFieldInterface.GetAll()
+	if err != nil {
+		return err
+	}
+	filter.CustomFields = filter.CustomFields.Parse(fields)
+
+	return nil
 }
 
 func (s *GSMService) GetByID(docID int) (models.Document, error) {
 	return s.repos.GSMInterface.GetByID(docID)
 }
 
-func (s *GSMService) GetAllWithID(workerID int) ([]models.Document, error) {
-	return s.repos.GSMInterface.GetAllWithWorkerID(workerID)
+func (s *GSMService) GetAllWithID(workerID int, filter models.DocumentFilter) (models.DocumentList, error) {
+	filter.WorkerID = workerID
+
+	return s.GetAll(filter)
 }
 
-func (s *GSMService) Update(docID int, docInput models.UpdateDocInput) error {
+// Update changes the document if it still has the version the client has read.
+func (s *GSMService) Update(docID, workerID, version int, docInput models.UpdateDocInput) error {
 	document, err := s.repos.GSMInterface.GetByID(docID)
 	if err != nil {
-		return errors.New("document doesn't exist")
+		return err
+	}
+
+	if document.Version != version {
+		return models.ErrVersionMismatch
 	}
 
+	return s.update(workerID, document, docInput, false)
+}
+
+// update applies the input to the document, restore is set when the input brings back a revision,
+// its custom values are checked then without the fields required later.
+func (s *GSMService) update(workerID int, document models.Document, docInput models.UpdateDocInput,
+	restore bool) error {
+	old := document
 	docInput.ToDocument(&document)
 
-	return s.repos.GSMInterface.Update(document)
+	if document.VehicleID != old.VehicleID {
+		if err := s.attachVehicle(&document); err != nil {
+			return err
+		}
+	}
+
+	if document.GasType != old.GasType {
+		if err := s.checkFuel(document.GasType); err != nil {
+			return err
+		}
+	}
+
+	if docInput.Tags != nil && len(document.Tags) != 0 {
+		names, err := s.tagNames()
+		if err != nil {
+			return err
+		}
+
+		if err := checkTags(document.Tags, names); err != nil {
+			return err
+		}
+	}
+
+	// stored values are left as they are, a field made required later doesn't block other changes
+	if docInput.CustomFields != nil && !document.CustomFields.Equal(old.CustomFields) {
+		fields, err := s.repos.CustomFieldInterface.GetAll()
+		if err != nil {
+			return err
+		}
+
+		if err := checkCustomFields(fields, document.CustomFields, !restore); err != nil {
+			return err
+		}
+	}
+
+	if err := document.CheckOdometer(); err != nil {
+		return err
+	}
+
+	if !equalReadings(document.OdometerStart, old.OdometerStart) || document.VehicleID != old.VehicleID ||
+		document.IssueDate != old.IssueDate {
+		if err := s.checkOdometer(document); err != nil {
+			return err
+		}
+	}
+
+	if err := s.checkNorm(&document); err != nil {
+		return err
+	}
+
+	var alerts []models.LimitAlert
+	if document.VehicleID != old.VehicleID || document.GasAmount != old.GasAmount ||
+		document.GasType != old.GasType || document.IssueDate != old.IssueDate {
+		var err error
+		if alerts, err = s.checkLimits(&document, nil); err != nil {
+			return err
+		}
+	}
+
+	if len(models.DiffDocuments(old, document)) == 0 {
+		return nil
+	}
+
+	if err := s.repos.GSMInterface.Update(workerID, document); err != nil {
+		return err
+	}
+	s.notify(alerts)
+
+	return nil
 }
 
-func (s *GSMService) Delete(docID int) error {
-	_, err := s.repos.GSMInterface.GetByID(docID)
+// UpdateOwn updates the document on behalf of the worker, workers and dispatchers can change
+// only their own documents until they are submitted, admins can change any document.
+func (s *GSMService) UpdateOwn(docID, version int, worker models.WorkerAttributes,
+	docInput models.UpdateDocInput) error {
+	if worker.Role != models.RoleAdmin {
+		document, err := s.repos.GSMInterface.GetByID(docID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAuthor(s.repos, docID, worker.ID); err != nil {
+			return err
+		}
+
+		if !models.EditableByAuthor(document.Status) {
+			return models.ErrDocumentReadOnly
+		}
+	}
+
+	return s.Update(docID, worker.ID, version, docInput)
+}
+
+func (s *GSMService) Transition(docID int, worker models.WorkerAttributes, input models.TransitionInput) error {
+	document, err := s.repos.GSMInterface.GetByID(docID)
 	if err != nil {
-		return errors.New("document doesn't exist")
+		return err
+	}
+
+	if err := models.CheckTransition(document.Status, input.Status, worker.Role); err != nil {
+		return err
+	}
+
+	if worker.Role == models.RoleWorker {
+		if err := checkAuthor(s.repos, docID, worker.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.repos.GSMInterface.ChangeStatus(worker.ID, models.Transition{
+		DocumentID: docID,
+		FromStatus: document.Status,
+		ToStatus:   input.Status,
+		Reason:     input.Reason,
+	})
+}
+
+func (s *GSMService) Transitions(docID int) ([]models.Transition, error) {
+	if _, err := s.repos.GSMInterface.GetByID(docID); err != nil {
+		return nil, err
+	}
+
+	return s.repos.TransitionInterface.GetAll(docID)
+}
+
+// History returns revisions of the document together with its comments as a timeline.
+func (s *GSMService) History(docID int) ([]models.TimelineEntry, error) {
+	if _, err := s.repos.GSMInterface.GetByID(docID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repos.RevisionInterface.GetAll(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range revisions {
+		revisions[i].Changes = models.DiffDocuments(
+			models.Document(revisions[i].OldValues),
+			models.Document(revisions[i].NewValues))
+	}
+
+	comments, err := s.repos.CommentInterface.GetAll(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.Timeline(revisions, comments), nil
+}
+
+// RestoreRevision brings the document back to the values it had before the revision,
+// the restore itself is recorded as a new revision.
+func (s *GSMService) RestoreRevision(docID, revisionID, workerID int) error {
+	revision, err := s.repos.RevisionInterface.GetByID(revisionID)
+	if err != nil {
+		return err
+	}
+
+	if revision.DocumentID != docID {
+		return models.ErrRevisionNotFound
+	}
+
+	document, err := s.repos.GSMInterface.GetByID(docID)
+	if err != nil {
+		return err
+	}
+
+	return s.update(workerID, document, revision.OldValues.ToUpdateInput(), true)
+}
+
+func (s *GSMService) Delete(docID, workerID int) error {
+	return s.repos.GSMInterface.Delete(docID, workerID)
+}
+
+func (s *GSMService) Trash(filter models.DocumentFilter) (models.DocumentList, error) {
+	filter.Deleted = true
+
+	return s.GetAll(filter)
+}
+
+func (s *GSMService) Restore(docID, workerID int) error {
+	return s.repos.GSMInterface.Restore(docID, workerID)
+}
+
+// Purge removes documents deleted more than olderThanDays days ago,
+// the configured retention period is used if olderThanDays is nil.
+func (s *GSMService) Purge(olderThanDays *int) (int, error) {
+	days := s.trashRetentionDays
+	if olderThanDays != nil {
+		days = *olderThanDays
+	}
+
+	docIDs, err := s.repos.GSMInterface.Purge(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return 0, err
+	}
+
+	// the documents are gone already, so files which fail to be removed are only left orphaned
+	for _, docID := range docIDs {
+		s.storage.DeletePrefix(attachmentsPrefix(docID))
+	}
+
+	return len(docIDs), nil
+}
+
+// definitions are the tags and custom fields of the organization new documents are checked against,
+// an import loads them once for all of its rows.
+type definitions struct {
+	tags   map[string]bool
+	fields []models.CustomField
+}
+
+// loadDefinitions loads the custom fields of the organization, the tags are loaded only when withTags
+// is set as most documents go without them.
+func (s *GSMService) loadDefinitions(withTags bool) (definitions, error) {
+	fields, err := s.repos.CustomFieldInterface.GetAll()
+	if err != nil {
+		return definitions{}, err
+	}
+	defs := definitions{fields: fields}
+
+	if withTags {
+		if defs.tags, err = s.tagNames(); err != nil {
+			return definitions{}, err
+		}
+	}
+
+	return defs, nil
+}
+
+func (s *GSMService) newDocument(docInput models.CreateDocInput, defs definitions) (models.Document, error) {
+	document := models.Document{
+		VehicleID:     docInput.VehicleID,
+		Waybill:       docInput.Waybill,
+		DriverName:    docInput.DriverName,
+		GasAmount:     docInput.GasAmount,
+		GasType:       docInput.GasType,
+		IssueDate:     docInput.IssueDate,
+		Status:        models.StatusDraft,
+		OdometerStart: docInput.OdometerStart,
+		OdometerEnd:   docInput.OdometerEnd,
+		Tags:          docInput.Tags,
+		CustomFields:  docInput.CustomFields,
+	}
+
+	if err := s.attachVehicle(&document); err != nil {
+		return models.Document{}, err
+	}
+
+	if err := checkTags(document.Tags, defs.tags); err != nil {
+		return models.Document{}, err
+	}
+
+	if err := checkCustomFields(defs.fields, document.CustomFields, true); err != nil {
+		return models.Document{}, err
+	}
+
+	if err := s.checkFuel(document.GasType); err != nil {
+		return models.Document{}, err
+	}
+
+	if err := s.checkOdometer(document); err != nil {
+		return models.Document{}, err
+	}
+
+	if err := s.checkNorm(&document); err != nil {
+		return models.Document{}, err
+	}
+
+	return document, nil
+}
+
+// checkOdometer makes sure the departure reading isn't less than the return reading
+// of the previous waybill of the same vehicle.
+func (s *GSMService) checkOdometer(document models.Document) error {
+	if document.OdometerStart == nil {
+		return nil
+	}
+
+	previous, err := s.repos.GSMInterface.PreviousOdometer(document.VehicleID, document.ID, document.IssueDate)
+	if err != nil {
+		return err
+	}
+
+	if previous != nil && *document.OdometerStart < *previous {
+		return models.ErrOdometerDecreased
+	}
+
+	return nil
+}
+
+// checkNorm flags the document if its consumption exceeds the vehicle norm by more than the tolerance,
+// documents without odometer readings and vehicles without the norm aren't checked.
+func (s *GSMService) checkNorm(document *models.Document) error {
+	document.NormExceeded = false
+
+	consumption, ok := document.FuelConsumption()
+	if !ok {
+		return nil
+	}
+
+	vehicle, err := s.repos.VehicleInterface.GetByID(document.VehicleID)
+	if err != nil {
+		return err
+	}
+
+	if vehicle.ConsumptionNorm != nil {
+		document.NormExceeded = consumption > *vehicle.ConsumptionNorm*(1+s.normTolerancePercent/100)
+	}
+
+	return nil
+}
+
+func equalReadings(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// tagNames returns the names of the tags defined in the organization.
+func (s *GSMService) tagNames() (map[string]bool, error) {
+	defined, err := s.repos.TagInterface.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(defined))
+	for _, tag := range defined {
+		names[tag.Name] = true
+	}
+
+	return names, nil
+}
+
+// checkTags makes sure every tag of the document is one of the names defined in the organization.
+func checkTags(tags []string, names map[string]bool) error {
+	v := &models.ValidationError{}
+	for _, tag := range tags {
+		if !names[tag] {
+			v.Add("tags", models.CodeInvalid, "unknown tag "+tag)
+		}
+	}
+
+	return v.Err()
+}
+
+// checkCustomFields validates the values against the custom fields of the organization,
+// it's done even without values as some of the fields may be required. Without required
+// only the values themselves are checked.
+func checkCustomFields(fields []models.CustomField, values models.CustomValues, required bool) error {
+	if !required {
+		// the definitions may be shared by several documents, so they're changed on a copy
+		fields = append([]models.CustomField(nil), fields...)
+		for i := range fields {
+			fields[i].Required = false
+		}
+	}
+
+	return models.CheckCustomValues(fields, values)
+}
+
+// checkFuel makes sure the gas type is an active fuel of the catalog.
+func (s *GSMService) checkFuel(gasType string) error {
+	fuel, err := s.repos.FuelInterface.GetByCode(gasType)
+	if err != nil {
+		return err
+	}
+
+	if !fuel.Active {
+		return models.ErrFuelInactive
+	}
+
+	return nil
+}
+
+// attachVehicle copies car model and plate number of the document vehicle into the document,
+// so the waybill keeps them even if the vehicle is changed later.
+func (s *GSMService) attachVehicle(document *models.Document) error {
+	vehicle, err := s.repos.VehicleInterface.GetByID(document.VehicleID)
+	if err != nil {
+		return err
+	}
+
+	if !vehicle.Active {
+		return models.ErrVehicleInactive
+	}
+
+	document.Car = vehicle.Model
+	document.CarID = vehicle.PlateNumber
+
+	return nil
+}
+
+// checkAuthor makes sure the document was created by the worker.
+func checkAuthor(repos *repositories.Repository, docID, workerID int) error {
+	authorID, err := repos.GSMInterface.GetAuthorID(docID)
+	if err != nil {
+		return err
+	}
+
+	if authorID != workerID {
+		return models.ErrNotEnoughRights
 	}
 
-	return s.repos.GSMInterface.Delete(docID)
+	return nil
 }
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func toMyTime(value string) models.MyTime {
+	t, _ := time.Parse("2006-01-02", value)
+	return models.MyTime(t)
+}
+
+// documentRepos expects the catalog lookups every new document goes through,
+// the organization has the custom fields given.
+func documentRepos(c *gomock.Controller, customFields ...models.CustomField) (*repositories.Repository,
+	*mock_repositories.MockGSMInterface) {
+	documents := mock_repositories.NewMockGSMInterface(c)
+	vehicles := mock_repositories.NewMockVehicleInterface(c)
+	fuels := mock_repositories.NewMockFuelInterface(c)
+	fields := mock_repositories.NewMockCustomFieldInterface(c)
+	periods := mock_repositories.NewMockPeriodInterface(c)
+	limits := mock_repositories.NewMockLimitInterface(c)
+
+	vehicles.EXPECT().GetByID(1).
+		Return(models.Vehicle{ID: 1, Model: "MAZ", PlateNumber: "1111 AA-1", Active: true}, nil).AnyTimes()
+	fuels.EXPECT().GetByCode("95").Return(models.Fuel{Code: "95", Active: true}, nil).AnyTimes()
+	fields.EXPECT().GetAll().DoAndReturn(func() ([]models.CustomField, error) {
+		return append([]models.CustomField{}, customFields...), nil
+	}).AnyTimes()
+	periods.EXPECT().IsClosed(gomock.Any()).Return(false, nil).AnyTimes()
+	limits.EXPECT().GetForVehicle(1).Return(nil, nil).AnyTimes()
+
+	return &repositories.Repository{
+		GSMInterface:         documents,
+		VehicleInterface:     vehicles,
+		FuelInterface:        fuels,
+		CustomFieldInterface: fields,
+		PeriodInterface:      periods,
+		LimitInterface:       limits,
+	}, documents
+}
+
+func TestGSMService_Import(t *testing.T) {
+	row := func(line, waybill int) models.ImportRow {
+		return models.ImportRow{Line: line, Input: models.CreateDocInput{
+			VehicleID:  1,
+			Waybill:    waybill,
+			DriverName: "test_name",
+			GasAmount:  10,
+			GasType:    "95",
+			IssueDate:  toMyTime("2023-01-01"),
+		}}
+	}
+
+	testTable := []struct {
+		name           string
+		dryRun         bool
+		mockBehavior   func(documents *mock_repositories.MockGSMInterface)
+		expectedReport models.ImportReport
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {
+				documents.EXPECT().CreateBatch(1, gomock.Len(2)).Return([]int{5, 6}, nil)
+			},
+			expectedReport: models.ImportReport{Total: 3, Valid: 2, Imported: 2, DocumentIDs: []int{5, 6},
+				Errors: []models.ImportRowError{{Line: 4, Message: "waybill number is already used in line 2"}}},
+		},
+		{
+			name:         "dry run",
+			dryRun:       true,
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {},
+			expectedReport: models.ImportReport{DryRun: true, Total: 3, Valid: 2, DocumentIDs: []int{},
+				Errors: []models.ImportRowError{{Line: 4, Message: "waybill number is already used in line 2"}}},
+		},
+		{
+			name: "batch rolled back",
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {
+				documents.EXPECT().CreateBatch(1, gomock.Len(2)).
+					Return(nil, &models.BatchError{Index: 1, Err: models.ErrWaybillTaken})
+			},
+			expectedReport: models.ImportReport{Total: 3, DocumentIDs: []int{},
+				Errors: []models.ImportRowError{
+					{Line: 4, Message: "waybill number is already used in line 2"},
+					{Line: 3, Message: "waybill number is already used in this year"},
+				}},
+		},
+		{
+			name: "period closed meanwhile",
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {
+				documents.EXPECT().CreateBatch(1, gomock.Len(2)).
+					Return(nil, &models.BatchError{Index: 0, Err: models.ErrPeriodClosed})
+			},
+			expectedReport: models.ImportReport{Total: 3, DocumentIDs: []int{},
+				Errors: []models.ImportRowError{
+					{Line: 4, Message: "waybill number is already used in line 2"},
+					{Line: 2, Message: models.ErrPeriodClosed.Error()},
+				}},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repos, documents := documentRepos(c)
+			tc.mockBehavior(documents)
+
+			service := &GSMService{repos: repos}
+			report, err := service.Import(1, []models.ImportRow{row(2, 1111), row(3, 1112), row(4, 1111)}, tc.dryRun)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
+
+func TestGSMService_importDefinitions(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	row := func(line int, tags []string, values models.CustomValues) models.ImportRow {
+		return models.ImportRow{Line: line, Input: models.CreateDocInput{
+			VehicleID:    1,
+			Waybill:      1110 + line,
+			DriverName:   "test_name",
+			GasAmount:    10,
+			GasType:      "95",
+			IssueDate:    toMyTime("2023-01-01"),
+			Tags:         tags,
+			CustomFields: values,
+		}}
+	}
+
+	// the definitions are loaded once for all the rows
+	repos, _ := documentRepos(c)
+	fields := mock_repositories.NewMockCustomFieldInterface(c)
+	fields.EXPECT().GetAll().
+		Return([]models.CustomField{{Code: "trip_km", Name: "Trip length", Type: models.CustomNumber, Required: true}}, nil)
+	tags := mock_repositories.NewMockTagInterface(c)
+	tags.EXPECT().GetAll().Return([]models.Tag{{Name: "urgent"}}, nil)
+	repos.CustomFieldInterface = fields
+	repos.TagInterface = tags
+
+	service := &GSMService{repos: repos}
+	report, err := service.Import(1, []models.ImportRow{
+		row(2, []string{"urgent"}, models.CustomValues{"trip_km": "340"}),
+		row(3, []string{"night"}, models.CustomValues{"trip_km": "12"}),
+		row(4, nil, nil),
+	}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ImportReport{DryRun: true, Total: 3, Valid: 1, DocumentIDs: []int{},
+		Errors: []models.ImportRowError{
+			{Line: 3, Message: "unknown tag night"},
+			{Line: 4, Message: "Trip length is required"},
+		}}, report)
+}
+
+func TestGSMService_Delete(t *testing.T) {
+	testTable := []struct {
+		name         string
+		mockBehavior func(documents *mock_repositories.MockGSMInterface)
+		expectedErr  error
+	}{
+		{
+			name: "ok",
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {
+				documents.EXPECT().Delete(5, 2).Return(nil)
+			},
+		},
+		{
+			name: "closed period",
+			mockBehavior: func(documents *mock_repositories.MockGSMInterface) {
+				documents.EXPECT().Delete(5, 2).Return(models.ErrPeriodClosed)
+			},
+			expectedErr: models.ErrPeriodClosed,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			documents := mock_repositories.NewMockGSMInterface(c)
+			tc.mockBehavior(documents)
+
+			service := &GSMService{repos: &repositories.Repository{GSMInterface: documents}}
+
+			assert.Equal(t, tc.expectedErr, service.Delete(5, 2))
+		})
+	}
+}
+
+func TestGSMService_customFields(t *testing.T) {
+	type mockBehavior func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface)
+
+	fields := []models.CustomField{
+		{Code: "card", Name: "Fuel card", Type: models.CustomString, Required: true},
+		{Code: "trip_km", Name: "Trip length", Type: models.CustomNumber},
+	}
+
+	// the document was stored before the card field was made required
+	stored := models.Document{
+		ID:           5,
+		VehicleID:    1,
+		Waybill:      1111,
+		DriverName:   "test_name",
+		GasAmount:    10,
+		GasType:      "95",
+		IssueDate:    toMyTime("2023-01-01"),
+		Version:      3,
+		CustomFields: models.CustomValues{"trip_km": 340.0},
+	}
+
+	revision := func(values models.CustomValues) models.Revision {
+		old := stored
+		old.CustomFields = values
+		return models.Revision{ID: 9, DocumentID: 5, OldValues: models.Snapshot(old)}
+	}
+
+	testTable := []struct {
+		name         string
+		call         func(s *GSMService) error
+		mockBehavior mockBehavior
+		expectedErr  string
+	}{
+		{
+			name: "values unchanged",
+			call: func(s *GSMService) error {
+				driver := "other_name"
+				return s.Update(5, 2, 3, models.UpdateDocInput{
+					DriverName:   &driver,
+					CustomFields: models.CustomValues{"trip_km": 340.0},
+				})
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				d.EXPECT().GetByID(5).Return(stored, nil)
+				d.EXPECT().Update(2, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "values changed without required",
+			call: func(s *GSMService) error {
+				return s.Update(5, 2, 3, models.UpdateDocInput{CustomFields: models.CustomValues{"trip_km": 400.0}})
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				d.EXPECT().GetByID(5).Return(stored, nil)
+			},
+			expectedErr: "Fuel card is required",
+		},
+		{
+			name: "revision restored without required",
+			call: func(s *GSMService) error {
+				return s.RestoreRevision(5, 9, 2)
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				r.EXPECT().GetByID(9).Return(revision(models.CustomValues{"trip_km": 120.0}), nil)
+				d.EXPECT().GetByID(5).Return(stored, nil)
+				d.EXPECT().Update(2, gomock.Any()).DoAndReturn(func(workerID int, document models.Document) error {
+					assert.Equal(t, models.CustomValues{"trip_km": 120.0}, document.CustomFields)
+					return nil
+				})
+			},
+		},
+		{
+			name: "revision restored with invalid value",
+			call: func(s *GSMService) error {
+				return s.RestoreRevision(5, 9, 2)
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				r.EXPECT().GetByID(9).Return(revision(models.CustomValues{"trip_km": "far"}), nil)
+				d.EXPECT().GetByID(5).Return(stored, nil)
+			},
+			expectedErr: "Trip length has to be a number",
+		},
+		{
+			name: "import typed by definitions",
+			call: func(s *GSMService) error {
+				_, err := s.Import(2, []models.ImportRow{{Line: 2, Input: models.CreateDocInput{
+					VehicleID:    1,
+					DriverName:   "test_name",
+					GasAmount:    10,
+					GasType:      "95",
+					IssueDate:    toMyTime("2023-01-01"),
+					CustomFields: models.CustomValues{"card": "0012", "trip_km": "340"},
+				}}}, false)
+				return err
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				d.EXPECT().CreateBatch(2, gomock.Len(1)).DoAndReturn(
+					func(workerID int, documents []models.Document) ([]int, error) {
+						assert.Equal(t, models.CustomValues{"card": "0012", "trip_km": 340.0}, documents[0].CustomFields)
+						return []int{6}, nil
+					})
+			},
+		},
+		{
+			name: "filter typed by definitions",
+			call: func(s *GSMService) error {
+				_, err := s.GetAll(models.DocumentFilter{
+					CustomFields: models.CustomValues{"card": "0012", "trip_km": "340"},
+				})
+				return err
+			},
+			mockBehavior: func(d *mock_repositories.MockGSMInterface, r *mock_repositories.MockRevisionInterface) {
+				d.EXPECT().GetAll(models.DocumentFilter{
+					CustomFields: models.CustomValues{"card": "0012", "trip_km": 340.0},
+				}).Return([]models.Document{}, 0, nil)
+			},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repos, documents := documentRepos(c, fields...)
+			revisions := mock_repositories.NewMockRevisionInterface(c)
+			repos.RevisionInterface = revisions
+			tc.mockBehavior(documents, revisions)
+
+			service := &GSMService{repos: repos}
+			err := tc.call(service)
+
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"time"
+)
+
+type LimitService struct {
+	repos *repositories.Repository
+}
+
+func NewLimitService(repos *repositories.Repository) *LimitService {
+	return &LimitService{repos: repos}
+}
+
+func (s *LimitService) Create(limitInput models.CreateLimitInput) (int, error) {
+	if limitInput.VehicleID != nil {
+		if _, err := s.repos.VehicleInterface.GetByID(*limitInput.VehicleID); err != nil {
+			return 0, err
+		}
+	}
+
+	if limitInput.DepartmentID != nil {
+		if _, err := s.repos.DepartmentInterface.GetByID(*limitInput.DepartmentID); err != nil {
+			return 0, err
+		}
+	}
+
+	alertPercent := float64(models.DefaultAlertPercent)
+	if limitInput.AlertPercent != nil {
+		alertPercent = *limitInput.AlertPercent
+	}
+
+	return s.repos.LimitInterface.Create(models.Limit{
+		VehicleID:    limitInput.VehicleID,
+		DepartmentID: limitInput.DepartmentID,
+		Period:       limitInput.Period,
+		Unit:         limitInput.Unit,
+		Amount:       limitInput.Amount,
+		AlertPercent: alertPercent,
+	})
+}
+
+func (s *LimitService) GetAll() ([]models.Limit, error) {
+	return s.repos.LimitInterface.GetAll()
+}
+
+func (s *LimitService) Delete(limitID int) error {
+	return s.repos.LimitInterface.Delete(limitID)
+}
+
+// Dashboard returns usage of every limit in its period containing the date.
+func (s *LimitService) Dashboard(date models.MyTime) ([]models.LimitUsage, error) {
+	limits, err := s.repos.LimitInterface.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]models.LimitUsage, 0, len(limits))
+	for _, limit := range limits {
+		from, to := limit.Bounds(date)
+
+		used, err := s.repos.LimitInterface.Usage(limit, from, to, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, models.LimitUsage{
+			Limit:   limit,
+			From:    models.MyTime(from),
+			To:      models.MyTime(to.AddDate(0, 0, -1)),
+			Used:    used,
+			Percent: used * 100 / limit.Amount,
+			State:   limit.State(used),
+		})
+	}
+
+	return usages, nil
+}
+
+// pendingUsage keeps usage of documents which aren't stored yet, like the previous rows of an import,
+// by the limit and the start of its period.
+type pendingUsage map[string]float64
+
+// checkLimits counts the document against the limits of its vehicle and the vehicle's department.
+// Documents which take a limit over the amount are refused or flagged depending on the policy,
+// the alerts have to be sent once the document is stored. The repository counts the document
+// once more under the lock of the limit, so concurrent documents can't take it over together.
+func (s *GSMService) checkLimits(document *models.Document, pending pendingUsage) ([]models.LimitAlert, error) {
+	document.LimitExceeded = false
+
+	if document.Status == models.StatusRejected {
+		return nil, nil
+	}
+
+	limits, err := s.repos.LimitInterface.GetForVehicle(document.VehicleID)
+	if err != nil || len(limits) == 0 {
+		return nil, err
+	}
+
+	var (
+		alerts []models.LimitAlert
+		added  = make(map[string]float64)
+	)
+	for _, limit := range limits {
+		from, to := limit.Bounds(document.IssueDate)
+		key := fmt.Sprintf("%d/%s", limit.ID, from.Format(models.PeriodLayout))
+
+		used, err := s.repos.LimitInterface.Usage(limit, from, to, document.ID)
+		if err != nil {
+			return nil, err
+		}
+		used += pending[key]
+
+		// the stored version of an updated document is counted as used before the update
+		before := used
+		if document.ID != 0 {
+			if before, err = s.repos.LimitInterface.Usage(limit, from, to, 0); err != nil {
+				return nil, err
+			}
+		}
+
+		amount, err := s.documentAmount(*document, limit.Unit)
+		if err != nil {
+			return nil, err
+		}
+		after := used + amount
+		added[key] = amount
+
+		alert := models.LimitAlert{Limit: limit, From: from, Used: after, Exceeded: after > limit.Amount}
+		if alert.Exceeded {
+			if s.refuseLimitExceeded {
+				return nil, fmt.Errorf("%w: %s", models.ErrLimitExceeded, alert)
+			}
+			document.LimitExceeded = true
+		}
+
+		if limit.State(before) != limit.State(after) && limit.State(after) != "ok" {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	if pending != nil {
+		for key, amount := range added {
+			pending[key] += amount
+		}
+	}
+
+	return alerts, nil
+}
+
+// documentAmount returns liters of the document or their cost at the price in effect on the issue date,
+// documents without a price cost nothing like in the reports.
+func (s *GSMService) documentAmount(document models.Document, unit string) (float64, error) {
+	if unit == models.LimitLiters {
+		return float64(document.GasAmount), nil
+	}
+
+	prices, err := s.repos.FuelInterface.GetPrices(document.GasType)
+	if err != nil {
+		return 0, err
+	}
+
+	// prices go from the latest one
+	for _, price := range prices {
+		if !time.Time(price.EffectiveFrom).After(time.Time(document.IssueDate)) {
+			return float64(document.GasAmount) * price.Price, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *GSMService) notify(alerts []models.LimitAlert) {
+	for _, alert := range alerts {
+		s.notifier.Notify(alert.String())
+	}
+}
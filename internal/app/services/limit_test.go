@@ -0,0 +1,179 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGSMService_checkLimits(t *testing.T) {
+	vehicleID, departmentID := 1, 3
+	vehicleLimit := models.Limit{ID: 1, VehicleID: &vehicleID, Period: models.LimitMonth,
+		Unit: models.LimitLiters, Amount: 100, AlertPercent: 80}
+	departmentLimit := models.Limit{ID: 2, DepartmentID: &departmentID, Period: models.LimitQuarter,
+		Unit: models.LimitMoney, Amount: 1000, AlertPercent: 80}
+
+	month, nextMonth := time.Time(toMyTime("2023-02-01")), time.Time(toMyTime("2023-03-01"))
+	quarter, nextQuarter := time.Time(toMyTime("2023-01-01")), time.Time(toMyTime("2023-04-01"))
+
+	document := func(id, amount int, status string) models.Document {
+		return models.Document{ID: id, VehicleID: 1, GasAmount: amount, GasType: "95",
+			IssueDate: toMyTime("2023-02-10"), Status: status, LimitExceeded: true}
+	}
+
+	type mockBehavior func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface)
+
+	testTable := []struct {
+		name             string
+		document         models.Document
+		refuse           bool
+		pending          pendingUsage
+		mockBehavior     mockBehavior
+		expectedAlerts   []models.LimitAlert
+		expectedExceeded bool
+		expectedPending  pendingUsage
+		expectedErr      string
+	}{
+		{
+			name:     "no limits",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return(nil, nil)
+			},
+		},
+		{
+			name:     "alert share crossed",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(75.0, nil)
+			},
+			expectedAlerts: []models.LimitAlert{{Limit: vehicleLimit, From: month, Used: 85}},
+		},
+		{
+			name:     "alert share crossed before",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(80.0, nil)
+			},
+		},
+		{
+			name:     "exceeded is flagged",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(95.0, nil)
+			},
+			expectedAlerts:   []models.LimitAlert{{Limit: vehicleLimit, From: month, Used: 105, Exceeded: true}},
+			expectedExceeded: true,
+		},
+		{
+			name:     "exceeded is refused",
+			document: document(0, 10, models.StatusDraft),
+			refuse:   true,
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(95.0, nil)
+			},
+			expectedErr: "document exceeds the fuel limit: " +
+				"vehicle 1 has exceeded the month liters limit of 100.00 in 2023-02: 105.00 used",
+		},
+		{
+			name:     "department limit counted in money",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{departmentLimit}, nil)
+				limits.EXPECT().Usage(departmentLimit, quarter, nextQuarter, 0).Return(700.0, nil)
+				fuels.EXPECT().GetPrices("95").Return([]models.FuelPrice{
+					{Price: 12, EffectiveFrom: toMyTime("2023-03-01")},
+					{Price: 10, EffectiveFrom: toMyTime("2023-01-01")},
+				}, nil)
+			},
+			expectedAlerts: []models.LimitAlert{{Limit: departmentLimit, From: quarter, Used: 800}},
+		},
+		{
+			name:     "department limit refused",
+			document: document(0, 10, models.StatusDraft),
+			refuse:   true,
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{departmentLimit}, nil)
+				limits.EXPECT().Usage(departmentLimit, quarter, nextQuarter, 0).Return(950.0, nil)
+				fuels.EXPECT().GetPrices("95").Return([]models.FuelPrice{
+					{Price: 10, EffectiveFrom: toMyTime("2023-01-01")},
+				}, nil)
+			},
+			expectedErr: "document exceeds the fuel limit: " +
+				"department 3 has exceeded the quarter money limit of 1000.00 in 2023 Q1: 1050.00 used",
+		},
+		{
+			name:     "no price in effect",
+			document: document(0, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{departmentLimit}, nil)
+				limits.EXPECT().Usage(departmentLimit, quarter, nextQuarter, 0).Return(950.0, nil)
+				fuels.EXPECT().GetPrices("95").Return([]models.FuelPrice{
+					{Price: 12, EffectiveFrom: toMyTime("2023-03-01")},
+				}, nil)
+			},
+		},
+		{
+			name:     "previous rows of the import",
+			document: document(0, 10, models.StatusDraft),
+			pending:  pendingUsage{"1/2023-02": 60, "1/2023-01": 30},
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(15.0, nil)
+			},
+			expectedAlerts:  []models.LimitAlert{{Limit: vehicleLimit, From: month, Used: 85}},
+			expectedPending: pendingUsage{"1/2023-02": 70, "1/2023-01": 30},
+		},
+		{
+			name:     "updated document",
+			document: document(5, 10, models.StatusDraft),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {
+				limits.EXPECT().GetForVehicle(1).Return([]models.Limit{vehicleLimit}, nil)
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 5).Return(70.0, nil)
+				// the stored version has crossed the alert share already
+				limits.EXPECT().Usage(vehicleLimit, month, nextMonth, 0).Return(85.0, nil)
+			},
+		},
+		{
+			name:         "rejected document",
+			document:     document(5, 10, models.StatusRejected),
+			mockBehavior: func(limits *mock_repositories.MockLimitInterface, fuels *mock_repositories.MockFuelInterface) {},
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			limits := mock_repositories.NewMockLimitInterface(c)
+			fuels := mock_repositories.NewMockFuelInterface(c)
+			tc.mockBehavior(limits, fuels)
+
+			service := &GSMService{
+				repos:               &repositories.Repository{LimitInterface: limits, FuelInterface: fuels},
+				refuseLimitExceeded: tc.refuse,
+			}
+
+			document := tc.document
+			alerts, err := service.checkLimits(&document, tc.pending)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedAlerts, alerts)
+			assert.Equal(t, tc.expectedExceeded, document.LimitExceeded)
+			assert.Equal(t, tc.expectedPending, tc.pending)
+		})
+	}
+}
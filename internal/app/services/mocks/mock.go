@@ -5,7 +5,9 @@
 package mock_services
 
 import (
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/HeadHardener/tp_lab/internal/app/models"
 	gomock "github.com/golang/mock/gomock"
@@ -102,6 +104,20 @@ func (mr *MockAdministrationMockRecorder) CreateWorker(workerInput interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorker", reflect.TypeOf((*MockAdministration)(nil).CreateWorker), workerInput)
 }
 
+// ExportWorkers mocks base method.
+func (m *MockAdministration) ExportWorkers(fn func(models.Worker) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportWorkers", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportWorkers indicates an expected call of ExportWorkers.
+func (mr *MockAdministrationMockRecorder) ExportWorkers(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportWorkers", reflect.TypeOf((*MockAdministration)(nil).ExportWorkers), fn)
+}
+
 // GetAll mocks base method.
 func (m *MockAdministration) GetAll() ([]models.Worker, error) {
 	m.ctrl.T.Helper()
@@ -133,17 +149,85 @@ func (mr *MockAdministrationMockRecorder) GetByID(workerID interface{}) *gomock.
 }
 
 // UpdateWorker mocks base method.
-func (m *MockAdministration) UpdateWorker(workerID int, workerInput models.UpdateWorkerInput) error {
+func (m *MockAdministration) UpdateWorker(workerID, version int, workerInput models.UpdateWorkerInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWorker", workerID, workerInput)
+	ret := m.ctrl.Call(m, "UpdateWorker", workerID, version, workerInput)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateWorker indicates an expected call of UpdateWorker.
-func (mr *MockAdministrationMockRecorder) UpdateWorker(workerID, workerInput interface{}) *gomock.Call {
+func (mr *MockAdministrationMockRecorder) UpdateWorker(workerID, version, workerInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorker", reflect.TypeOf((*MockAdministration)(nil).UpdateWorker), workerID, version, workerInput)
+}
+
+// MockOrganizations is a mock of Organizations interface.
+type MockOrganizations struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationsMockRecorder
+}
+
+// MockOrganizationsMockRecorder is the mock recorder for MockOrganizations.
+type MockOrganizationsMockRecorder struct {
+	mock *MockOrganizations
+}
+
+// NewMockOrganizations creates a new mock instance.
+func NewMockOrganizations(ctrl *gomock.Controller) *MockOrganizations {
+	mock := &MockOrganizations{ctrl: ctrl}
+	mock.recorder = &MockOrganizationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizations) EXPECT() *MockOrganizationsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizations) Create(organizationInput models.CreateOrganizationInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", organizationInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationsMockRecorder) Create(organizationInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizations)(nil).Create), organizationInput)
+}
+
+// CreateWorker mocks base method.
+func (m *MockOrganizations) CreateWorker(orgID int, workerInput models.CreateWorkerInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorker", orgID, workerInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorker indicates an expected call of CreateWorker.
+func (mr *MockOrganizationsMockRecorder) CreateWorker(orgID, workerInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorker", reflect.TypeOf((*MockOrganizations)(nil).CreateWorker), orgID, workerInput)
+}
+
+// GetAll mocks base method.
+func (m *MockOrganizations) GetAll() ([]models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockOrganizationsMockRecorder) GetAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorker", reflect.TypeOf((*MockAdministration)(nil).UpdateWorker), workerID, workerInput)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockOrganizations)(nil).GetAll))
 }
 
 // MockGSMInterface is a mock of GSMInterface interface.
@@ -185,47 +269,61 @@ func (mr *MockGSMInterfaceMockRecorder) Create(workerID, docInput interface{}) *
 }
 
 // Delete mocks base method.
-func (m *MockGSMInterface) Delete(docID int) error {
+func (m *MockGSMInterface) Delete(docID, workerID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", docID)
+	ret := m.ctrl.Call(m, "Delete", docID, workerID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockGSMInterfaceMockRecorder) Delete(docID interface{}) *gomock.Call {
+func (mr *MockGSMInterfaceMockRecorder) Delete(docID, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGSMInterface)(nil).Delete), docID, workerID)
+}
+
+// Export mocks base method.
+func (m *MockGSMInterface) Export(filter models.DocumentFilter, fn func(models.Document) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockGSMInterfaceMockRecorder) Export(filter, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGSMInterface)(nil).Delete), docID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockGSMInterface)(nil).Export), filter, fn)
 }
 
 // GetAll mocks base method.
-func (m *MockGSMInterface) GetAll() ([]models.Document, error) {
+func (m *MockGSMInterface) GetAll(filter models.DocumentFilter) (models.DocumentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]models.Document)
+	ret := m.ctrl.Call(m, "GetAll", filter)
+	ret0, _ := ret[0].(models.DocumentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockGSMInterfaceMockRecorder) GetAll() *gomock.Call {
+func (mr *MockGSMInterfaceMockRecorder) GetAll(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGSMInterface)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockGSMInterface)(nil).GetAll), filter)
 }
 
 // GetAllWithID mocks base method.
-func (m *MockGSMInterface) GetAllWithID(workerID int) ([]models.Document, error) {
+func (m *MockGSMInterface) GetAllWithID(workerID int, filter models.DocumentFilter) (models.DocumentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllWithID", workerID)
-	ret0, _ := ret[0].([]models.Document)
+	ret := m.ctrl.Call(m, "GetAllWithID", workerID, filter)
+	ret0, _ := ret[0].(models.DocumentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllWithID indicates an expected call of GetAllWithID.
-func (mr *MockGSMInterfaceMockRecorder) GetAllWithID(workerID interface{}) *gomock.Call {
+func (mr *MockGSMInterfaceMockRecorder) GetAllWithID(workerID, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWithID", reflect.TypeOf((*MockGSMInterface)(nil).GetAllWithID), workerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWithID", reflect.TypeOf((*MockGSMInterface)(nil).GetAllWithID), workerID, filter)
 }
 
 // GetByID mocks base method.
@@ -243,16 +341,1310 @@ func (mr *MockGSMInterfaceMockRecorder) GetByID(docID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGSMInterface)(nil).GetByID), docID)
 }
 
+// History mocks base method.
+func (m *MockGSMInterface) History(docID int) ([]models.TimelineEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", docID)
+	ret0, _ := ret[0].([]models.TimelineEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockGSMInterfaceMockRecorder) History(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockGSMInterface)(nil).History), docID)
+}
+
+// Import mocks base method.
+func (m *MockGSMInterface) Import(workerID int, rows []models.ImportRow, dryRun bool) (models.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", workerID, rows, dryRun)
+	ret0, _ := ret[0].(models.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockGSMInterfaceMockRecorder) Import(workerID, rows, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockGSMInterface)(nil).Import), workerID, rows, dryRun)
+}
+
+// Purge mocks base method.
+func (m *MockGSMInterface) Purge(olderThanDays *int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", olderThanDays)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockGSMInterfaceMockRecorder) Purge(olderThanDays interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockGSMInterface)(nil).Purge), olderThanDays)
+}
+
+// Restore mocks base method.
+func (m *MockGSMInterface) Restore(docID, workerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", docID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockGSMInterfaceMockRecorder) Restore(docID, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockGSMInterface)(nil).Restore), docID, workerID)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockGSMInterface) RestoreRevision(docID, revisionID, workerID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", docID, revisionID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockGSMInterfaceMockRecorder) RestoreRevision(docID, revisionID, workerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockGSMInterface)(nil).RestoreRevision), docID, revisionID, workerID)
+}
+
+// Transition mocks base method.
+func (m *MockGSMInterface) Transition(docID int, worker models.WorkerAttributes, input models.TransitionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", docID, worker, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockGSMInterfaceMockRecorder) Transition(docID, worker, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockGSMInterface)(nil).Transition), docID, worker, input)
+}
+
+// Transitions mocks base method.
+func (m *MockGSMInterface) Transitions(docID int) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transitions", docID)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transitions indicates an expected call of Transitions.
+func (mr *MockGSMInterfaceMockRecorder) Transitions(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transitions", reflect.TypeOf((*MockGSMInterface)(nil).Transitions), docID)
+}
+
+// Trash mocks base method.
+func (m *MockGSMInterface) Trash(filter models.DocumentFilter) (models.DocumentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", filter)
+	ret0, _ := ret[0].(models.DocumentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockGSMInterfaceMockRecorder) Trash(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockGSMInterface)(nil).Trash), filter)
+}
+
 // Update mocks base method.
-func (m *MockGSMInterface) Update(docID int, docInput models.UpdateDocInput) error {
+func (m *MockGSMInterface) Update(docID, workerID, version int, docInput models.UpdateDocInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", docID, docInput)
+	ret := m.ctrl.Call(m, "Update", docID, workerID, version, docInput)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockGSMInterfaceMockRecorder) Update(docID, docInput interface{}) *gomock.Call {
+func (mr *MockGSMInterfaceMockRecorder) Update(docID, workerID, version, docInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGSMInterface)(nil).Update), docID, workerID, version, docInput)
+}
+
+// UpdateOwn mocks base method.
+func (m *MockGSMInterface) UpdateOwn(docID, version int, worker models.WorkerAttributes, docInput models.UpdateDocInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwn", docID, version, worker, docInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOwn indicates an expected call of UpdateOwn.
+func (mr *MockGSMInterfaceMockRecorder) UpdateOwn(docID, version, worker, docInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwn", reflect.TypeOf((*MockGSMInterface)(nil).UpdateOwn), docID, version, worker, docInput)
+}
+
+// MockDocumentAccess is a mock of DocumentAccess interface.
+type MockDocumentAccess struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentAccessMockRecorder
+}
+
+// MockDocumentAccessMockRecorder is the mock recorder for MockDocumentAccess.
+type MockDocumentAccessMockRecorder struct {
+	mock *MockDocumentAccess
+}
+
+// NewMockDocumentAccess creates a new mock instance.
+func NewMockDocumentAccess(ctrl *gomock.Controller) *MockDocumentAccess {
+	mock := &MockDocumentAccess{ctrl: ctrl}
+	mock.recorder = &MockDocumentAccessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentAccess) EXPECT() *MockDocumentAccessMockRecorder {
+	return m.recorder
+}
+
+// Attachments mocks base method.
+func (m *MockDocumentAccess) Attachments(worker models.WorkerAttributes, docID int) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attachments", worker, docID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attachments indicates an expected call of Attachments.
+func (mr *MockDocumentAccessMockRecorder) Attachments(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachments", reflect.TypeOf((*MockDocumentAccess)(nil).Attachments), worker, docID)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockDocumentAccess) DeleteAttachment(worker models.WorkerAttributes, docID, attachmentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", worker, docID, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockDocumentAccessMockRecorder) DeleteAttachment(worker, docID, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockDocumentAccess)(nil).DeleteAttachment), worker, docID, attachmentID)
+}
+
+// Download mocks base method.
+func (m *MockDocumentAccess) Download(worker models.WorkerAttributes, docID, attachmentID int) (models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", worker, docID, attachmentID)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockDocumentAccessMockRecorder) Download(worker, docID, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockDocumentAccess)(nil).Download), worker, docID, attachmentID)
+}
+
+// Export mocks base method.
+func (m *MockDocumentAccess) Export(worker models.WorkerAttributes, filter models.DocumentFilter, fn func(models.Document) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", worker, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockDocumentAccessMockRecorder) Export(worker, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockDocumentAccess)(nil).Export), worker, filter, fn)
+}
+
+// GetAll mocks base method.
+func (m *MockDocumentAccess) GetAll(worker models.WorkerAttributes, filter models.DocumentFilter) (models.DocumentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", worker, filter)
+	ret0, _ := ret[0].(models.DocumentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDocumentAccessMockRecorder) GetAll(worker, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDocumentAccess)(nil).GetAll), worker, filter)
+}
+
+// GetByID mocks base method.
+func (m *MockDocumentAccess) GetByID(worker models.WorkerAttributes, docID int) (models.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", worker, docID)
+	ret0, _ := ret[0].(models.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDocumentAccessMockRecorder) GetByID(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDocumentAccess)(nil).GetByID), worker, docID)
+}
+
+// History mocks base method.
+func (m *MockDocumentAccess) History(worker models.WorkerAttributes, docID int) ([]models.TimelineEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", worker, docID)
+	ret0, _ := ret[0].([]models.TimelineEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockDocumentAccessMockRecorder) History(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockDocumentAccess)(nil).History), worker, docID)
+}
+
+// Transition mocks base method.
+func (m *MockDocumentAccess) Transition(worker models.WorkerAttributes, docID int, input models.TransitionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", worker, docID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockDocumentAccessMockRecorder) Transition(worker, docID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockDocumentAccess)(nil).Transition), worker, docID, input)
+}
+
+// Transitions mocks base method.
+func (m *MockDocumentAccess) Transitions(worker models.WorkerAttributes, docID int) ([]models.Transition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transitions", worker, docID)
+	ret0, _ := ret[0].([]models.Transition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transitions indicates an expected call of Transitions.
+func (mr *MockDocumentAccessMockRecorder) Transitions(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transitions", reflect.TypeOf((*MockDocumentAccess)(nil).Transitions), worker, docID)
+}
+
+// UpdateOwn mocks base method.
+func (m *MockDocumentAccess) UpdateOwn(worker models.WorkerAttributes, docID, version int, docInput models.UpdateDocInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwn", worker, docID, version, docInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOwn indicates an expected call of UpdateOwn.
+func (mr *MockDocumentAccessMockRecorder) UpdateOwn(worker, docID, version, docInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwn", reflect.TypeOf((*MockDocumentAccess)(nil).UpdateOwn), worker, docID, version, docInput)
+}
+
+// Upload mocks base method.
+func (m *MockDocumentAccess) Upload(worker models.WorkerAttributes, docID int, fileName string, content io.Reader) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", worker, docID, fileName, content)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockDocumentAccessMockRecorder) Upload(worker, docID, fileName, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockDocumentAccess)(nil).Upload), worker, docID, fileName, content)
+}
+
+// WaybillPDF mocks base method.
+func (m *MockDocumentAccess) WaybillPDF(worker models.WorkerAttributes, docID int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaybillPDF", worker, docID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaybillPDF indicates an expected call of WaybillPDF.
+func (mr *MockDocumentAccessMockRecorder) WaybillPDF(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaybillPDF", reflect.TypeOf((*MockDocumentAccess)(nil).WaybillPDF), worker, docID)
+}
+
+// MockComments is a mock of Comments interface.
+type MockComments struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentsMockRecorder
+}
+
+// MockCommentsMockRecorder is the mock recorder for MockComments.
+type MockCommentsMockRecorder struct {
+	mock *MockComments
+}
+
+// NewMockComments creates a new mock instance.
+func NewMockComments(ctrl *gomock.Controller) *MockComments {
+	mock := &MockComments{ctrl: ctrl}
+	mock.recorder = &MockCommentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComments) EXPECT() *MockCommentsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockComments) Create(worker models.WorkerAttributes, docID int, commentInput models.CommentInput) (models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", worker, docID, commentInput)
+	ret0, _ := ret[0].(models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentsMockRecorder) Create(worker, docID, commentInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComments)(nil).Create), worker, docID, commentInput)
+}
+
+// Delete mocks base method.
+func (m *MockComments) Delete(worker models.WorkerAttributes, docID, commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", worker, docID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentsMockRecorder) Delete(worker, docID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComments)(nil).Delete), worker, docID, commentID)
+}
+
+// GetAll mocks base method.
+func (m *MockComments) GetAll(worker models.WorkerAttributes, docID int) ([]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", worker, docID)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCommentsMockRecorder) GetAll(worker, docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockComments)(nil).GetAll), worker, docID)
+}
+
+// Update mocks base method.
+func (m *MockComments) Update(worker models.WorkerAttributes, docID, commentID int, commentInput models.CommentInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", worker, docID, commentID, commentInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentsMockRecorder) Update(worker, docID, commentID, commentInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockComments)(nil).Update), worker, docID, commentID, commentInput)
+}
+
+// MockAttachments is a mock of Attachments interface.
+type MockAttachments struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsMockRecorder
+}
+
+// MockAttachmentsMockRecorder is the mock recorder for MockAttachments.
+type MockAttachmentsMockRecorder struct {
+	mock *MockAttachments
+}
+
+// NewMockAttachments creates a new mock instance.
+func NewMockAttachments(ctrl *gomock.Controller) *MockAttachments {
+	mock := &MockAttachments{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachments) EXPECT() *MockAttachmentsMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAttachments) Delete(docID, attachmentID int, worker models.WorkerAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", docID, attachmentID, worker)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentsMockRecorder) Delete(docID, attachmentID, worker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachments)(nil).Delete), docID, attachmentID, worker)
+}
+
+// Download mocks base method.
+func (m *MockAttachments) Download(docID, attachmentID int) (models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", docID, attachmentID)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockAttachmentsMockRecorder) Download(docID, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachments)(nil).Download), docID, attachmentID)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachments) GetAll(docID int) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", docID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentsMockRecorder) GetAll(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachments)(nil).GetAll), docID)
+}
+
+// Upload mocks base method.
+func (m *MockAttachments) Upload(docID int, worker models.WorkerAttributes, fileName string, content io.Reader) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", docID, worker, fileName, content)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentsMockRecorder) Upload(docID, worker, fileName, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachments)(nil).Upload), docID, worker, fileName, content)
+}
+
+// MockVehicleInterface is a mock of VehicleInterface interface.
+type MockVehicleInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleInterfaceMockRecorder
+}
+
+// MockVehicleInterfaceMockRecorder is the mock recorder for MockVehicleInterface.
+type MockVehicleInterfaceMockRecorder struct {
+	mock *MockVehicleInterface
+}
+
+// NewMockVehicleInterface creates a new mock instance.
+func NewMockVehicleInterface(ctrl *gomock.Controller) *MockVehicleInterface {
+	mock := &MockVehicleInterface{ctrl: ctrl}
+	mock.recorder = &MockVehicleInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleInterface) EXPECT() *MockVehicleInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVehicleInterface) Create(vehicleInput models.CreateVehicleInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vehicleInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleInterfaceMockRecorder) Create(vehicleInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicleInterface)(nil).Create), vehicleInput)
+}
+
+// Delete mocks base method.
+func (m *MockVehicleInterface) Delete(vehicleID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", vehicleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleInterfaceMockRecorder) Delete(vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleInterface)(nil).Delete), vehicleID)
+}
+
+// GetAll mocks base method.
+func (m *MockVehicleInterface) GetAll(onlyActive bool) ([]models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", onlyActive)
+	ret0, _ := ret[0].([]models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockVehicleInterfaceMockRecorder) GetAll(onlyActive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockVehicleInterface)(nil).GetAll), onlyActive)
+}
+
+// GetByID mocks base method.
+func (m *MockVehicleInterface) GetByID(vehicleID int) (models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", vehicleID)
+	ret0, _ := ret[0].(models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVehicleInterfaceMockRecorder) GetByID(vehicleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicleInterface)(nil).GetByID), vehicleID)
+}
+
+// Update mocks base method.
+func (m *MockVehicleInterface) Update(vehicleID int, vehicleInput models.UpdateVehicleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vehicleID, vehicleInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleInterfaceMockRecorder) Update(vehicleID, vehicleInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleInterface)(nil).Update), vehicleID, vehicleInput)
+}
+
+// MockFuelInterface is a mock of FuelInterface interface.
+type MockFuelInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFuelInterfaceMockRecorder
+}
+
+// MockFuelInterfaceMockRecorder is the mock recorder for MockFuelInterface.
+type MockFuelInterfaceMockRecorder struct {
+	mock *MockFuelInterface
+}
+
+// NewMockFuelInterface creates a new mock instance.
+func NewMockFuelInterface(ctrl *gomock.Controller) *MockFuelInterface {
+	mock := &MockFuelInterface{ctrl: ctrl}
+	mock.recorder = &MockFuelInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFuelInterface) EXPECT() *MockFuelInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFuelInterface) Create(fuelInput models.CreateFuelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", fuelInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFuelInterfaceMockRecorder) Create(fuelInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFuelInterface)(nil).Create), fuelInput)
+}
+
+// Delete mocks base method.
+func (m *MockFuelInterface) Delete(code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFuelInterfaceMockRecorder) Delete(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFuelInterface)(nil).Delete), code)
+}
+
+// GetAll mocks base method.
+func (m *MockFuelInterface) GetAll(onlyActive bool) ([]models.Fuel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", onlyActive)
+	ret0, _ := ret[0].([]models.Fuel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockFuelInterfaceMockRecorder) GetAll(onlyActive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFuelInterface)(nil).GetAll), onlyActive)
+}
+
+// GetByCode mocks base method.
+func (m *MockFuelInterface) GetByCode(code string) (models.Fuel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", code)
+	ret0, _ := ret[0].(models.Fuel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockFuelInterfaceMockRecorder) GetByCode(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockFuelInterface)(nil).GetByCode), code)
+}
+
+// GetPrices mocks base method.
+func (m *MockFuelInterface) GetPrices(code string) ([]models.FuelPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", code)
+	ret0, _ := ret[0].([]models.FuelPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockFuelInterfaceMockRecorder) GetPrices(code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockFuelInterface)(nil).GetPrices), code)
+}
+
+// SetPrice mocks base method.
+func (m *MockFuelInterface) SetPrice(code string, priceInput models.FuelPriceInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", code, priceInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockFuelInterfaceMockRecorder) SetPrice(code, priceInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockFuelInterface)(nil).SetPrice), code, priceInput)
+}
+
+// Update mocks base method.
+func (m *MockFuelInterface) Update(code string, fuelInput models.UpdateFuelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", code, fuelInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFuelInterfaceMockRecorder) Update(code, fuelInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFuelInterface)(nil).Update), code, fuelInput)
+}
+
+// MockStock is a mock of Stock interface.
+type MockStock struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockMockRecorder
+}
+
+// MockStockMockRecorder is the mock recorder for MockStock.
+type MockStockMockRecorder struct {
+	mock *MockStock
+}
+
+// NewMockStock creates a new mock instance.
+func NewMockStock(ctrl *gomock.Controller) *MockStock {
+	mock := &MockStock{ctrl: ctrl}
+	mock.recorder = &MockStockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStock) EXPECT() *MockStockMockRecorder {
+	return m.recorder
+}
+
+// Balances mocks base method.
+func (m *MockStock) Balances(asOf *models.MyTime) ([]models.StockBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Balances", asOf)
+	ret0, _ := ret[0].([]models.StockBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Balances indicates an expected call of Balances.
+func (mr *MockStockMockRecorder) Balances(asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Balances", reflect.TypeOf((*MockStock)(nil).Balances), asOf)
+}
+
+// Movements mocks base method.
+func (m *MockStock) Movements(filter models.StockFilter) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Movements", filter)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Movements indicates an expected call of Movements.
+func (mr *MockStockMockRecorder) Movements(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Movements", reflect.TypeOf((*MockStock)(nil).Movements), filter)
+}
+
+// Receive mocks base method.
+func (m *MockStock) Receive(workerID int, receiptInput models.ReceiptInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", workerID, receiptInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockStockMockRecorder) Receive(workerID, receiptInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockStock)(nil).Receive), workerID, receiptInput)
+}
+
+// MockWaybills is a mock of Waybills interface.
+type MockWaybills struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaybillsMockRecorder
+}
+
+// MockWaybillsMockRecorder is the mock recorder for MockWaybills.
+type MockWaybillsMockRecorder struct {
+	mock *MockWaybills
+}
+
+// NewMockWaybills creates a new mock instance.
+func NewMockWaybills(ctrl *gomock.Controller) *MockWaybills {
+	mock := &MockWaybills{ctrl: ctrl}
+	mock.recorder = &MockWaybillsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaybills) EXPECT() *MockWaybillsMockRecorder {
+	return m.recorder
+}
+
+// ResetSequence mocks base method.
+func (m *MockWaybills) ResetSequence(year int, resetInput models.ResetSequenceInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSequence", year, resetInput)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSequence indicates an expected call of ResetSequence.
+func (mr *MockWaybillsMockRecorder) ResetSequence(year, resetInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSequence", reflect.TypeOf((*MockWaybills)(nil).ResetSequence), year, resetInput)
+}
+
+// Sequences mocks base method.
+func (m *MockWaybills) Sequences() ([]models.WaybillSequence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sequences")
+	ret0, _ := ret[0].([]models.WaybillSequence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sequences indicates an expected call of Sequences.
+func (mr *MockWaybillsMockRecorder) Sequences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sequences", reflect.TypeOf((*MockWaybills)(nil).Sequences))
+}
+
+// MockPeriods is a mock of Periods interface.
+type MockPeriods struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeriodsMockRecorder
+}
+
+// MockPeriodsMockRecorder is the mock recorder for MockPeriods.
+type MockPeriodsMockRecorder struct {
+	mock *MockPeriods
+}
+
+// NewMockPeriods creates a new mock instance.
+func NewMockPeriods(ctrl *gomock.Controller) *MockPeriods {
+	mock := &MockPeriods{ctrl: ctrl}
+	mock.recorder = &MockPeriodsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeriods) EXPECT() *MockPeriodsMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPeriods) Close(period time.Time, workerID int, input models.PeriodInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", period, workerID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPeriodsMockRecorder) Close(period, workerID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPeriods)(nil).Close), period, workerID, input)
+}
+
+// GetAll mocks base method.
+func (m *MockPeriods) GetAll() ([]models.PeriodClosing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.PeriodClosing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPeriodsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPeriods)(nil).GetAll))
+}
+
+// Reopen mocks base method.
+func (m *MockPeriods) Reopen(period time.Time, workerID int, input models.PeriodInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", period, workerID, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockPeriodsMockRecorder) Reopen(period, workerID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockPeriods)(nil).Reopen), period, workerID, input)
+}
+
+// MockDepartments is a mock of Departments interface.
+type MockDepartments struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepartmentsMockRecorder
+}
+
+// MockDepartmentsMockRecorder is the mock recorder for MockDepartments.
+type MockDepartmentsMockRecorder struct {
+	mock *MockDepartments
+}
+
+// NewMockDepartments creates a new mock instance.
+func NewMockDepartments(ctrl *gomock.Controller) *MockDepartments {
+	mock := &MockDepartments{ctrl: ctrl}
+	mock.recorder = &MockDepartmentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepartments) EXPECT() *MockDepartmentsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDepartments) Create(departmentInput models.CreateDepartmentInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", departmentInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDepartmentsMockRecorder) Create(departmentInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDepartments)(nil).Create), departmentInput)
+}
+
+// GetAll mocks base method.
+func (m *MockDepartments) GetAll() ([]models.Department, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Department)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDepartmentsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDepartments)(nil).GetAll))
+}
+
+// MockTags is a mock of Tags interface.
+type MockTags struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsMockRecorder
+}
+
+// MockTagsMockRecorder is the mock recorder for MockTags.
+type MockTagsMockRecorder struct {
+	mock *MockTags
+}
+
+// NewMockTags creates a new mock instance.
+func NewMockTags(ctrl *gomock.Controller) *MockTags {
+	mock := &MockTags{ctrl: ctrl}
+	mock.recorder = &MockTagsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTags) EXPECT() *MockTagsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTags) Create(tagInput models.CreateTagInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", tagInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagsMockRecorder) Create(tagInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTags)(nil).Create), tagInput)
+}
+
+// Delete mocks base method.
+func (m *MockTags) Delete(tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagsMockRecorder) Delete(tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTags)(nil).Delete), tagID)
+}
+
+// GetAll mocks base method.
+func (m *MockTags) GetAll() ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTags)(nil).GetAll))
+}
+
+// MockCustomFields is a mock of CustomFields interface.
+type MockCustomFields struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldsMockRecorder
+}
+
+// MockCustomFieldsMockRecorder is the mock recorder for MockCustomFields.
+type MockCustomFieldsMockRecorder struct {
+	mock *MockCustomFields
+}
+
+// NewMockCustomFields creates a new mock instance.
+func NewMockCustomFields(ctrl *gomock.Controller) *MockCustomFields {
+	mock := &MockCustomFields{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomFields) EXPECT() *MockCustomFieldsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomFields) Create(fieldInput models.CreateCustomFieldInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", fieldInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldsMockRecorder) Create(fieldInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomFields)(nil).Create), fieldInput)
+}
+
+// Delete mocks base method.
+func (m *MockCustomFields) Delete(fieldID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fieldID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldsMockRecorder) Delete(fieldID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomFields)(nil).Delete), fieldID)
+}
+
+// GetAll mocks base method.
+func (m *MockCustomFields) GetAll() ([]models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCustomFieldsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCustomFields)(nil).GetAll))
+}
+
+// MockLimits is a mock of Limits interface.
+type MockLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitsMockRecorder
+}
+
+// MockLimitsMockRecorder is the mock recorder for MockLimits.
+type MockLimitsMockRecorder struct {
+	mock *MockLimits
+}
+
+// NewMockLimits creates a new mock instance.
+func NewMockLimits(ctrl *gomock.Controller) *MockLimits {
+	mock := &MockLimits{ctrl: ctrl}
+	mock.recorder = &MockLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimits) EXPECT() *MockLimitsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLimits) Create(limitInput models.CreateLimitInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", limitInput)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLimitsMockRecorder) Create(limitInput interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLimits)(nil).Create), limitInput)
+}
+
+// Dashboard mocks base method.
+func (m *MockLimits) Dashboard(date models.MyTime) ([]models.LimitUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dashboard", date)
+	ret0, _ := ret[0].([]models.LimitUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dashboard indicates an expected call of Dashboard.
+func (mr *MockLimitsMockRecorder) Dashboard(date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dashboard", reflect.TypeOf((*MockLimits)(nil).Dashboard), date)
+}
+
+// Delete mocks base method.
+func (m *MockLimits) Delete(limitID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", limitID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLimitsMockRecorder) Delete(limitID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLimits)(nil).Delete), limitID)
+}
+
+// GetAll mocks base method.
+func (m *MockLimits) GetAll() ([]models.Limit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Limit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLimitsMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLimits)(nil).GetAll))
+}
+
+// MockReporting is a mock of Reporting interface.
+type MockReporting struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportingMockRecorder
+}
+
+// MockReportingMockRecorder is the mock recorder for MockReporting.
+type MockReportingMockRecorder struct {
+	mock *MockReporting
+}
+
+// NewMockReporting creates a new mock instance.
+func NewMockReporting(ctrl *gomock.Controller) *MockReporting {
+	mock := &MockReporting{ctrl: ctrl}
+	mock.recorder = &MockReportingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReporting) EXPECT() *MockReportingMockRecorder {
+	return m.recorder
+}
+
+// FuelReport mocks base method.
+func (m *MockReporting) FuelReport(filter models.FuelReportFilter) (models.FuelReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuelReport", filter)
+	ret0, _ := ret[0].(models.FuelReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FuelReport indicates an expected call of FuelReport.
+func (mr *MockReportingMockRecorder) FuelReport(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuelReport", reflect.TypeOf((*MockReporting)(nil).FuelReport), filter)
+}
+
+// MockPrinting is a mock of Printing interface.
+type MockPrinting struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrintingMockRecorder
+}
+
+// MockPrintingMockRecorder is the mock recorder for MockPrinting.
+type MockPrintingMockRecorder struct {
+	mock *MockPrinting
+}
+
+// NewMockPrinting creates a new mock instance.
+func NewMockPrinting(ctrl *gomock.Controller) *MockPrinting {
+	mock := &MockPrinting{ctrl: ctrl}
+	mock.recorder = &MockPrintingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrinting) EXPECT() *MockPrintingMockRecorder {
+	return m.recorder
+}
+
+// WaybillPDF mocks base method.
+func (m *MockPrinting) WaybillPDF(docID int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaybillPDF", docID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaybillPDF indicates an expected call of WaybillPDF.
+func (mr *MockPrintingMockRecorder) WaybillPDF(docID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaybillPDF", reflect.TypeOf((*MockPrinting)(nil).WaybillPDF), docID)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Notify", message)
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), message)
+}
+
+// NotifyWorker mocks base method.
+func (m *MockNotifier) NotifyWorker(workerID int, message string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyWorker", workerID, message)
+}
+
+// NotifyWorker indicates an expected call of NotifyWorker.
+func (mr *MockNotifierMockRecorder) NotifyWorker(workerID, message interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGSMInterface)(nil).Update), docID, docInput)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWorker", reflect.TypeOf((*MockNotifier)(nil).NotifyWorker), workerID, message)
 }
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type OrganizationService struct {
+	repos *repositories.Repository
+}
+
+func NewOrganizationService(repos *repositories.Repository) *OrganizationService {
+	return &OrganizationService{repos: repos}
+}
+
+func (s *OrganizationService) Create(organizationInput models.CreateOrganizationInput) (int, error) {
+	return s.repos.OrganizationInterface.Create(models.Organization{Name: organizationInput.Name})
+}
+
+func (s *OrganizationService) GetAll() ([]models.Organization, error) {
+	return s.repos.OrganizationInterface.GetAll()
+}
+
+// CreateWorker creates the worker in the organization, usually its first admin.
+func (s *OrganizationService) CreateWorker(orgID int, workerInput models.CreateWorkerInput) (int, error) {
+	if _, err := s.repos.OrganizationInterface.GetByID(orgID); err != nil {
+		return 0, err
+	}
+
+	return NewAdminService(s.repos.Tenant(orgID)).CreateWorker(workerInput)
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"time"
+)
+
+type PeriodService struct {
+	repos *repositories.Repository
+}
+
+func NewPeriodService(repos *repositories.Repository) *PeriodService {
+	return &PeriodService{repos: repos}
+}
+
+func (s *PeriodService) GetAll() ([]models.PeriodClosing, error) {
+	return s.repos.PeriodInterface.GetAll()
+}
+
+// Close locks documents issued in the period, they can't be created, updated, moved to the trash, restored
+// or purged until it's reopened.
+func (s *PeriodService) Close(period time.Time, workerID int, input models.PeriodInput) error {
+	return s.repos.PeriodInterface.Close(workerID, period, input.Reason)
+}
+
+func (s *PeriodService) Reopen(period time.Time, workerID int, input models.PeriodInput) error {
+	return s.repos.PeriodInterface.Reopen(workerID, period, input.Reason)
+}
+
+// checkPeriod refuses changes of documents issued in a closed period, it's only a preliminary check,
+// the repository checks the period again in the transaction which changes the document.
+func checkPeriod(repos *repositories.Repository, issueDate models.MyTime) error {
+	closed, err := repos.PeriodInterface.IsClosed(models.PeriodOf(issueDate))
+	if err != nil {
+		return err
+	}
+
+	if closed {
+		return models.ErrPeriodClosed
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"github.com/HeadHardener/tp_lab/internal/pkg/pdf"
+	"os"
+	"text/template"
+	"time"
+)
+
+var printFuncs = template.FuncMap{
+	"date": func(mt models.MyTime) string {
+		return time.Time(mt).Format("02.01.2006")
+	},
+	"datetime": func(t time.Time) string {
+		return t.Format("02.01.2006 15:04")
+	},
+}
+
+type PrintService struct {
+	repos           *repositories.Repository
+	waybillTemplate string
+}
+
+func NewPrintService(repos *repositories.Repository, waybillTemplate string) *PrintService {
+	return &PrintService{
+		repos:           repos,
+		waybillTemplate: waybillTemplate,
+	}
+}
+
+// WaybillPDF renders the document with the waybill layout, the layout is read on every call,
+// so it can be changed without restarting the server. Texts of the layout commands are
+// templates filled in with the document after the layout is parsed, so the document values
+// never become layout commands.
+func (s *PrintService) WaybillPDF(docID int) ([]byte, error) {
+	document, err := s.repos.GSMInterface.GetByID(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(s.waybillTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := pdf.ParseLayout(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"Document":  document,
+		"PrintedAt": time.Now(),
+	}
+
+	page, err := layout.Render(func(text string) (string, error) {
+		tmpl, err := template.New("waybill").Funcs(printFuncs).Parse(text)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+
+		return buf.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Bytes(), nil
+}
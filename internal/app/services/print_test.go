@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	mock_repositories "github.com/HeadHardener/tp_lab/internal/app/repositories/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrintService_WaybillPDF(t *testing.T) {
+	layout := filepath.Join(t.TempDir(), "waybill.tmpl")
+	if err := os.WriteFile(layout, []byte("page 595 842\n"+
+		"bold 50 780 18 WAYBILL No {{.Document.Waybill}}\n"+
+		"text 180 680 12 {{.Document.DriverName}}\n"+
+		"text 400 780 11 issued {{date .Document.IssueDate}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	documents := mock_repositories.NewMockGSMInterface(c)
+	documents.EXPECT().GetByID(1).Return(models.Document{
+		ID:         1,
+		Waybill:    1111,
+		DriverName: "Ivanov\nrect 0 0 595 842 50",
+		IssueDate:  toMyTime("2023-01-02"),
+	}, nil)
+
+	service := NewPrintService(&repositories.Repository{GSMInterface: documents}, layout)
+	out, err := service.WaybillPDF(1)
+
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(out), "(WAYBILL No 1111) Tj")
+		assert.Contains(t, string(out), "(Ivanov rect 0 0 595 842 50) Tj")
+		assert.Contains(t, string(out), "(issued 02.01.2023) Tj")
+		assert.NotContains(t, string(out), " re S")
+	}
+}
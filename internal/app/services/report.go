@@ -0,0 +1,40 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type ReportService struct {
+	repos *repositories.Repository
+}
+
+func NewReportService(repos *repositories.Repository) *ReportService {
+	return &ReportService{repos: repos}
+}
+
+func (s *ReportService) FuelReport(filter models.FuelReportFilter) (models.FuelReport, error) {
+	rows, err := s.repos.ReportInterface.FuelConsumption(filter)
+	if err != nil {
+		return models.FuelReport{}, err
+	}
+
+	report := models.FuelReport{
+		From:    filter.From,
+		To:      filter.To,
+		GroupBy: filter.GroupBy,
+		Rows:    rows,
+	}
+
+	if filter.Grouped(models.GroupByPeriod) {
+		report.Period = filter.Period
+	}
+
+	for _, row := range rows {
+		report.Documents += row.Documents
+		report.GasAmount += row.GasAmount
+		report.Cost += row.Cost
+	}
+
+	return report, nil
+}
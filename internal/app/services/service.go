@@ -1,8 +1,12 @@
 package services
 
 import (
+	"github.com/HeadHardener/tp_lab/configs"
 	"github.com/HeadHardener/tp_lab/internal/app/models"
 	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"github.com/HeadHardener/tp_lab/internal/pkg/storage"
+	"io"
+	"time"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
@@ -15,29 +19,214 @@ type Authorization interface {
 type Administration interface {
 	CreateWorker(workerInput models.CreateWorkerInput) (int, error)
 	GetAll() ([]models.Worker, error)
+	ExportWorkers(fn func(models.Worker) error) error
 	GetByID(workerID int) (models.Worker, error)
-	UpdateWorker(workerID int, workerInput models.UpdateWorkerInput) error
+	UpdateWorker(workerID, version int, workerInput models.UpdateWorkerInput) error
+}
+
+// Organizations is used by super-admins to manage tenants.
+type Organizations interface {
+	Create(organizationInput models.CreateOrganizationInput) (int, error)
+	GetAll() ([]models.Organization, error)
+	CreateWorker(orgID int, workerInput models.CreateWorkerInput) (int, error)
 }
 
 type GSMInterface interface {
 	Create(workerID int, docInput models.CreateDocInput) (int, error)
-	GetAll() ([]models.Document, error)
+	Import(workerID int, rows []models.ImportRow, dryRun bool) (models.ImportReport, error)
+	GetAll(filter models.DocumentFilter) (models.DocumentList, error)
+	Export(filter models.DocumentFilter, fn func(models.Document) error) error
 	GetByID(docID int) (models.Document, error)
-	GetAllWithID(workerID int) ([]models.Document, error)
-	Update(docID int, docInput models.UpdateDocInput) error
-	Delete(docID int) error
+	GetAllWithID(workerID int, filter models.DocumentFilter) (models.DocumentList, error)
+	Update(docID, workerID, version int, docInput models.UpdateDocInput) error
+	UpdateOwn(docID, version int, worker models.WorkerAttributes, docInput models.UpdateDocInput) error
+	Transition(docID int, worker models.WorkerAttributes, input models.TransitionInput) error
+	Transitions(docID int) ([]models.Transition, error)
+	History(docID int) ([]models.TimelineEntry, error)
+	RestoreRevision(docID, revisionID, workerID int) error
+	Delete(docID, workerID int) error
+	Trash(filter models.DocumentFilter) (models.DocumentList, error)
+	Restore(docID, workerID int) error
+	Purge(olderThanDays *int) (int, error)
+}
+
+// DocumentAccess reads documents on behalf of the worker, documents out of the worker's
+// scope fail with models.ErrDocumentNotFound as if they didn't exist.
+type DocumentAccess interface {
+	GetAll(worker models.WorkerAttributes, filter models.DocumentFilter) (models.DocumentList, error)
+	Export(worker models.WorkerAttributes, filter models.DocumentFilter, fn func(models.Document) error) error
+	GetByID(worker models.WorkerAttributes, docID int) (models.Document, error)
+	Transitions(worker models.WorkerAttributes, docID int) ([]models.Transition, error)
+	History(worker models.WorkerAttributes, docID int) ([]models.TimelineEntry, error)
+	Attachments(worker models.WorkerAttributes, docID int) ([]models.Attachment, error)
+	Download(worker models.WorkerAttributes, docID, attachmentID int) (models.Attachment, io.ReadCloser, error)
+	WaybillPDF(worker models.WorkerAttributes, docID int) ([]byte, error)
+	UpdateOwn(worker models.WorkerAttributes, docID, version int, docInput models.UpdateDocInput) error
+	Transition(worker models.WorkerAttributes, docID int, input models.TransitionInput) error
+	Upload(worker models.WorkerAttributes, docID int, fileName string, content io.Reader) (models.Attachment, error)
+	DeleteAttachment(worker models.WorkerAttributes, docID, attachmentID int) error
+}
+
+// Comments are discussed on behalf of the worker, the document has to be in the worker's scope.
+type Comments interface {
+	GetAll(worker models.WorkerAttributes, docID int) ([]models.Comment, error)
+	Create(worker models.WorkerAttributes, docID int, commentInput models.CommentInput) (models.Comment, error)
+	Update(worker models.WorkerAttributes, docID, commentID int, commentInput models.CommentInput) error
+	Delete(worker models.WorkerAttributes, docID, commentID int) error
+}
+
+type Attachments interface {
+	Upload(docID int, worker models.WorkerAttributes, fileName string, content io.Reader) (models.Attachment, error)
+	GetAll(docID int) ([]models.Attachment, error)
+	Download(docID, attachmentID int) (models.Attachment, io.ReadCloser, error)
+	Delete(docID, attachmentID int, worker models.WorkerAttributes) error
+}
+
+type VehicleInterface interface {
+	Create(vehicleInput models.CreateVehicleInput) (int, error)
+	GetAll(onlyActive bool) ([]models.Vehicle, error)
+	GetByID(vehicleID int) (models.Vehicle, error)
+	Update(vehicleID int, vehicleInput models.UpdateVehicleInput) error
+	Delete(vehicleID int) error
+}
+
+type FuelInterface interface {
+	Create(fuelInput models.CreateFuelInput) error
+	GetAll(onlyActive bool) ([]models.Fuel, error)
+	GetByCode(code string) (models.Fuel, error)
+	Update(code string, fuelInput models.UpdateFuelInput) error
+	Delete(code string) error
+	GetPrices(code string) ([]models.FuelPrice, error)
+	SetPrice(code string, priceInput models.FuelPriceInput) (int, error)
+}
+
+type Stock interface {
+	Receive(workerID int, receiptInput models.ReceiptInput) (int, error)
+	Movements(filter models.StockFilter) ([]models.StockMovement, error)
+	Balances(asOf *models.MyTime) ([]models.StockBalance, error)
+}
+
+type Waybills interface {
+	Sequences() ([]models.WaybillSequence, error)
+	ResetSequence(year int, resetInput models.ResetSequenceInput) error
+}
+
+type Periods interface {
+	GetAll() ([]models.PeriodClosing, error)
+	Close(period time.Time, workerID int, input models.PeriodInput) error
+	Reopen(period time.Time, workerID int, input models.PeriodInput) error
+}
+
+type Departments interface {
+	Create(departmentInput models.CreateDepartmentInput) (int, error)
+	GetAll() ([]models.Department, error)
+}
+
+type Tags interface {
+	Create(tagInput models.CreateTagInput) (int, error)
+	GetAll() ([]models.Tag, error)
+	Delete(tagID int) error
 }
 
+type CustomFields interface {
+	Create(fieldInput models.CreateCustomFieldInput) (int, error)
+	GetAll() ([]models.CustomField, error)
+	Delete(fieldID int) error
+}
+
+type Limits interface {
+	Create(limitInput models.CreateLimitInput) (int, error)
+	GetAll() ([]models.Limit, error)
+	Delete(limitID int) error
+	Dashboard(date models.MyTime) ([]models.LimitUsage, error)
+}
+
+type Reporting interface {
+	FuelReport(filter models.FuelReportFilter) (models.FuelReport, error)
+}
+
+type Printing interface {
+	WaybillPDF(docID int) ([]byte, error)
+}
+
+// Notifier delivers alerts to the workers of an organization, it mustn't block the request which raised them.
+type Notifier interface {
+	Notify(message string)
+	NotifyWorker(workerID int, message string)
+}
+
+// Notifiers returns the notifier of the organization.
+type Notifiers func(orgID int) Notifier
+
 type Service struct {
 	Authorization
+	Organizations
 	Administration
 	GSMInterface
+	DocumentAccess
+	Comments
+	Attachments
+	VehicleInterface
+	Departments
+	Tags
+	CustomFields
+	Limits
+	FuelInterface
+	Stock
+	Waybills
+	Periods
+	Reporting
+	Printing
+
+	tenant func(orgID int) *Service
+}
+
+func NewService(repos *repositories.Repository, storage storage.Storage, notifiers Notifiers,
+	conf *configs.ServiceConfig) *Service {
+	// services outside of organizations have nobody to notify, their notifier reaches no room
+	service := newService(repos, storage, notifiers(0), conf)
+	service.tenant = func(orgID int) *Service {
+		return newService(repos.Tenant(orgID), storage, notifiers(orgID), conf)
+	}
+
+	return service
 }
 
-func NewService(repos *repositories.Repository) *Service {
+// Tenant returns services working with the data of the organization only.
+// Services assembled by hand have no tenants and return themselves.
+func (s *Service) Tenant(orgID int) *Service {
+	if s.tenant == nil {
+		return s
+	}
+
+	return s.tenant(orgID)
+}
+
+func newService(repos *repositories.Repository, storage storage.Storage, notifier Notifier,
+	conf *configs.ServiceConfig) *Service {
+	documents := NewGSMService(repos, storage, notifier, conf)
+	attachments := NewAttachmentService(repos, storage, conf)
+	printing := NewPrintService(repos, conf.WaybillTemplate)
+	access := NewAccessService(repos, documents, attachments, printing)
+
 	return &Service{
-		Authorization:  NewAuthService(repos),
-		Administration: NewAdminService(repos),
-		GSMInterface:   NewGSMService(repos),
+		Authorization:    NewAuthService(repos),
+		Organizations:    NewOrganizationService(repos),
+		Administration:   NewAdminService(repos),
+		GSMInterface:     documents,
+		DocumentAccess:   access,
+		Comments:         NewCommentService(repos, access, notifier),
+		Attachments:      attachments,
+		VehicleInterface: NewVehicleService(repos),
+		Departments:      NewDepartmentService(repos),
+		Tags:             NewTagService(repos),
+		CustomFields:     NewCustomFieldService(repos),
+		Limits:           NewLimitService(repos),
+		FuelInterface:    NewFuelService(repos),
+		Stock:            NewStockService(repos),
+		Waybills:         NewWaybillService(repos),
+		Periods:          NewPeriodService(repos),
+		Reporting:        NewReportService(repos),
+		Printing:         printing,
 	}
 }
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+	"time"
+)
+
+type StockService struct {
+	repos *repositories.Repository
+}
+
+func NewStockService(repos *repositories.Repository) *StockService {
+	return &StockService{repos: repos}
+}
+
+func (s *StockService) Receive(workerID int, receiptInput models.ReceiptInput) (int, error) {
+	return s.repos.StockInterface.AddReceipt(workerID, models.StockMovement{
+		FuelCode: receiptInput.FuelCode,
+		Amount:   receiptInput.Amount,
+		MovedAt:  receiptInput.ReceivedAt,
+		Note:     receiptInput.Note,
+	})
+}
+
+func (s *StockService) Movements(filter models.StockFilter) ([]models.StockMovement, error) {
+	return s.repos.StockInterface.GetMovements(filter)
+}
+
+// Balances returns stock of every fuel as of the date, today if the date is nil.
+func (s *StockService) Balances(asOf *models.MyTime) ([]models.StockBalance, error) {
+	date := models.MyTime(time.Now())
+	if asOf != nil {
+		date = *asOf
+	}
+
+	return s.repos.StockInterface.GetBalances(date)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type TagService struct {
+	repos *repositories.Repository
+}
+
+func NewTagService(repos *repositories.Repository) *TagService {
+	return &TagService{repos: repos}
+}
+
+func (s *TagService) Create(tagInput models.CreateTagInput) (int, error) {
+	return s.repos.TagInterface.Create(models.Tag{Name: tagInput.Name})
+}
+
+func (s *TagService) GetAll() ([]models.Tag, error) {
+	return s.repos.TagInterface.GetAll()
+}
+
+func (s *TagService) Delete(tagID int) error {
+	return s.repos.TagInterface.Delete(tagID)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type VehicleService struct {
+	repos *repositories.Repository
+}
+
+func NewVehicleService(repos *repositories.Repository) *VehicleService {
+	return &VehicleService{repos: repos}
+}
+
+func (s *VehicleService) Create(vehicleInput models.CreateVehicleInput) (int, error) {
+	tankCapacity := vehicleInput.TankCapacity
+	vehicle := models.Vehicle{
+		PlateNumber:     vehicleInput.PlateNumber,
+		Model:           vehicleInput.Model,
+		FuelType:        vehicleInput.FuelType,
+		TankCapacity:    &tankCapacity,
+		Active:          true,
+		ConsumptionNorm: vehicleInput.ConsumptionNorm,
+		DepartmentID:    vehicleInput.DepartmentID,
+	}
+
+	if err := checkDepartment(s.repos, vehicle.DepartmentID); err != nil {
+		return 0, err
+	}
+
+	return s.repos.VehicleInterface.Create(vehicle)
+}
+
+func (s *VehicleService) GetAll(onlyActive bool) ([]models.Vehicle, error) {
+	return s.repos.VehicleInterface.GetAll(onlyActive)
+}
+
+func (s *VehicleService) GetByID(vehicleID int) (models.Vehicle, error) {
+	return s.repos.VehicleInterface.GetByID(vehicleID)
+}
+
+func (s *VehicleService) Update(vehicleID int, vehicleInput models.UpdateVehicleInput) error {
+	vehicle, err := s.repos.VehicleInterface.GetByID(vehicleID)
+	if err != nil {
+		return err
+	}
+
+	vehicleInput.ToVehicle(&vehicle)
+
+	if err := checkDepartment(s.repos, vehicleInput.DepartmentID); err != nil {
+		return err
+	}
+
+	return s.repos.VehicleInterface.Update(vehicle)
+}
+
+func (s *VehicleService) Delete(vehicleID int) error {
+	if _, err := s.repos.VehicleInterface.GetByID(vehicleID); err != nil {
+		return err
+	}
+
+	return s.repos.VehicleInterface.Delete(vehicleID)
+}
+
+// checkDepartment makes sure the department belongs to the organization of the repositories,
+// nil department is allowed.
+func checkDepartment(repos *repositories.Repository, departmentID *int) error {
+	if departmentID == nil {
+		return nil
+	}
+
+	_, err := repos.DepartmentInterface.GetByID(*departmentID)
+	return err
+}
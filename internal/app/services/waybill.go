@@ -0,0 +1,27 @@
+package services
+
+import (
+	"github.com/HeadHardener/tp_lab/internal/app/models"
+	"github.com/HeadHardener/tp_lab/internal/app/repositories"
+)
+
+type WaybillService struct {
+	repos *repositories.Repository
+}
+
+func NewWaybillService(repos *repositories.Repository) *WaybillService {
+	return &WaybillService{repos: repos}
+}
+
+func (s *WaybillService) Sequences() ([]models.WaybillSequence, error) {
+	return s.repos.WaybillInterface.GetSequences()
+}
+
+// ResetSequence makes the year continue from the number, numbers which are
+// already used are skipped on allocation, so it's safe to reset below them.
+func (s *WaybillService) ResetSequence(year int, resetInput models.ResetSequenceInput) error {
+	return s.repos.WaybillInterface.ResetSequence(models.WaybillSequence{
+		Year:      year,
+		LastValue: *resetInput.LastValue,
+	})
+}
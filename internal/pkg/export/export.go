@@ -0,0 +1,91 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Writer writes a table row by row to the underlying stream.
+type Writer interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer, delimiter rune) *CSVWriter {
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+
+	return &CSVWriter{w: cw}
+}
+
+func (c *CSVWriter) WriteHeader(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *CSVWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatValue(value)
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *CSVWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format("2006-01-02")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ParseDelimiter converts the delimiter query value to a rune, "tab" and "semicolon"
+// are accepted as readable aliases since both are awkward to pass in a query string.
+func ParseDelimiter(value string) (rune, error) {
+	switch value {
+	case "", ",":
+		return ',', nil
+	case ";", "semicolon":
+		return ';', nil
+	case "tab", "\t":
+		return '\t', nil
+	case "|":
+		return '|', nil
+	default:
+		return 0, fmt.Errorf("unsupported delimiter %q", value)
+	}
+}
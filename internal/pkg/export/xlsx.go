@@ -0,0 +1,269 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cell styles declared in xlsxStyles
+const (
+	styleDefault = 0
+	styleDate    = 1
+	styleBold    = 2
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+</styleSheet>`
+
+	xlsxWorkbookHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="`
+	xlsxWorkbookTail = `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxSheetHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetTail = `</sheetData></worksheet>`
+)
+
+// excelEpoch is the day zero of spreadsheet date serial numbers.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// XLSXWriter streams a single sheet workbook, rows are written to the zip
+// as soon as they come, only the column totals are kept in memory.
+type XLSXWriter struct {
+	zw      *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+	columns []string
+	totals  map[string]bool
+	sums    []float64
+}
+
+// NewXLSXWriter starts a workbook with one sheet, totalColumns are the names of
+// the columns summed up into the totals row written on Close.
+func NewXLSXWriter(w io.Writer, sheetName string, totalColumns ...string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/workbook.xml", xlsxWorkbookHead + escape(sheetName) + xlsxWorkbookTail},
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(xlsxSheetHead); err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]bool, len(totalColumns))
+	for _, column := range totalColumns {
+		totals[column] = true
+	}
+
+	return &XLSXWriter{zw: zw, sheet: sheet, totals: totals}, nil
+}
+
+func (x *XLSXWriter) WriteHeader(columns []string) error {
+	x.columns = columns
+	x.sums = make([]float64, len(columns))
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+
+	return x.writeRow(values, styleBold)
+}
+
+func (x *XLSXWriter) WriteRow(values []interface{}) error {
+	for i, value := range values {
+		if i < len(x.columns) && x.totals[x.columns[i]] {
+			if number, ok := toFloat(value); ok {
+				x.sums[i] += number
+			}
+		}
+	}
+
+	return x.writeRow(values, styleDefault)
+}
+
+// Close writes the totals row if any total columns were requested and finishes the workbook.
+func (x *XLSXWriter) Close() error {
+	if len(x.totals) != 0 && len(x.columns) != 0 {
+		values := make([]interface{}, len(x.columns))
+		values[0] = "total"
+		for i, column := range x.columns {
+			if x.totals[column] {
+				values[i] = x.sums[i]
+			}
+		}
+
+		if err := x.writeRow(values, styleBold); err != nil {
+			return err
+		}
+	}
+
+	if _, err := x.sheet.WriteString(xlsxSheetTail); err != nil {
+		return err
+	}
+
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.zw.Close()
+}
+
+func (x *XLSXWriter) writeRow(values []interface{}, style int) error {
+	x.row++
+	rowNum := strconv.Itoa(x.row)
+
+	x.sheet.WriteString(`<row r="` + rowNum + `">`)
+	for i, value := range values {
+		ref := columnName(i) + rowNum
+		if err := x.writeCell(ref, value, style); err != nil {
+			return err
+		}
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+
+	return err
+}
+
+func (x *XLSXWriter) writeCell(ref string, value interface{}, style int) error {
+	styleAttr := ""
+	if style != styleDefault {
+		styleAttr = ` s="` + strconv.Itoa(style) + `"`
+	}
+
+	if number, ok := toFloat(value); ok {
+		_, err := x.sheet.WriteString(`<c r="` + ref + `"` + styleAttr + `><v>` +
+			strconv.FormatFloat(number, 'f', -1, 64) + `</v></c>`)
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		if style == styleDefault {
+			styleAttr = ` s="` + strconv.Itoa(styleDate) + `"`
+		}
+		serial := v.Sub(excelEpoch).Hours() / 24
+		_, err := x.sheet.WriteString(`<c r="` + ref + `"` + styleAttr + `><v>` +
+			strconv.FormatFloat(serial, 'f', -1, 64) + `</v></c>`)
+		return err
+	case bool:
+		flag := "0"
+		if v {
+			flag = "1"
+		}
+		_, err := x.sheet.WriteString(`<c r="` + ref + `"` + styleAttr + ` t="b"><v>` + flag + `</v></c>`)
+		return err
+	default:
+		text := formatValue(value)
+		if text == "" {
+			return nil
+		}
+		_, err := x.sheet.WriteString(`<c r="` + ref + `"` + styleAttr + ` t="inlineStr"><is><t xml:space="preserve">` +
+			escape(text) + `</t></is></c>`)
+		return err
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case *int:
+		if v == nil {
+			return 0, false
+		}
+		return float64(*v), true
+	case float64:
+		return v, true
+	case *float64:
+		if v == nil {
+			return 0, false
+		}
+		return *v, true
+	default:
+		return 0, false
+	}
+}
+
+// columnName converts zero based column index to the spreadsheet letters: 0 - A, 26 - AA.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
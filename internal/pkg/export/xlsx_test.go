@@ -0,0 +1,147 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+// readPart returns the content of the workbook part.
+func readPart(t *testing.T, workbook []byte, name string) string {
+	zr, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	odometer := 1500
+	var noCost *float64
+
+	var buf bytes.Buffer
+	x, err := NewXLSXWriter(&buf, "Fuel & oil", "gas_amount", "cost")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, x.WriteHeader([]string{"driver_name", "issue_date", "gas_amount", "odometer", "cost", "approved"}))
+	assert.NoError(t, x.WriteRow([]interface{}{
+		"Ivanov <I.>", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), 10, &odometer, 25.5, true,
+	}))
+	assert.NoError(t, x.WriteRow([]interface{}{"", time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), 5, nil, noCost, false}))
+	assert.NoError(t, x.Close())
+
+	workbook := buf.Bytes()
+
+	t.Run("sheet", func(t *testing.T) {
+		assert.Equal(t, xlsxSheetHead+
+			`<row r="1">`+
+			`<c r="A1" s="2" t="inlineStr"><is><t xml:space="preserve">driver_name</t></is></c>`+
+			`<c r="B1" s="2" t="inlineStr"><is><t xml:space="preserve">issue_date</t></is></c>`+
+			`<c r="C1" s="2" t="inlineStr"><is><t xml:space="preserve">gas_amount</t></is></c>`+
+			`<c r="D1" s="2" t="inlineStr"><is><t xml:space="preserve">odometer</t></is></c>`+
+			`<c r="E1" s="2" t="inlineStr"><is><t xml:space="preserve">cost</t></is></c>`+
+			`<c r="F1" s="2" t="inlineStr"><is><t xml:space="preserve">approved</t></is></c>`+
+			`</row>`+
+			`<row r="2">`+
+			`<c r="A2" t="inlineStr"><is><t xml:space="preserve">Ivanov &lt;I.&gt;</t></is></c>`+
+			`<c r="B2" s="1"><v>44928</v></c>`+
+			`<c r="C2"><v>10</v></c>`+
+			`<c r="D2"><v>1500</v></c>`+
+			`<c r="E2"><v>25.5</v></c>`+
+			`<c r="F2" t="b"><v>1</v></c>`+
+			`</row>`+
+			`<row r="3">`+
+			`<c r="B3" s="1"><v>61</v></c>`+
+			`<c r="C3"><v>5</v></c>`+
+			`<c r="F3" t="b"><v>0</v></c>`+
+			`</row>`+
+			`<row r="4">`+
+			`<c r="A4" s="2" t="inlineStr"><is><t xml:space="preserve">total</t></is></c>`+
+			`<c r="C4" s="2"><v>15</v></c>`+
+			`<c r="E4" s="2"><v>25.5</v></c>`+
+			`</row>`+
+			xlsxSheetTail, readPart(t, workbook, "xl/worksheets/sheet1.xml"))
+	})
+
+	t.Run("workbook", func(t *testing.T) {
+		var parsed struct {
+			Sheets []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"sheets>sheet"`
+		}
+		assert.NoError(t, xml.Unmarshal([]byte(readPart(t, workbook, "xl/workbook.xml")), &parsed))
+		if assert.Len(t, parsed.Sheets, 1) {
+			assert.Equal(t, "Fuel & oil", parsed.Sheets[0].Name)
+		}
+	})
+
+	t.Run("well-formed parts", func(t *testing.T) {
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels",
+			"xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+			decoder := xml.NewDecoder(bytes.NewBufferString(readPart(t, workbook, name)))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if !assert.NoError(t, err, name) {
+					break
+				}
+			}
+		}
+	})
+}
+
+func TestXLSXWriter_noTotals(t *testing.T) {
+	var buf bytes.Buffer
+	x, err := NewXLSXWriter(&buf, "Documents")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, x.WriteHeader([]string{"gas_amount"}))
+	assert.NoError(t, x.WriteRow([]interface{}{10}))
+	assert.NoError(t, x.Close())
+
+	assert.Equal(t, xlsxSheetHead+
+		`<row r="1"><c r="A1" s="2" t="inlineStr"><is><t xml:space="preserve">gas_amount</t></is></c></row>`+
+		`<row r="2"><c r="A2"><v>10</v></c></row>`+
+		xlsxSheetTail, readPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml"))
+}
+
+func TestColumnName(t *testing.T) {
+	testTable := []struct {
+		index    int
+		expected string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tc := range testTable {
+		assert.Equal(t, tc.expected, columnName(tc.index))
+	}
+}
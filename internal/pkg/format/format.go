@@ -0,0 +1,89 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Format is a regional layout of a plate or phone number. Normalize returns the value
+// in the canonical form of the layout and false if the value doesn't match it.
+type Format struct {
+	Name      string
+	Normalize func(value string) (string, bool)
+}
+
+// Registry is an ordered set of formats, a value is normalized by the first format it matches.
+type Registry struct {
+	formats []Format
+}
+
+func NewRegistry(formats ...Format) *Registry {
+	return &Registry{formats: formats}
+}
+
+// Select returns a registry with the named formats only, in the given order.
+func (r *Registry) Select(names []string) (*Registry, error) {
+	selected := &Registry{}
+
+	for _, name := range names {
+		format, ok := r.lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown format %q, allowed: %s", name, strings.Join(r.Names(), ", "))
+		}
+		selected.formats = append(selected.formats, format)
+	}
+
+	return selected, nil
+}
+
+// Normalize returns the value in the canonical form of the first matching format.
+func (r *Registry) Normalize(value string) (string, bool) {
+	for _, format := range r.formats {
+		if normalized, ok := format.Normalize(value); ok {
+			return normalized, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.formats))
+	for _, format := range r.formats {
+		names = append(names, format.Name)
+	}
+
+	return names
+}
+
+func (r *Registry) lookup(name string) (Format, bool) {
+	for _, format := range r.formats {
+		if format.Name == name {
+			return format, true
+		}
+	}
+
+	return Format{}, false
+}
+
+// separators are dropped before matching, so "1234ab-7" and "1234 AB-7" are the same plate
+var separators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+// compactFormat matches the value without separators against the pattern
+// and expands the template with its groups to get the canonical form.
+func compactFormat(name, pattern, template string, prepare func(string) string) Format {
+	re := regexp.MustCompile(pattern)
+
+	return Format{
+		Name: name,
+		Normalize: func(value string) (string, bool) {
+			compact := prepare(separators.Replace(strings.TrimSpace(value)))
+			if !re.MatchString(compact) {
+				return "", false
+			}
+
+			return re.ReplaceAllString(compact, template), true
+		},
+	}
+}
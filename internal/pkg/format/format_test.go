@@ -0,0 +1,91 @@
+package format
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPhones_Normalize(t *testing.T) {
+	testTable := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"+375 29 123-45-67", "+375 29 123-45-67", true},
+		{"375291234567", "+375 29 123-45-67", true},
+		{" +375 (29) 123.45.67 ", "+375 29 123-45-67", true},
+		{"+7 912 345-67-89", "+7 912 345-67-89", true},
+		{"79123456789", "+7 912 345-67-89", true},
+		{"+49 151 1234 5678", "+4915112345678", true},
+		{"0291234567", "", false},
+		{"+375 29 12A-45-67", "", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.value, func(t *testing.T) {
+			normalized, ok := Phones.Normalize(tc.value)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+func TestPlates_Normalize(t *testing.T) {
+	testTable := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"1234 AB-7", "1234 AB-7", true},
+		{"1234ab7", "1234 AB-7", true},
+		{"1234 АВ-7", "1234 AB-7", true},
+		{"1234 AB-8", "", false},
+		{"а123вс 77", "A123BC 77", true},
+		{"A123BC777", "A123BC 777", true},
+		{"AA1234BB", "AA 1234 BB", true},
+		{"wa 12345", "WA 12345", true},
+		{"ABC-123", "ABC 123", true},
+		{"12", "", false},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.value, func(t *testing.T) {
+			normalized, ok := Plates.Normalize(tc.value)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+func TestRegistry_Select(t *testing.T) {
+	t.Run("order", func(t *testing.T) {
+		phones, err := Phones.Select([]string{"e164", "by"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"e164", "by"}, phones.Names())
+
+			// the first matching format wins
+			normalized, ok := phones.Normalize("375291234567")
+			assert.True(t, ok)
+			assert.Equal(t, "+375291234567", normalized)
+		}
+	})
+
+	t.Run("only selected formats", func(t *testing.T) {
+		plates, err := Plates.Select([]string{"by"})
+
+		if assert.NoError(t, err) {
+			_, ok := plates.Normalize("AA1234BB")
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := Plates.Select([]string{"by", "de"})
+
+		assert.EqualError(t, err, "unknown format \"de\", allowed: by, ru, ua, pl, lt")
+	})
+}
@@ -0,0 +1,17 @@
+package format
+
+import "strings"
+
+func preparePhone(value string) string {
+	return strings.TrimPrefix(value, "+")
+}
+
+// Phones contains every known phone number format.
+var Phones = NewRegistry(
+	// +375 29 123-45-67
+	compactFormat("by", `^(375)([0-9]{2})([0-9]{3})([0-9]{2})([0-9]{2})$`, "+$1 $2 $3-$4-$5", preparePhone),
+	// +7 912 345-67-89
+	compactFormat("ru", `^(7)([0-9]{3})([0-9]{3})([0-9]{2})([0-9]{2})$`, "+$1 $2 $3-$4-$5", preparePhone),
+	// +4915112345678
+	compactFormat("e164", `^([1-9][0-9]{7,14})$`, "+$1", preparePhone),
+)
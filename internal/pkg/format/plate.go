@@ -0,0 +1,27 @@
+package format
+
+import "strings"
+
+// cyrillic letters which look like latin ones, plates are stored with the latin letters
+var cyrillicToLatin = strings.NewReplacer(
+	"А", "A", "В", "B", "Е", "E", "І", "I", "К", "K", "М", "M", "Н", "H",
+	"О", "O", "Р", "P", "С", "C", "Т", "T", "У", "Y", "Х", "X",
+)
+
+func preparePlate(value string) string {
+	return cyrillicToLatin.Replace(strings.ToUpper(value))
+}
+
+// Plates contains every known plate number format.
+var Plates = NewRegistry(
+	// 1234 AB-7
+	compactFormat("by", `^([0-9]{4})([A-Z]{2})([1-7])$`, "$1 $2-$3", preparePlate),
+	// A123BC 77
+	compactFormat("ru", `^([ABEKMHOPCTYX])([0-9]{3})([ABEKMHOPCTYX]{2})([0-9]{2,3})$`, "$1$2$3 $4", preparePlate),
+	// AA 1234 BB
+	compactFormat("ua", `^([A-Z]{2})([0-9]{4})([A-Z]{2})$`, "$1 $2 $3", preparePlate),
+	// WA 12345
+	compactFormat("pl", `^([A-Z]{2,3})([0-9][0-9A-Z]{3,4})$`, "$1 $2", preparePlate),
+	// ABC 123
+	compactFormat("lt", `^([A-Z]{3})([0-9]{3})$`, "$1 $2", preparePlate),
+)
@@ -0,0 +1,253 @@
+// Package pdf renders simple one page documents made of text, lines and rectangles
+// using the standard Helvetica fonts, so no font files have to be embedded.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595
+	A4Height = 842
+)
+
+type Page struct {
+	Width   float64
+	Height  float64
+	content bytes.Buffer
+}
+
+func NewPage(width, height float64) *Page {
+	return &Page{Width: width, Height: height}
+}
+
+// Text draws text with its baseline starting at x, y, the origin is the bottom left corner.
+func (p *Page) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font, num(size), num(x), num(y), escape(toWinAnsi(text)))
+}
+
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(width), num(x), num(y), num(w), num(h))
+}
+
+// Bytes returns the complete pdf file with the page.
+func (p *Page) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+		"/Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 4 0 R >>", num(p.Width), num(p.Height)))
+	object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// Layout is a parsed layout description, texts of its commands are filled in on rendering,
+// so values put into the texts can't add commands to the layout.
+type Layout struct {
+	commands []command
+}
+
+type command struct {
+	line    int
+	name    string
+	numbers []float64
+	text    string
+}
+
+// ParseLayout parses the layout description, one command per line:
+//
+//	page <width> <height>
+//	text <x> <y> <size> <text...>
+//	bold <x> <y> <size> <text...>
+//	line <x1> <y1> <x2> <y2> [width]
+//	rect <x> <y> <w> <h> [width]
+//
+// Empty lines and lines starting with # are skipped.
+func ParseLayout(layout string) (*Layout, error) {
+	parsed := &Layout{}
+
+	scanner := bufio.NewScanner(strings.NewReader(layout))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, args, _ := strings.Cut(line, " ")
+		cmd, err := parseCommand(name, args)
+		if err != nil {
+			return nil, fmt.Errorf("layout line %d: %w", lineNum, err)
+		}
+		cmd.line = lineNum
+
+		parsed.commands = append(parsed.commands, cmd)
+	}
+
+	return parsed, scanner.Err()
+}
+
+// Render draws the layout on an A4 page unless the layout sets the page size,
+// fill gets the text of every text command and returns the text to draw.
+func (l *Layout) Render(fill func(text string) (string, error)) (*Page, error) {
+	page := NewPage(A4Width, A4Height)
+
+	for _, cmd := range l.commands {
+		n := cmd.numbers
+		switch cmd.name {
+		case "page":
+			page.Width, page.Height = n[0], n[1]
+		case "text", "bold":
+			text, err := fill(cmd.text)
+			if err != nil {
+				return nil, fmt.Errorf("layout line %d: %w", cmd.line, err)
+			}
+			page.Text(n[0], n[1], n[2], cmd.name == "bold", text)
+		case "line":
+			page.Line(n[0], n[1], n[2], n[3], n[4])
+		case "rect":
+			page.Rect(n[0], n[1], n[2], n[3], n[4])
+		}
+	}
+
+	return page, nil
+}
+
+func parseCommand(name, args string) (command, error) {
+	switch name {
+	case "page":
+		n, _, err := numbers(args, 2)
+		if err != nil {
+			return command{}, err
+		}
+		return command{name: name, numbers: n}, nil
+	case "text", "bold":
+		n, text, err := numbers(args, 3)
+		if err != nil {
+			return command{}, err
+		}
+		return command{name: name, numbers: n, text: text}, nil
+	case "line", "rect":
+		n, rest, err := numbers(args, 4)
+		if err != nil {
+			return command{}, err
+		}
+
+		width := 1.0
+		if rest != "" {
+			if width, err = strconv.ParseFloat(rest, 64); err != nil {
+				return command{}, errors.New("invalid line width")
+			}
+		}
+
+		return command{name: name, numbers: append(n, width)}, nil
+	default:
+		return command{}, fmt.Errorf("unknown command %q", name)
+	}
+}
+
+// numbers parses count leading numbers of args and returns them with the rest of args.
+func numbers(args string, count int) ([]float64, string, error) {
+	result := make([]float64, count)
+	rest := strings.TrimSpace(args)
+
+	for i := 0; i < count; i++ {
+		var field string
+		field, rest, _ = strings.Cut(rest, " ")
+		rest = strings.TrimSpace(rest)
+
+		n, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("expected %d numbers", count)
+		}
+		result[i] = n
+	}
+
+	return result, rest, nil
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func escape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", "", "\n", " ")
+	return replacer.Replace(s)
+}
+
+// toWinAnsi converts text to the single byte encoding of the standard fonts,
+// cyrillic letters are transliterated since the standard fonts don't have them.
+func toWinAnsi(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r < 0x80 || (r >= 0xA0 && r <= 0xFF) {
+			b.WriteByte(byte(r))
+			continue
+		}
+
+		if latin, ok := translit[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+
+		b.WriteByte('?')
+	}
+
+	return b.String()
+}
+
+var translit = func() map[rune]string {
+	lower := map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+		'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+		'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ў': "u",
+	}
+
+	table := make(map[rune]string, len(lower)*2)
+	for r, latin := range lower {
+		table[r] = latin
+		upper := []rune(strings.ToUpper(string(r)))[0]
+		if latin == "" {
+			table[upper] = ""
+			continue
+		}
+		table[upper] = strings.ToUpper(latin[:1]) + latin[1:]
+	}
+
+	return table
+}()
@@ -0,0 +1,123 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func echo(text string) (string, error) {
+	return text, nil
+}
+
+func TestParseLayout(t *testing.T) {
+	testTable := []struct {
+		name            string
+		layout          string
+		fill            func(text string) (string, error)
+		expectedWidth   float64
+		expectedContent string
+		expectedErr     string
+	}{
+		{
+			name: "ok",
+			layout: "# comment\n\npage 300 400\n" +
+				"text 10 20 12 Driver (night shift)\n" +
+				"bold 10 40 14 WAYBILL\n" +
+				"line 0 0 100 0\n" +
+				"rect 5 5 50 60 0.5\n",
+			fill:          echo,
+			expectedWidth: 300,
+			expectedContent: "BT /F1 12 Tf 10 20 Td (Driver \\(night shift\\)) Tj ET\n" +
+				"BT /F2 14 Tf 10 40 Td (WAYBILL) Tj ET\n" +
+				"1 w 0 0 m 100 0 l S\n" +
+				"0.5 w 5 5 50 60 re S\n",
+		},
+		{
+			name:   "filled text stays one text",
+			layout: "text 10 20 12 {{name}}\n",
+			fill: func(text string) (string, error) {
+				return "Ivanov\nrect 0 0 595 842 50\r\nline 0 0 1 1", nil
+			},
+			expectedWidth:   A4Width,
+			expectedContent: "BT /F1 12 Tf 10 20 Td (Ivanov rect 0 0 595 842 50 line 0 0 1 1) Tj ET\n",
+		},
+		{
+			name:            "cyrillic is transliterated",
+			layout:          "text 0 0 10 Иванов Щука\n",
+			fill:            echo,
+			expectedWidth:   A4Width,
+			expectedContent: "BT /F1 10 Tf 0 0 Td (Ivanov Shchuka) Tj ET\n",
+		},
+		{
+			name:        "unknown command",
+			layout:      "page 595 842\ncircle 1 2 3\n",
+			fill:        echo,
+			expectedErr: "layout line 2: unknown command \"circle\"",
+		},
+		{
+			name:        "missing numbers",
+			layout:      "text 10 twenty 12 Driver\n",
+			fill:        echo,
+			expectedErr: "layout line 1: expected 3 numbers",
+		},
+		{
+			name:        "invalid line width",
+			layout:      "line 0 0 1 1 wide\n",
+			fill:        echo,
+			expectedErr: "layout line 1: invalid line width",
+		},
+		{
+			name:   "fill error",
+			layout: "\ntext 0 0 10 {{bad}}\n",
+			fill: func(text string) (string, error) {
+				return "", errors.New("bad template")
+			},
+			expectedErr: "layout line 2: bad template",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			layout, err := ParseLayout(tc.layout)
+			var page *Page
+			if err == nil {
+				page, err = layout.Render(tc.fill)
+			}
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedWidth, page.Width)
+				assert.Equal(t, tc.expectedContent, page.content.String())
+			}
+		})
+	}
+}
+
+func TestPage_Bytes(t *testing.T) {
+	page := NewPage(A4Width, A4Height)
+	page.Text(10, 20, 12, false, "test")
+
+	out := string(page.Bytes())
+
+	assert.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	assert.Contains(t, out, "/MediaBox [0 0 595 842]")
+	assert.Contains(t, out, "stream\nBT /F1 12 Tf 10 20 Td (test) Tj ET\nendstream")
+
+	// every xref entry points at the start of its object
+	xref := out[strings.Index(out, "\nxref\n")+1:]
+	for i, entry := range strings.Split(xref, "\n")[3:9] {
+		var offset int
+		_, err := fmt.Sscanf(entry, "%010d", &offset)
+		if assert.NoError(t, err) {
+			assert.True(t, strings.HasPrefix(out[offset:], fmt.Sprintf("%d 0 obj", i+1)), entry)
+		}
+	}
+}
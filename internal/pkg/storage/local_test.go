@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingReader returns the content and then the error instead of io.EOF.
+type failingReader struct {
+	content io.Reader
+	err     error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.content.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+
+	return n, err
+}
+
+func newLocal(t *testing.T) (*Local, string) {
+	root := filepath.Join(t.TempDir(), "files")
+	local, err := NewLocal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return local, root
+}
+
+func read(t *testing.T, local *Local, key string) string {
+	file, err := local.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+// files lists every file under the root, slash separated and relative to it.
+func files(t *testing.T, root string) []string {
+	var names []string
+	err := filepath.WalkDir(root, func(name string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, name)
+		names = append(names, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return names
+}
+
+func TestLocal_Put(t *testing.T) {
+	local, root := newLocal(t)
+
+	assert.NoError(t, local.Put("documents/1/a", strings.NewReader("first")))
+	assert.Equal(t, "first", read(t, local, "documents/1/a"))
+
+	// the file is replaced as a whole
+	assert.NoError(t, local.Put("documents/1/a", strings.NewReader("second")))
+	assert.Equal(t, "second", read(t, local, "documents/1/a"))
+
+	// a failed upload leaves neither a partial file nor the temporary one
+	failed := &failingReader{content: strings.NewReader("partial"), err: errors.New("connection reset")}
+	assert.EqualError(t, local.Put("documents/1/b", failed), "connection reset")
+	assert.EqualError(t, local.Put("documents/1/a", &failingReader{
+		content: strings.NewReader("third"),
+		err:     errors.New("connection reset"),
+	}), "connection reset")
+
+	assert.Equal(t, []string{"documents/1/a"}, files(t, root))
+	assert.Equal(t, "second", read(t, local, "documents/1/a"))
+}
+
+func TestLocal_Get(t *testing.T) {
+	local, _ := newLocal(t)
+
+	_, err := local.Get("documents/1/missing")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLocal_Delete(t *testing.T) {
+	local, root := newLocal(t)
+
+	assert.NoError(t, local.Put("documents/1/a", strings.NewReader("a")))
+	assert.NoError(t, local.Put("documents/1/b", strings.NewReader("b")))
+	assert.NoError(t, local.Put("documents/2/a", strings.NewReader("a")))
+
+	assert.NoError(t, local.Delete("documents/1/a"))
+	assert.NoError(t, local.Delete("documents/1/a"), "deleting a missing file succeeds")
+	assert.Equal(t, []string{"documents/1/b", "documents/2/a"}, files(t, root))
+
+	assert.NoError(t, local.DeletePrefix("documents/1"))
+	assert.Equal(t, []string{"documents/2/a"}, files(t, root))
+}
+
+func TestLocal_invalidKeys(t *testing.T) {
+	local, root := newLocal(t)
+
+	outside := filepath.Join(filepath.Dir(root), "outside")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"", "/", "../outside", "documents/../../outside", "documents/.."} {
+		t.Run(key, func(t *testing.T) {
+			assert.Error(t, local.Put(key, strings.NewReader("x")))
+
+			_, err := local.Get(key)
+			assert.Error(t, err)
+
+			assert.Error(t, local.Delete(key))
+			assert.Error(t, local.DeletePrefix(key))
+		})
+	}
+
+	// absolute keys stay inside the root
+	assert.NoError(t, local.Put("/documents/3/a", strings.NewReader("a")))
+	assert.Equal(t, []string{"documents/3/a"}, files(t, root))
+
+	content, err := os.ReadFile(outside)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(content))
+}
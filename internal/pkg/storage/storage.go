@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("file doesn't exist")
+
+// Storage keeps file contents by keys, keys are slash separated paths
+// and a prefix groups files which are removed together. Deleting a key
+// which has no file succeeds, so a failed removal can be repeated.
+type Storage interface {
+	Put(key string, content io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	DeletePrefix(prefix string) error
+}